@@ -20,6 +20,7 @@ package accounts
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -45,13 +46,67 @@ type AccountS struct {
 // ListenAndServe keeps the service alive
 func (aS *AccountS) ListenAndServe(stopChan, cfgRld chan struct{}) {
 	for {
+		var sweepTimer <-chan time.Time // nil channel blocks forever, disabling the sweeper
+		if sweepIntvl := aS.cfg.AccountSCfg().SweepInterval; sweepIntvl > 0 {
+			sweepTimer = time.After(sweepIntvl)
+		}
 		select {
 		case <-stopChan:
 			return
 		case rld := <-cfgRld: // configuration was reloaded
 			cfgRld <- rld
+		case <-sweepTimer:
+			if err := aS.sweepExpiredBalances(time.Now()); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> when sweeping expired balances",
+						utils.AccountS, err.Error()))
+			}
+		}
+	}
+}
+
+// sweepExpiredBalances will remove or reset(based on config) the balances expired at atTime
+func (aS *AccountS) sweepExpiredBalances(atTime time.Time) (err error) {
+	var keys []string
+	if keys, err = aS.dm.DataDB().GetKeysForPrefix(utils.AccountProfilePrefix); err != nil {
+		return
+	}
+	for _, key := range keys {
+		tntID := strings.SplitN(strings.TrimPrefix(key, utils.AccountProfilePrefix), utils.InInFieldSep, 2)
+		if len(tntID) < 2 {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> invalid AccountProfile key: <%s>", utils.AccountS, key))
+			continue
+		}
+		if _, err = guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+			var acnt *utils.AccountProfile
+			if acnt, gErr = aS.dm.GetAccountProfile(tntID[0], tntID[1],
+				true, true, utils.NonTransactional); gErr != nil {
+				if gErr == utils.ErrNotFound { // removed in the meantime
+					gErr = nil
+				}
+				return
+			}
+			acnt = acnt.Clone() // do not modify the cached version in case of errors
+			bkp := acnt.AccountBalancesBackup()
+			if !expireBalances(acnt, atTime, aS.cfg.AccountSCfg().ExpiredBalances) {
+				return
+			}
+			if gErr = aS.dm.SetAccountProfile(acnt, false); gErr != nil {
+				return
+			}
+			aS.processAccountUpdate(acnt, bkp)
+			return
+		}, aS.cfg.GeneralCfg().LockingTimeout,
+			utils.ConcatenatedKey(utils.CacheAccountProfiles, tntID[1])); err != nil {
+			// do not abort the sweep of the other accounts
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> when sweeping the expired balances of AccountProfile: <%s>",
+					utils.AccountS, err.Error(), utils.ConcatenatedKey(tntID...)))
+			err = nil
 		}
 	}
+	return
 }
 
 // Shutdown is called to shutdown the service
@@ -140,8 +195,12 @@ func (aS *AccountS) matchingAccountsForEvent(tnt string, cgrEv *utils.CGREvent,
 func (aS *AccountS) accountDebitUsage(acnt *utils.AccountProfile, usage *decimal.Big,
 	cgrEv *utils.CGREvent) (ec *utils.EventCharges, err error) {
 	// Find balances matching event
+	evTime := eventTime(cgrEv)
 	blcsWithWeight := make(utils.BalancesWithWeight, 0, len(acnt.Balances))
 	for _, blnCfg := range acnt.Balances {
+		if !blnCfg.IsActiveAt(evTime) {
+			continue
+		}
 		var weight float64
 		if weight, err = engine.WeightFromDynamics(blnCfg.Weights,
 			aS.fltrS, cgrEv.Tenant, cgrEv.AsDataProvider()); err != nil {
@@ -199,6 +258,14 @@ func (aS *AccountS) accountsDebitUsage(acnts []*utils.AccountProfileWithWeight,
 		usage = decimal.New(int64(usgEv), 0)
 	}
 	acntBkps := make([]utils.AccountBalancesBackup, len(acnts))
+	var updIdxs []int // accounts updated, notified only if all debits succeed
+	defer func() {
+		if err == nil {
+			for _, i := range updIdxs {
+				aS.processAccountUpdate(acnts[i].AccountProfile, acntBkps[i])
+			}
+		}
+	}()
 	for i, acnt := range acnts {
 		if i == 0 {
 			ec = utils.NewEventCharges()
@@ -220,18 +287,212 @@ func (aS *AccountS) accountsDebitUsage(acnts []*utils.AccountProfileWithWeight,
 				restoreAccounts(aS.dm, acnts, acntBkps)
 				return
 			}
+			updIdxs = append(updIdxs, i)
 		}
+		ecDbt.BalanceCharges = balanceCharges(acnt.AccountProfile, acntBkps[i])
 		usage = utils.SubstractBig(usage, ecDbt.Usage.Big)
 		ec.Merge(ecDbt)
 	}
 	return
 }
 
-func (aS *AccountS) accountDebitCost(acnt *utils.AccountProfile,
+// accountDebitCost will debit the cost out of the concrete balances of an Account
+func (aS *AccountS) accountDebitCost(acnt *utils.AccountProfile, cost *decimal.Big,
 	cgrEv *utils.CGREvent) (ec *utils.EventCharges, err error) {
+	evNm := utils.MapStorage{
+		utils.MetaOpts: cgrEv.Opts,
+		utils.MetaReq:  cgrEv.Event,
+	}
+	// Find concrete balances matching event
+	evTime := eventTime(cgrEv)
+	blcsWithWeight := make(utils.BalancesWithWeight, 0, len(acnt.Balances))
+	for _, blnCfg := range acnt.Balances {
+		if blnCfg.Type != utils.MetaConcrete ||
+			!blnCfg.IsActiveAt(evTime) {
+			continue
+		}
+		var weight float64
+		if weight, err = engine.WeightFromDynamics(blnCfg.Weights,
+			aS.fltrS, cgrEv.Tenant, evNm); err != nil {
+			return
+		}
+		blcsWithWeight = append(blcsWithWeight, &utils.BalanceWithWeight{Balance: blnCfg, Weight: weight})
+	}
+	blcsWithWeight.Sort()
+	ec = utils.NewEventCharges()
+	ec.Cost = utils.NewDecimal(0, 0)
+	for _, blnCfg := range blcsWithWeight.Balances() {
+		if cost.Cmp(decimal.New(0, 0)) <= 0 {
+			return // no more debit
+		}
+		cB := newConcreteBalanceOperator(blnCfg, aS.fltrS, aS.connMgr,
+			aS.cfg.AccountSCfg().AttributeSConns, aS.cfg.AccountSCfg().RateSConns).(*concreteBalance)
+		var dbted *utils.Decimal
+		if dbted, _, err = cB.debitUnits(&utils.Decimal{Big: new(decimal.Big).Copy(cost)},
+			cgrEv.Tenant, evNm); err != nil {
+			if err == utils.ErrFilterNotPassingNoCaps {
+				err = nil
+				continue
+			}
+			return
+		}
+		cost = utils.SubstractBig(cost, dbted.Big)
+		ec.Cost.Big = utils.SumBig(ec.Cost.Big, dbted.Big)
+	}
+	return
+}
+
+// accountsDebitCost will debit the cost of the event out of multiple accounts
+func (aS *AccountS) accountsDebitCost(acnts []*utils.AccountProfileWithWeight,
+	cgrEv *utils.CGREvent, store bool) (ec *utils.EventCharges, err error) {
+	var cost *decimal.Big
+	if cost, err = eventCost(cgrEv); err != nil {
+		return
+	}
+	acntBkps := make([]utils.AccountBalancesBackup, len(acnts))
+	var updIdxs []int // accounts updated, notified only if all debits succeed
+	defer func() {
+		if err == nil {
+			for _, i := range updIdxs {
+				aS.processAccountUpdate(acnts[i].AccountProfile, acntBkps[i])
+			}
+		}
+	}()
+	defer func() {
+		if !store { // only simulation, put back the units in the cached accounts
+			for i, bkp := range acntBkps {
+				if bkp != nil {
+					acnts[i].AccountProfile.RestoreFromBackup(bkp)
+				}
+			}
+		}
+	}()
+	ec = utils.NewEventCharges()
+	ec.Cost = utils.NewDecimal(0, 0)
+	for i, acnt := range acnts {
+		if cost.Cmp(decimal.New(0, 0)) <= 0 {
+			return // no more debits
+		}
+		acntBkps[i] = acnt.AccountProfile.AccountBalancesBackup()
+		var ecDbt *utils.EventCharges
+		if ecDbt, err = aS.accountDebitCost(acnt.AccountProfile,
+			new(decimal.Big).Copy(cost), cgrEv); err != nil {
+			if store {
+				restoreAccounts(aS.dm, acnts, acntBkps)
+			}
+			return
+		}
+		if store && acnt.AccountProfile.BalancesAltered(acntBkps[i]) {
+			if err = aS.dm.SetAccountProfile(acnt.AccountProfile, false); err != nil {
+				restoreAccounts(aS.dm, acnts, acntBkps)
+				return
+			}
+			updIdxs = append(updIdxs, i)
+		}
+		ecDbt.BalanceCharges = balanceCharges(acnt.AccountProfile, acntBkps[i])
+		cost = utils.SubstractBig(cost, ecDbt.Cost.Big)
+		ec.Cost.Big = utils.SumBig(ec.Cost.Big, ecDbt.Cost.Big)
+		ec.Merge(ecDbt)
+	}
+	return
+}
+
+// refundCharges will give back the units recorded in BalanceCharges
+// uses the AccountProfile locks so it can be executed in parallel with the debits
+// the refundID is stored on the AccountProfiles so the same refund is applied only once
+func (aS *AccountS) refundCharges(tnt, refundID string, ec *utils.EventCharges) (err error) {
+	blncChrgs := make(map[string][]*utils.BalanceCharge) // group the charges per account
+	var acntIDs []string
+	for _, bC := range ec.BalanceCharges {
+		if _, has := blncChrgs[bC.AccountID]; !has {
+			acntIDs = append(acntIDs, bC.AccountID)
+		}
+		blncChrgs[bC.AccountID] = append(blncChrgs[bC.AccountID], bC)
+	}
+	var partExec bool
+	for _, acntID := range acntIDs {
+		if _, errRfnd := guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+			var acnt *utils.AccountProfile
+			if acnt, gErr = aS.dm.GetAccountProfile(tnt, acntID,
+				true, true, utils.NonTransactional); gErr != nil {
+				return
+			}
+			if _, has := acnt.RefundIDs[refundID]; has { // already refunded
+				return
+			}
+			acnt = acnt.Clone() // do not modify the cached version in case of errors
+			bkp := acnt.AccountBalancesBackup()
+			for _, bC := range blncChrgs[acntID] {
+				blnc, has := acnt.Balances[bC.BalanceID]
+				if !has {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> cannot refund %s units, missing balance <%s> on account <%s>",
+							utils.AccountS, bC.Units, bC.BalanceID, acnt.TenantID()))
+					partExec = true
+					continue
+				}
+				if blnc.Units == nil {
+					blnc.Units = utils.NewDecimal(0, 0)
+				}
+				blnc.Units.Big = utils.SumBig(blnc.Units.Big, bC.Units.Big)
+			}
+			addRefundID(acnt, refundID, time.Now(), aS.cfg.AccountSCfg().RefundIDsTTL)
+			if gErr = aS.dm.SetAccountProfile(acnt, false); gErr != nil {
+				return
+			}
+			aS.processAccountUpdate(acnt, bkp)
+			return
+		}, aS.cfg.GeneralCfg().LockingTimeout,
+			utils.ConcatenatedKey(utils.CacheAccountProfiles, acntID)); errRfnd != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> refunding account <%s:%s>",
+					utils.AccountS, errRfnd, tnt, acntID))
+			partExec = true
+		}
+	}
+	if partExec {
+		err = utils.ErrPartiallyExecuted
+	}
 	return
 }
 
+// processAccountUpdate will send the altered balances of the AccountProfile to ThresholdS and StatS
+func (aS *AccountS) processAccountUpdate(acnt *utils.AccountProfile, bkp utils.AccountBalancesBackup) {
+	thdSConns := aS.cfg.AccountSCfg().ThresholdSConns
+	stSConns := aS.cfg.AccountSCfg().StatSConns
+	if len(thdSConns) == 0 && len(stSConns) == 0 {
+		return
+	}
+	cgrEv := accountUpdateEvent(acnt, bkp)
+	if cgrEv == nil {
+		return
+	}
+	if len(thdSConns) != 0 &&
+		(len(acnt.ThresholdIDs) != 1 || acnt.ThresholdIDs[0] != utils.MetaNone) {
+		var tIDs []string
+		if err := aS.connMgr.Call(thdSConns, nil, utils.ThresholdSv1ProcessEvent,
+			&engine.ThresholdsArgsProcessEvent{
+				ThresholdIDs: acnt.ThresholdIDs,
+				CGREvent:     cgrEv,
+			}, &tIDs); err != nil &&
+			err.Error() != utils.ErrNotFound.Error() {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing event %+v with %s.",
+					utils.AccountS, err.Error(), cgrEv, utils.ThresholdS))
+		}
+	}
+	if len(stSConns) != 0 {
+		var stsIDs []string
+		if err := aS.connMgr.Call(stSConns, nil, utils.StatSv1ProcessEvent,
+			&engine.StatsArgsProcessEvent{CGREvent: cgrEv}, &stsIDs); err != nil &&
+			err.Error() != utils.ErrNotFound.Error() {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing event %+v with %s.",
+					utils.AccountS, err.Error(), cgrEv, utils.StatS))
+		}
+	}
+}
+
 // V1AccountProfilesForEvent returns the matching AccountProfiles for Event
 func (aS *AccountS) V1AccountProfilesForEvent(args *utils.ArgsAccountsForEvent, aps *[]*utils.AccountProfile) (err error) {
 	var acnts utils.AccountProfilesWithWeight
@@ -302,3 +563,91 @@ func (aS *AccountS) V1DebitUsage(args *utils.ArgsAccountsForEvent, eEc *utils.Ex
 	*eEc = *rcvEec
 	return
 }
+
+// V1RefundCharges will give back the units debited for an event
+// each CGRID is refunded only once per account, the refunded CGRIDs are stored on the AccountProfiles
+func (aS *AccountS) V1RefundCharges(args *utils.ArgsAccountsRefundCharges, rply *string) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.EventCharges == nil {
+		return utils.NewErrMandatoryIeMissing(utils.EventChargesString)
+	}
+	var cgrID string
+	if cgrID, err = args.CGREvent.FieldAsString(utils.CGRID); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.NewErrMandatoryIeMissing(utils.CGRID)
+		}
+		return
+	}
+	ec := args.EventCharges.AsEventCharges()
+	for _, bC := range ec.BalanceCharges {
+		if bC.Units == nil {
+			return utils.NewErrMandatoryIeMissing(utils.Units)
+		}
+		if bC.Units.Sign() < 0 { // only the debited units can be given back
+			return utils.NewErrServerError(
+				fmt.Errorf("negative units <%s> for balance <%s>", bC.Units, bC.BalanceID))
+		}
+	}
+	if err = aS.refundCharges(utils.FirstNonEmpty(args.CGREvent.Tenant, aS.cfg.GeneralCfg().DefaultTenant),
+		cgrID, ec); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
+
+// V1MaxCost returns the maximum cost which can be debited for the event, based on matching Accounts
+func (aS *AccountS) V1MaxCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	var acnts utils.AccountProfilesWithWeight
+	if acnts, err = aS.matchingAccountsForEvent(args.CGREvent.Tenant,
+		args.CGREvent, args.AccountIDs, true); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	defer func() {
+		for _, lkID := range acnts.LockIDs() {
+			guardian.Guardian.UnguardIDs(lkID)
+		}
+	}()
+	var procEC *utils.EventCharges
+	if procEC, err = aS.accountsDebitCost(acnts, args.CGREvent, false); err != nil {
+		return
+	}
+	var rcvEec *utils.ExtEventCharges
+	if rcvEec, err = procEC.AsExtEventCharges(); err != nil {
+		return
+	}
+	*eEc = *rcvEec
+	return
+}
+
+// V1DebitCost performs debit of the cost for the provided event
+func (aS *AccountS) V1DebitCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	var acnts utils.AccountProfilesWithWeight
+	if acnts, err = aS.matchingAccountsForEvent(args.CGREvent.Tenant,
+		args.CGREvent, args.AccountIDs, true); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	defer func() {
+		for _, lkID := range acnts.LockIDs() {
+			guardian.Guardian.UnguardIDs(lkID)
+		}
+	}()
+	var procEC *utils.EventCharges
+	if procEC, err = aS.accountsDebitCost(acnts, args.CGREvent, true); err != nil {
+		return
+	}
+	var rcvEec *utils.ExtEventCharges
+	if rcvEec, err = procEC.AsExtEventCharges(); err != nil {
+		return
+	}
+	*eEc = *rcvEec
+	return
+}
@@ -0,0 +1,669 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package accounts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestV1RefundCharges(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	aS := NewAccountS(cfg, fltrS, nil, dm)
+
+	acnt := &utils.AccountProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TestV1RefundCharges",
+		FilterIDs: []string{"*string:~*req.Account:1001"},
+		Balances: map[string]*utils.Balance{
+			"AbstractBalance": {
+				ID:    "AbstractBalance",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(int64(40*time.Second), 0),
+				CostIncrements: []*utils.CostIncrement{
+					{
+						Increment:    utils.NewDecimal(int64(time.Second), 0),
+						RecurrentFee: utils.NewDecimal(0, 0),
+					},
+				},
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, true); err != nil {
+		t.Fatal(err)
+	}
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestV1RefundCharges",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Usage:        "30s",
+			utils.CGRID:        "CGRID_1",
+		},
+	}
+	var eEc utils.ExtEventCharges
+	if err := aS.V1DebitUsage(&utils.ArgsAccountsForEvent{CGREvent: cgrEv}, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*utils.ExtBalanceCharge{
+		{
+			AccountID: "TestV1RefundCharges",
+			BalanceID: "AbstractBalance",
+			Units:     utils.Float64Pointer(float64(30 * time.Second)),
+		},
+	}
+	if utils.ToJSON(exp) != utils.ToJSON(eEc.BalanceCharges) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(eEc.BalanceCharges))
+	}
+	var rply string
+	for i := 0; i < 2; i++ { // the second refund should not credit the balance again
+		if err := aS.V1RefundCharges(&utils.ArgsAccountsRefundCharges{
+			CGREvent:     cgrEv,
+			EventCharges: &eEc,
+		}, &rply); err != nil {
+			t.Error(err)
+		} else if rply != utils.OK {
+			t.Errorf("Unexpected reply: %s", rply)
+		}
+		if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1RefundCharges",
+			true, true, utils.NonTransactional); err != nil {
+			t.Error(err)
+		} else if rcvUnts := rcv.Balances["AbstractBalance"].Units; rcvUnts.Compare(
+			utils.NewDecimal(int64(40*time.Second), 0)) != 0 {
+			t.Errorf("Expected %d, received %s", 40*time.Second, rcvUnts)
+		} else if _, has := rcv.RefundIDs["CGRID_1"]; !has {
+			t.Errorf("Expected the refund to be stored on the account, received %s", utils.ToJSON(rcv.RefundIDs))
+		}
+	}
+
+	if err := aS.V1RefundCharges(&utils.ArgsAccountsRefundCharges{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestV1RefundCharges",
+			Event:  map[string]interface{}{},
+		},
+		EventCharges: &eEc,
+	}, &rply); err == nil || err.Error() != "MANDATORY_IE_MISSING: [CGRID]" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := aS.V1RefundCharges(&utils.ArgsAccountsRefundCharges{
+		CGREvent: cgrEv,
+	}, &rply); err == nil || err.Error() != "MANDATORY_IE_MISSING: [EventCharges]" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for unts, expErr := range map[*float64]string{
+		nil:                      "MANDATORY_IE_MISSING: [Units]",
+		utils.Float64Pointer(-1): "SERVER_ERROR: negative units <-1> for balance <AbstractBalance>",
+	} {
+		if err := aS.V1RefundCharges(&utils.ArgsAccountsRefundCharges{
+			CGREvent: cgrEv,
+			EventCharges: &utils.ExtEventCharges{
+				BalanceCharges: []*utils.ExtBalanceCharge{
+					{
+						AccountID: "TestV1RefundCharges",
+						BalanceID: "AbstractBalance",
+						Units:     unts,
+					},
+				},
+			},
+		}, &rply); err == nil || err.Error() != expErr {
+			t.Errorf("Expected %s, received: %v", expErr, err)
+		}
+	}
+}
+
+func TestRefundChargesMissingBalance(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	aS := NewAccountS(cfg, nil, nil, dm)
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "TestRefundChargesMissingBalance",
+		Balances: map[string]*utils.Balance{
+			"ConcreteBalance": {
+				ID:    "ConcreteBalance",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(5, 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, false); err != nil {
+		t.Fatal(err)
+	}
+	ec := &utils.EventCharges{
+		BalanceCharges: []*utils.BalanceCharge{
+			{
+				AccountID: "TestRefundChargesMissingBalance",
+				BalanceID: "ConcreteBalance",
+				Units:     utils.NewDecimal(2, 0),
+			},
+			{
+				AccountID: "TestRefundChargesMissingBalance",
+				BalanceID: "RemovedBalance",
+				Units:     utils.NewDecimal(2, 0),
+			},
+		},
+	}
+	if err := aS.refundCharges("cgrates.org", "CGRID_1", ec); err != utils.ErrPartiallyExecuted {
+		t.Errorf("Expected %v, received %v", utils.ErrPartiallyExecuted, err)
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestRefundChargesMissingBalance",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcvUnts := rcv.Balances["ConcreteBalance"].Units; rcvUnts.Compare(utils.NewDecimal(7, 0)) != 0 {
+		t.Errorf("Expected 7, received %s", rcvUnts)
+	}
+}
+
+func TestV1DebitCost(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	aS := NewAccountS(cfg, fltrS, nil, dm)
+
+	acnt := &utils.AccountProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TestV1DebitCost",
+		FilterIDs: []string{"*string:~*req.Account:1003"},
+		Balances: map[string]*utils.Balance{
+			"AbstractBalance": {
+				ID:    "AbstractBalance",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(int64(40*time.Second), 0),
+			},
+			"ConcreteBalance1": {
+				ID: "ConcreteBalance1",
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 20,
+					},
+				},
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(2, 0),
+			},
+			"ConcreteBalance2": {
+				ID: "ConcreteBalance2",
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 10,
+					},
+				},
+				Type: utils.MetaConcrete,
+				UnitFactors: []*utils.UnitFactor{
+					{
+						Factor: utils.NewDecimal(100, 0), // balance stored in cents
+					},
+				},
+				Units: utils.NewDecimal(500, 0),
+			},
+			"ConcreteBalance3": {
+				ID:        "ConcreteBalance3",
+				FilterIDs: []string{"*string:~*req.Category:sms"},
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 30,
+					},
+				},
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(10, 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestV1DebitCost",
+			Event: map[string]interface{}{
+				utils.AccountField: "1003",
+				utils.Cost:         3.5,
+			},
+		},
+	}
+	var eEc utils.ExtEventCharges
+	if err := aS.V1MaxCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	} else if *eEc.Cost != 3.5 {
+		t.Errorf("Expected 3.5, received %+v", *eEc.Cost)
+	}
+	// MaxCost should not modify the balances
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1DebitCost",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcvUnts := rcv.Balances["ConcreteBalance1"].Units; rcvUnts.Compare(utils.NewDecimal(2, 0)) != 0 {
+		t.Errorf("Expected 2, received %s", rcvUnts)
+	}
+
+	eEc = utils.ExtEventCharges{}
+	if err := aS.V1DebitCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	exp := &utils.ExtEventCharges{
+		Cost: utils.Float64Pointer(3.5),
+		BalanceCharges: []*utils.ExtBalanceCharge{
+			{
+				AccountID: "TestV1DebitCost",
+				BalanceID: "ConcreteBalance1",
+				Units:     utils.Float64Pointer(2),
+			},
+			{
+				AccountID: "TestV1DebitCost",
+				BalanceID: "ConcreteBalance2",
+				Units:     utils.Float64Pointer(150),
+			},
+		},
+	}
+	if utils.ToJSON(exp) != utils.ToJSON(eEc) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(eEc))
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1DebitCost",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcvUnts := rcv.Balances["ConcreteBalance2"].Units; rcvUnts.Compare(utils.NewDecimal(350, 0)) != 0 {
+		t.Errorf("Expected 350, received %s", rcvUnts)
+	}
+
+	// not enough credit left, the *balanceLimit of 0 stops the debit
+	args.CGREvent.Event[utils.Cost] = 5
+	eEc = utils.ExtEventCharges{}
+	if err := aS.V1DebitCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	} else if *eEc.Cost != 3.5 {
+		t.Errorf("Expected 3.5, received %+v", *eEc.Cost)
+	}
+
+	delete(args.CGREvent.Event, utils.Cost)
+	if err := aS.V1DebitCost(args, &eEc); err == nil ||
+		err.Error() != "MANDATORY_IE_MISSING: [Cost]" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestDebitCostInactiveBalances(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	aS := NewAccountS(cfg, fltrS, nil, dm)
+
+	evTime := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	acnt := &utils.AccountProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TestDebitCostInactiveBalances",
+		FilterIDs: []string{"*string:~*req.Account:1004"},
+		Balances: map[string]*utils.Balance{
+			"ExpiredBalance": {
+				ID: "ExpiredBalance",
+				ActivationInterval: &utils.ActivationInterval{
+					ExpiryTime: evTime.Add(-time.Hour),
+				},
+				Weights: utils.DynamicWeights{{Weight: 30}},
+				Type:    utils.MetaConcrete,
+				Units:   utils.NewDecimal(10, 0),
+			},
+			"FutureBalance": {
+				ID: "FutureBalance",
+				ActivationInterval: &utils.ActivationInterval{
+					ActivationTime: evTime.Add(time.Hour),
+				},
+				Weights: utils.DynamicWeights{{Weight: 20}},
+				Type:    utils.MetaConcrete,
+				Units:   utils.NewDecimal(10, 0),
+			},
+			"ActiveBalance": {
+				ID: "ActiveBalance",
+				ActivationInterval: &utils.ActivationInterval{
+					ActivationTime: evTime.Add(-time.Hour),
+					ExpiryTime:     evTime.Add(time.Hour),
+				},
+				Weights: utils.DynamicWeights{{Weight: 10}},
+				Type:    utils.MetaConcrete,
+				Units:   utils.NewDecimal(10, 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestDebitCostInactiveBalances",
+			Time:   &evTime,
+			Event: map[string]interface{}{
+				utils.AccountField: "1004",
+				utils.Cost:         4,
+			},
+		},
+	}
+	var eEc utils.ExtEventCharges
+	if err := aS.V1DebitCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*utils.ExtBalanceCharge{
+		{
+			AccountID: "TestDebitCostInactiveBalances",
+			BalanceID: "ActiveBalance",
+			Units:     utils.Float64Pointer(4),
+		},
+	}
+	if utils.ToJSON(exp) != utils.ToJSON(eEc.BalanceCharges) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(eEc.BalanceCharges))
+	}
+}
+
+func TestSweepExpiredBalances(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	aS := NewAccountS(cfg, fltrS, nil, dm)
+
+	sweepTime := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "TestSweepExpiredBalances",
+		Balances: map[string]*utils.Balance{
+			"ExpiredBalance": {
+				ID: "ExpiredBalance",
+				ActivationInterval: &utils.ActivationInterval{
+					ExpiryTime: sweepTime.Add(-time.Hour),
+				},
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(10, 0),
+			},
+			"ActiveBalance": {
+				ID: "ActiveBalance",
+				ActivationInterval: &utils.ActivationInterval{
+					ExpiryTime: sweepTime.Add(time.Hour),
+				},
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(10, 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.AccountSCfg().ExpiredBalances = utils.MetaReset
+	if err := aS.sweepExpiredBalances(sweepTime); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestSweepExpiredBalances",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcvUnts := rcv.Balances["ExpiredBalance"].Units; rcvUnts.Compare(utils.NewDecimal(0, 0)) != 0 {
+		t.Errorf("Expected 0, received %s", rcvUnts)
+	} else if rcvUnts := rcv.Balances["ActiveBalance"].Units; rcvUnts.Compare(utils.NewDecimal(10, 0)) != 0 {
+		t.Errorf("Expected 10, received %s", rcvUnts)
+	}
+
+	cfg.AccountSCfg().ExpiredBalances = utils.MetaRemove
+	if err := aS.sweepExpiredBalances(sweepTime); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestSweepExpiredBalances",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if _, has := rcv.Balances["ExpiredBalance"]; has {
+		t.Errorf("ExpiredBalance should be removed, received %s", utils.ToJSON(rcv))
+	} else if _, has := rcv.Balances["ActiveBalance"]; !has {
+		t.Errorf("ActiveBalance should not be removed, received %s", utils.ToJSON(rcv))
+	}
+}
+
+type dataDBMockSweep struct {
+	*engine.DataDBMock
+	acnts map[string]*utils.AccountProfile
+}
+
+func (dbM *dataDBMockSweep) GetKeysForPrefix(prfx string) (keys []string, err error) {
+	return []string{prfx + "cgrates.org:BAD_ACNT", prfx + "cgrates.org:GOOD_ACNT"}, nil
+}
+
+func (dbM *dataDBMockSweep) GetAccountProfileDrv(tnt, id string) (*utils.AccountProfile, error) {
+	if acnt, has := dbM.acnts[id]; has {
+		return acnt, nil
+	}
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *dataDBMockSweep) SetAccountProfileDrv(acnt *utils.AccountProfile) error {
+	dbM.acnts[acnt.ID] = acnt
+	return nil
+}
+
+func TestSweepExpiredBalancesContinueOnError(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	sweepTime := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	dbM := &dataDBMockSweep{acnts: map[string]*utils.AccountProfile{
+		"GOOD_ACNT": {
+			Tenant: "cgrates.org",
+			ID:     "GOOD_ACNT",
+			Balances: map[string]*utils.Balance{
+				"ExpiredBalance": {
+					ID: "ExpiredBalance",
+					ActivationInterval: &utils.ActivationInterval{
+						ExpiryTime: sweepTime.Add(-time.Hour),
+					},
+					Type:  utils.MetaConcrete,
+					Units: utils.NewDecimal(10, 0),
+				},
+			},
+		},
+	}}
+	dm := engine.NewDataManager(dbM, cfg.CacheCfg(), nil)
+	aS := NewAccountS(cfg, engine.NewFilterS(cfg, nil, dm), nil, dm)
+	defer func() {
+		for _, id := range []string{"BAD_ACNT", "GOOD_ACNT"} {
+			engine.Cache.Remove(utils.CacheAccountProfiles, utils.ConcatenatedKey("cgrates.org", id),
+				true, utils.NonTransactional)
+		}
+	}()
+
+	if err := aS.sweepExpiredBalances(sweepTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := dbM.acnts["GOOD_ACNT"].Balances["ExpiredBalance"]; has {
+		t.Errorf("ExpiredBalance should be removed, received %s", utils.ToJSON(dbM.acnts["GOOD_ACNT"]))
+	}
+}
+
+func TestDebitUsageProcessAccountUpdate(t *testing.T) {
+	engine.Cache.Clear(nil)
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AccountSCfg().ThresholdSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)}
+	cfg.AccountSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)}
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	var thEv, stEv *utils.CGREvent
+	sTestMock := &testMockCall{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ThresholdSv1ProcessEvent: func(args interface{}, reply interface{}) error {
+				thEv = args.(*engine.ThresholdsArgsProcessEvent).CGREvent
+				return nil
+			},
+			utils.StatSv1ProcessEvent: func(args interface{}, reply interface{}) error {
+				stEv = args.(*engine.StatsArgsProcessEvent).CGREvent
+				return nil
+			},
+		},
+	}
+	chanInternal := make(chan rpcclient.ClientConnector, 1)
+	chanInternal <- sTestMock
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds): chanInternal,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats):      chanInternal,
+	})
+	aS := NewAccountS(cfg, fltrS, connMgr, dm)
+
+	acnt := &utils.AccountProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TestDebitUsageProcessAccountUpdate",
+		FilterIDs: []string{"*string:~*req.Account:1005"},
+		Balances: map[string]*utils.Balance{
+			"AbstractBalance": {
+				ID:    "AbstractBalance",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(int64(40*time.Second), 0),
+				CostIncrements: []*utils.CostIncrement{
+					{
+						Increment:    utils.NewDecimal(int64(time.Second), 0),
+						RecurrentFee: utils.NewDecimal(0, 0),
+					},
+				},
+			},
+			"UnusedBalance": {
+				ID:        "UnusedBalance",
+				FilterIDs: []string{"*string:~*req.Category:sms"},
+				Type:      utils.MetaConcrete,
+				Units:     utils.NewDecimal(10, 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestDebitUsageProcessAccountUpdate",
+			Event: map[string]interface{}{
+				utils.AccountField: "1005",
+				utils.Usage:        "30s",
+			},
+		},
+	}
+	var eEc utils.ExtEventCharges
+	if err := aS.V1DebitUsage(args, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	if thEv == nil {
+		t.Fatal("Expected event to be sent to ThresholdS")
+	}
+	expEv := map[string]interface{}{
+		utils.EventType:    utils.AccountUpdate,
+		utils.EventSource:  utils.AccountS,
+		utils.AccountField: "TestDebitUsageProcessAccountUpdate",
+		utils.BalanceIDs:   []string{"AbstractBalance"},
+		utils.BalancesFld: map[string]interface{}{
+			"AbstractBalance": map[string]interface{}{
+				utils.InitialUnits: float64(40 * time.Second),
+				utils.Units:        float64(10 * time.Second),
+			},
+		},
+	}
+	if !reflect.DeepEqual(expEv, thEv.Event) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expEv), utils.ToJSON(thEv.Event))
+	}
+	if stEv != thEv {
+		t.Errorf("Expected the same event sent to StatS, received %s", utils.ToJSON(stEv))
+	}
+
+	// MaxUsage does not alter the balances so no event is sent
+	thEv, stEv = nil, nil
+	args.CGREvent.Event[utils.Usage] = "5s"
+	if err := aS.V1MaxUsage(args, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	if thEv != nil || stEv != nil {
+		t.Errorf("Expected no event, received %s and %s", utils.ToJSON(thEv), utils.ToJSON(stEv))
+	}
+}
+
+func TestDebitCostProcessAccountUpdateOnSuccess(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AccountSCfg().ThresholdSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)}
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	var acntIDs []string
+	sTestMock := &testMockCall{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ThresholdSv1ProcessEvent: func(args interface{}, reply interface{}) error {
+				acntIDs = append(acntIDs, args.(*engine.ThresholdsArgsProcessEvent).Event[utils.AccountField].(string))
+				return nil
+			},
+		},
+	}
+	chanInternal := make(chan rpcclient.ClientConnector, 1)
+	chanInternal <- sTestMock
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds): chanInternal,
+	})
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	aS := NewAccountS(cfg, fltrS, connMgr, dm)
+
+	newAcnt := func(id string, weightFltrs []string) *utils.AccountProfileWithWeight {
+		return &utils.AccountProfileWithWeight{
+			AccountProfile: &utils.AccountProfile{
+				Tenant: "cgrates.org",
+				ID:     id,
+				Balances: map[string]*utils.Balance{
+					"ConcreteBalance": {
+						ID:      "ConcreteBalance",
+						Type:    utils.MetaConcrete,
+						Units:   utils.NewDecimal(5, 0),
+						Weights: utils.DynamicWeights{{FilterIDs: weightFltrs, Weight: 10}},
+					},
+				},
+			},
+		}
+	}
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestDebitCostProcessAccountUpdateOnSuccess",
+		Event: map[string]interface{}{
+			utils.Cost: 8,
+		},
+	}
+	// the second account fails so the update of the first one is not sent
+	if _, err := aS.accountsDebitCost([]*utils.AccountProfileWithWeight{
+		newAcnt("ACNT1", nil), newAcnt("ACNT2", []string{"*wrong:~*req.Account:1001"})},
+		cgrEv, true); err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(acntIDs) != 0 {
+		t.Errorf("Expected no account update, received %+v", acntIDs)
+	}
+	if _, err := aS.accountsDebitCost([]*utils.AccountProfileWithWeight{
+		newAcnt("ACNT1", nil), newAcnt("ACNT2", nil)}, cgrEv, true); err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{"ACNT1", "ACNT2"}; !reflect.DeepEqual(exp, acntIDs) {
+		t.Errorf("Expected %+v, received %+v", exp, acntIDs)
+	}
+}
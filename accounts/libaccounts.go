@@ -21,6 +21,8 @@ package accounts
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 
@@ -116,11 +118,16 @@ func processAttributeS(connMgr *engine.ConnManager, cgrEv *utils.CGREvent,
 }
 
 // rateSCostForEvent will process the event with RateS in order to get the cost
+// the volume counters of RateS are not updated since the event can be rated multiple times during one debit
 func rateSCostForEvent(connMgr *engine.ConnManager, cgrEv *utils.CGREvent,
 	rateSConns, rpIDs []string) (rplyCost *engine.RateProfileCost, err error) {
 	if len(rateSConns) == 0 {
 		return nil, utils.NewErrNotConnected(utils.RateS)
 	}
+	if _, has := cgrEv.Opts[utils.OptsRatesUpdateVolume]; has {
+		cgrEv = cgrEv.Clone()
+		delete(cgrEv.Opts, utils.OptsRatesUpdateVolume)
+	}
 	var tmpReply engine.RateProfileCost
 	if err = connMgr.Call(rateSConns, nil, utils.RateSv1CostForEvent,
 		&utils.ArgsCostForEvent{CGREvent: cgrEv, RateProfileIDs: rpIDs}, &tmpReply); err != nil {
@@ -318,6 +325,108 @@ func maxDebitUsageFromConcretes(cncrtBlncs []*concreteBalance, usage *decimal.Bi
 	return &utils.EventCharges{Usage: &utils.Decimal{usagePaid}}, nil
 }
 
+// eventCost returns the cost which should be debited for the event
+// the Cost field has priority over the *cost option
+func eventCost(cgrEv *utils.CGREvent) (cost *decimal.Big, err error) {
+	costIface, has := cgrEv.Event[utils.Cost]
+	if !has {
+		if costIface, has = cgrEv.Opts[utils.MetaCost]; !has {
+			return nil, utils.NewErrMandatoryIeMissing(utils.Cost)
+		}
+	}
+	var flt64Cost float64
+	if flt64Cost, err = utils.IfaceAsFloat64(costIface); err != nil {
+		return
+	}
+	return utils.NewDecimalFromFloat64(flt64Cost).Big, nil
+}
+
+// eventTime returns the time of the event, defaulting to current time if not specified
+func eventTime(cgrEv *utils.CGREvent) time.Time {
+	if cgrEv.Time != nil {
+		return *cgrEv.Time
+	}
+	return time.Now()
+}
+
+// expireBalances will apply the expiredAction(*remove or *reset) on the balances expired at atTime
+// returns true if the AccountProfile was modified
+func expireBalances(acnt *utils.AccountProfile, atTime time.Time, expiredAction string) (modified bool) {
+	for blncID, blnc := range acnt.Balances {
+		if !blnc.IsExpiredAt(atTime) {
+			continue
+		}
+		if expiredAction == utils.MetaReset {
+			if blnc.Units != nil && blnc.Units.Cmp(decimal.New(0, 0)) == 0 {
+				continue // already reset
+			}
+			blnc.Units = utils.NewDecimal(0, 0)
+		} else {
+			delete(acnt.Balances, blncID)
+		}
+		modified = true
+	}
+	return
+}
+
+// balanceCharges returns the units debited out of each balance by comparing the AccountProfile with its backup
+func balanceCharges(acnt *utils.AccountProfile, bkp utils.AccountBalancesBackup) (blncChrgs []*utils.BalanceCharge) {
+	for blncID, bkpUnts := range bkp {
+		blnc, has := acnt.Balances[blncID]
+		if !has ||
+			blnc.Units.Big.Cmp(bkpUnts) == 0 {
+			continue
+		}
+		blncChrgs = append(blncChrgs, &utils.BalanceCharge{
+			AccountID: acnt.ID,
+			BalanceID: blncID,
+			Units:     &utils.Decimal{Big: utils.SubstractBig(bkpUnts, blnc.Units.Big)},
+		})
+	}
+	sort.Slice(blncChrgs, func(i, j int) bool { return blncChrgs[i].BalanceID < blncChrgs[j].BalanceID })
+	return
+}
+
+// accountUpdateEvent builds the event sent to ThresholdS and StatS out of the balances altered since the backup
+// returns nil if no balance was altered
+func accountUpdateEvent(acnt *utils.AccountProfile, bkp utils.AccountBalancesBackup) *utils.CGREvent {
+	blncIDs := make([]string, 0, len(bkp))
+	blncs := make(map[string]interface{})
+	for blncID, bkpUnts := range bkp {
+		blnc, has := acnt.Balances[blncID]
+		if !has ||
+			blnc.Units.Big.Cmp(bkpUnts) == 0 {
+			continue
+		}
+		initUnts, _ := bkpUnts.Float64()
+		unts, _ := blnc.Units.Big.Float64()
+		blncIDs = append(blncIDs, blncID)
+		blncs[blncID] = map[string]interface{}{
+			utils.InitialUnits: initUnts,
+			utils.Units:        unts,
+		}
+	}
+	if len(blncIDs) == 0 {
+		return nil
+	}
+	sort.Strings(blncIDs)
+	return &utils.CGREvent{
+		Tenant: acnt.Tenant,
+		ID:     utils.GenUUID(),
+		Time:   utils.TimePointer(time.Now()),
+		Event: map[string]interface{}{
+			utils.EventType:    utils.AccountUpdate,
+			utils.EventSource:  utils.AccountS,
+			utils.AccountField: acnt.ID,
+			utils.BalanceIDs:   blncIDs,
+			utils.BalancesFld:  blncs,
+		},
+		Opts: map[string]interface{}{
+			utils.MetaEventType: utils.AccountUpdate,
+		},
+	}
+}
+
 // restoreAccounts will restore the accounts in DataDB out of their backups if present
 func restoreAccounts(dm *engine.DataManager,
 	acnts []*utils.AccountProfileWithWeight, bkps []utils.AccountBalancesBackup) {
@@ -333,3 +442,18 @@ func restoreAccounts(dm *engine.DataManager,
 		}
 	}
 }
+
+// addRefundID marks the refund as applied on the account, removing the markers older than ttl
+func addRefundID(acnt *utils.AccountProfile, refundID string, now time.Time, ttl time.Duration) {
+	if acnt.RefundIDs == nil {
+		acnt.RefundIDs = make(map[string]time.Time)
+	}
+	if ttl > 0 {
+		for id, rfndTime := range acnt.RefundIDs {
+			if now.Sub(rfndTime) > ttl {
+				delete(acnt.RefundIDs, id)
+			}
+		}
+	}
+	acnt.RefundIDs[refundID] = now
+}
@@ -199,6 +199,44 @@ func TestRateSCostForEvent2(t *testing.T) { // coverage purpose
 	}
 }
 
+func TestRateSCostForEventNoVolumeUpdate(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	var rcvOpts map[string]interface{}
+	sTestMock := &testMockCall{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.RateSv1CostForEvent: func(args interface{}, reply interface{}) error {
+				rcvOpts = args.(*utils.ArgsCostForEvent).Opts
+				return nil
+			},
+		},
+	}
+	chanInternal := make(chan rpcclient.ClientConnector, 1)
+	chanInternal <- sTestMock
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS): chanInternal,
+	})
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	cgrEvent := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestRateSCostForEventNoVolumeUpdate",
+		Opts: map[string]interface{}{
+			utils.OptsRatesUsage:        "1m",
+			utils.OptsRatesUpdateVolume: true,
+		},
+	}
+	if _, err := rateSCostForEvent(connMgr, cgrEvent,
+		[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if exp := map[string]interface{}{utils.OptsRatesUsage: "1m"}; !reflect.DeepEqual(exp, rcvOpts) {
+		t.Errorf("Expected %+v, received %+v", exp, rcvOpts)
+	}
+	if _, has := cgrEvent.Opts[utils.OptsRatesUpdateVolume]; !has {
+		t.Error("The option should not be removed from the original event")
+	}
+}
+
 func TestDebitUsageFromConcretes(t *testing.T) {
 	engine.Cache.Clear(nil)
 
@@ -473,3 +511,65 @@ func TestRestoreAccount3(t *testing.T) { //coverage purpose
 	})
 
 }
+
+func TestBalanceCharges(t *testing.T) {
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"AbstractBalance": {
+				ID:    "AbstractBalance",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(int64(30*time.Second), 0),
+			},
+			"ConcreteBalance": {
+				ID:    "ConcreteBalance",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(10, 0),
+			},
+		},
+	}
+	bkp := acnt.AccountBalancesBackup()
+	if rcv := balanceCharges(acnt, bkp); rcv != nil {
+		t.Errorf("Expected no charges, received %s", utils.ToJSON(rcv))
+	}
+	acnt.Balances["AbstractBalance"].Units = utils.NewDecimal(int64(10*time.Second), 0)
+	acnt.Balances["ConcreteBalance"].Units = utils.NewDecimalFromFloat64(8.5)
+	exp := []*utils.BalanceCharge{
+		{
+			AccountID: "1001",
+			BalanceID: "AbstractBalance",
+			Units:     utils.NewDecimal(int64(20*time.Second), 0),
+		},
+		{
+			AccountID: "1001",
+			BalanceID: "ConcreteBalance",
+			Units:     utils.NewDecimalFromFloat64(1.5),
+		},
+	}
+	if rcv := balanceCharges(acnt, bkp); utils.ToJSON(exp) != utils.ToJSON(rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestAddRefundID(t *testing.T) {
+	now := time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "TestAddRefundID",
+	}
+	addRefundID(acnt, "CGRID_1", now.Add(-2*time.Hour), time.Hour)
+	addRefundID(acnt, "CGRID_2", now.Add(-time.Minute), time.Hour)
+	addRefundID(acnt, "CGRID_3", now, time.Hour)
+	exp := map[string]time.Time{
+		"CGRID_2": now.Add(-time.Minute),
+		"CGRID_3": now,
+	}
+	if !reflect.DeepEqual(exp, acnt.RefundIDs) {
+		t.Errorf("Expected %+v, received %+v", exp, acnt.RefundIDs)
+	}
+	addRefundID(acnt, "CGRID_4", now.Add(24*time.Hour), 0) // no ttl, nothing is removed
+	if len(acnt.RefundIDs) != 3 {
+		t.Errorf("Expected 3 refunds, received %+v", acnt.RefundIDs)
+	}
+}
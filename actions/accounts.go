@@ -0,0 +1,287 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+)
+
+// balancePath splits an action path in the form *balance.<ID>[.<Field>] into its components
+func balancePath(path string) (blncID string, fldPath []string, err error) {
+	pathItems := strings.Split(path, utils.NestingSep)
+	if len(pathItems) < 2 ||
+		pathItems[0] != utils.MetaBalance ||
+		pathItems[1] == utils.EmptyString {
+		return utils.EmptyString, nil, fmt.Errorf("unsupported balance path: <%s>", path)
+	}
+	return pathItems[1], pathItems[2:], nil
+}
+
+// decimalFromValue converts the string value of an action into a Decimal
+// accepts both plain numbers and durations(ie: 10.5, 1h)
+func decimalFromValue(val string) (d *utils.Decimal, err error) {
+	if dBig, canSet := new(decimal.Big).SetString(val); canSet && !dBig.IsNaN(0) { // durations are parsed as NaN
+		return &utils.Decimal{Big: dBig}, nil
+	}
+	if d, err = utils.NewDecimalFromUsage(val); err != nil {
+		return nil, fmt.Errorf("cannot convert value: <%s> to decimal", val)
+	}
+	return
+}
+
+// updateAccountProfile will lock the AccountProfile, apply the changes and store it back
+// all balance actions should modify the AccountProfile through this function
+func updateAccountProfile(ctx context.Context, cfg *config.CGRConfig, dm *engine.DataManager,
+	tnt, acntID string, updFunc func(acnt *utils.AccountProfile) error) (err error) {
+	if acntID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.AccountField)
+	}
+	_, err = guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+		var acnt *utils.AccountProfile
+		if acnt, gErr = dm.GetAccountProfile(tnt, acntID,
+			true, true, utils.NonTransactional); gErr != nil {
+			return
+		}
+		acnt = acnt.Clone() // do not modify the cached version in case of errors
+		if gErr = updFunc(acnt); gErr != nil {
+			return
+		}
+		if gErr = ctxErr(ctx); gErr != nil { // cancelled or expired, do not write
+			return
+		}
+		gErr = dm.SetAccountProfile(acnt, false)
+		return
+	}, cfg.GeneralCfg().LockingTimeout,
+		utils.ConcatenatedKey(utils.CacheAccountProfiles, acntID)) // same lock as AccountS
+	return
+}
+
+// actBalance is the common part of all the actions modifying the balances of an AccountProfile
+type actBalance struct {
+	tnt    string
+	config *config.CGRConfig
+	dm     *engine.DataManager
+	aCfg   *engine.APAction
+}
+
+func (aL *actBalance) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actBalance) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// decimalValue parses the action Value into a Decimal
+func (aL *actBalance) decimalValue(data utils.MapStorage) (d *utils.Decimal, err error) {
+	var val string
+	if val, err = aL.cfg().Value.ParseDataProvider(data); err != nil {
+		return
+	}
+	return decimalFromValue(val)
+}
+
+// actTopUp will add the Value to the Units of the balance
+// the balance is created as *concrete if it does not exist
+type actTopUp struct {
+	*actBalance
+}
+
+// execute implements actioner interface
+func (aL *actTopUp) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	var blncID string
+	if blncID, _, err = balancePath(aL.cfg().Path); err != nil {
+		return
+	}
+	var val *utils.Decimal
+	if val, err = aL.decimalValue(data); err != nil {
+		return
+	}
+	return updateAccountProfile(ctx, aL.config, aL.dm, aL.tnt, trgID,
+		func(acnt *utils.AccountProfile) error {
+			if acnt.Balances == nil {
+				acnt.Balances = make(map[string]*utils.Balance)
+			}
+			blnc, has := acnt.Balances[blncID]
+			if !has {
+				blnc = &utils.Balance{
+					ID:    blncID,
+					Type:  utils.MetaConcrete,
+					Units: utils.NewDecimal(0, 0),
+				}
+				acnt.Balances[blncID] = blnc
+			}
+			if blnc.Units == nil {
+				blnc.Units = utils.NewDecimal(0, 0)
+			}
+			blnc.Units = &utils.Decimal{Big: utils.SumBig(blnc.Units.Big, val.Big)}
+			return nil
+		})
+}
+
+// actDebit will substract the Value out of the Units of the balance
+type actDebit struct {
+	*actBalance
+}
+
+// execute implements actioner interface
+func (aL *actDebit) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	var blncID string
+	if blncID, _, err = balancePath(aL.cfg().Path); err != nil {
+		return
+	}
+	var val *utils.Decimal
+	if val, err = aL.decimalValue(data); err != nil {
+		return
+	}
+	return updateAccountProfile(ctx, aL.config, aL.dm, aL.tnt, trgID,
+		func(acnt *utils.AccountProfile) error {
+			blnc, has := acnt.Balances[blncID]
+			if !has {
+				return utils.ErrNotFound
+			}
+			if blnc.Units == nil {
+				blnc.Units = utils.NewDecimal(0, 0)
+			}
+			blnc.Units = &utils.Decimal{Big: utils.SubstractBig(blnc.Units.Big, val.Big)}
+			return nil
+		})
+}
+
+// actSetBalance will set the field of the balance on the path to the Value
+// the balance is created as *concrete if it does not exist
+type actSetBalance struct {
+	*actBalance
+}
+
+// execute implements actioner interface
+func (aL *actSetBalance) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	var blncID string
+	var fldPath []string
+	if blncID, fldPath, err = balancePath(aL.cfg().Path); err != nil {
+		return
+	}
+	if len(fldPath) == 0 {
+		return fmt.Errorf("missing balance field in path: <%s>", aL.cfg().Path)
+	}
+	var val string
+	if val, err = aL.cfg().Value.ParseDataProvider(data); err != nil {
+		return
+	}
+	return updateAccountProfile(ctx, aL.config, aL.dm, aL.tnt, trgID,
+		func(acnt *utils.AccountProfile) error {
+			if acnt.Balances == nil {
+				acnt.Balances = make(map[string]*utils.Balance)
+			}
+			blnc, has := acnt.Balances[blncID]
+			if !has {
+				blnc = &utils.Balance{
+					ID:    blncID,
+					Type:  utils.MetaConcrete,
+					Units: utils.NewDecimal(0, 0),
+				}
+				acnt.Balances[blncID] = blnc
+			}
+			return setBalanceField(blnc, fldPath, val)
+		})
+}
+
+// setBalanceField populates the field of the balance out of the string value
+func setBalanceField(blnc *utils.Balance, fldPath []string, val string) (err error) {
+	switch fldPath[0] {
+	case utils.Units:
+		var d *utils.Decimal
+		if d, err = decimalFromValue(val); err != nil {
+			return
+		}
+		blnc.Units = d
+	case utils.Type:
+		blnc.Type = val
+	case utils.Weights:
+		blnc.Weights, err = utils.NewDynamicWeightsFromString(val, utils.InfieldSep, utils.ANDSep)
+	case utils.FilterIDs:
+		blnc.FilterIDs = utils.NewStringSet(strings.Split(val, utils.InfieldSep)).AsSlice()
+	case utils.AttributeIDs:
+		blnc.AttributeIDs = utils.NewStringSet(strings.Split(val, utils.InfieldSep)).AsSlice()
+	case utils.RateProfileIDs:
+		blnc.RateProfileIDs = utils.NewStringSet(strings.Split(val, utils.InfieldSep)).AsSlice()
+	case utils.Opts:
+		if len(fldPath) != 2 {
+			return fmt.Errorf("unsupported balance field: <%s>", strings.Join(fldPath, utils.NestingSep))
+		}
+		if blnc.Opts == nil {
+			blnc.Opts = make(map[string]interface{})
+		}
+		blnc.Opts[fldPath[1]] = val
+	default:
+		return fmt.Errorf("unsupported balance field: <%s>", strings.Join(fldPath, utils.NestingSep))
+	}
+	return
+}
+
+// actResetBalance will set the Units of the balance to 0
+type actResetBalance struct {
+	*actBalance
+}
+
+// execute implements actioner interface
+func (aL *actResetBalance) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	var blncID string
+	if blncID, _, err = balancePath(aL.cfg().Path); err != nil {
+		return
+	}
+	return updateAccountProfile(ctx, aL.config, aL.dm, aL.tnt, trgID,
+		func(acnt *utils.AccountProfile) error {
+			blnc, has := acnt.Balances[blncID]
+			if !has {
+				return utils.ErrNotFound
+			}
+			blnc.Units = utils.NewDecimal(0, 0)
+			return nil
+		})
+}
+
+// actRemoveBalance will remove the balance out of the AccountProfile
+type actRemoveBalance struct {
+	*actBalance
+}
+
+// execute implements actioner interface
+func (aL *actRemoveBalance) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	var blncID string
+	if blncID, _, err = balancePath(aL.cfg().Path); err != nil {
+		return
+	}
+	return updateAccountProfile(ctx, aL.config, aL.dm, aL.tnt, trgID,
+		func(acnt *utils.AccountProfile) error {
+			if _, has := acnt.Balances[blncID]; !has {
+				return utils.ErrNotFound
+			}
+			delete(acnt.Balances, blncID)
+			return nil
+		})
+}
@@ -0,0 +1,279 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package actions
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestActionTargetAccounts(t *testing.T) {
+	for _, actTyp := range []string{utils.MetaTopUp, utils.MetaDebit, utils.MetaSetBalance,
+		utils.MetaResetBalance, utils.MetaRemoveBalance} {
+		if rcv := actionTarget(actTyp); rcv != utils.MetaAccounts {
+			t.Errorf("Expected %+v for %s, received %+v", utils.MetaAccounts, actTyp, rcv)
+		}
+	}
+	if rcv := actionTarget(utils.MetaLog); rcv != utils.MetaNone {
+		t.Errorf("Expected %+v, received %+v", utils.MetaNone, rcv)
+	}
+}
+
+func TestBalancePath(t *testing.T) {
+	if blncID, fldPath, err := balancePath("*balance.MONETARY.Units"); err != nil {
+		t.Error(err)
+	} else if blncID != "MONETARY" {
+		t.Errorf("Expected MONETARY, received %+v", blncID)
+	} else if !reflect.DeepEqual(fldPath, []string{utils.Units}) {
+		t.Errorf("Expected %+v, received %+v", []string{utils.Units}, fldPath)
+	}
+	if _, _, err := balancePath("*balance"); err == nil ||
+		err.Error() != "unsupported balance path: <*balance>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, _, err := balancePath("~*req.Account"); err == nil ||
+		err.Error() != "unsupported balance path: <~*req.Account>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestDecimalFromValue(t *testing.T) {
+	if d, err := decimalFromValue("10.5"); err != nil {
+		t.Error(err)
+	} else if d.Compare(utils.NewDecimalFromFloat64(10.5)) != 0 {
+		t.Errorf("Expected 10.5, received %s", d)
+	}
+	if d, err := decimalFromValue("1s"); err != nil {
+		t.Error(err)
+	} else if d.Compare(utils.NewDecimal(1000000000, 0)) != 0 {
+		t.Errorf("Expected 1000000000, received %s", d)
+	}
+	if _, err := decimalFromValue("invalid"); err == nil ||
+		err.Error() != "cannot convert value: <invalid> to decimal" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestBalanceActionsExecute(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	filters := engine.NewFilterS(cfg, nil, dm)
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"VoiceBalance": {
+				ID:    "VoiceBalance",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(int64(10), 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, false); err != nil {
+		t.Fatal(err)
+	}
+	evNM := utils.MapStorage{
+		utils.MetaReq:  map[string]interface{}{utils.AccountField: "1001"},
+		utils.MetaOpts: map[string]interface{}{},
+	}
+	aCfgs := []*engine.APAction{
+		{
+			ID:    "TOPUP",
+			Type:  utils.MetaTopUp,
+			Path:  "*balance.MONETARY.Units",
+			Value: config.NewRSRParsersMustCompile("10", utils.InfieldSep),
+		},
+		{
+			ID:    "DEBIT",
+			Type:  utils.MetaDebit,
+			Path:  "*balance.MONETARY.Units",
+			Value: config.NewRSRParsersMustCompile("2.5", utils.InfieldSep),
+		},
+		{
+			ID:    "SET_WEIGHT",
+			Type:  utils.MetaSetBalance,
+			Path:  "*balance.MONETARY.Weights",
+			Value: config.NewRSRParsersMustCompile("*string:~*req.Account:1001;20", utils.PipeSep),
+		},
+		{
+			ID:   "RESET_VOICE",
+			Type: utils.MetaResetBalance,
+			Path: "*balance.VoiceBalance",
+		},
+	}
+	acts, err := newActionersFromActions(cfg, filters, dm, nil, aCfgs, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sActs := newScheduledActs("cgrates.org", "TOPUP_PROFILE", utils.MetaAccounts, "1001",
+		utils.MetaASAP, context.Background(), evNM, acts)
+	if err := sActs.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	exp := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"VoiceBalance": {
+				ID:    "VoiceBalance",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(0, 0),
+			},
+			"MONETARY": {
+				ID:   "MONETARY",
+				Type: utils.MetaConcrete,
+				Weights: utils.DynamicWeights{
+					{
+						FilterIDs: []string{"*string:~*req.Account:1001"},
+						Weight:    20,
+					},
+				},
+				Units: utils.NewDecimalFromFloat64(7.5),
+			},
+		},
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "1001", true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if utils.ToJSON(rcv) != utils.ToJSON(exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	rmvAct, err := newActioner(cfg, filters, dm, nil, &engine.APAction{
+		ID:   "REMOVE_VOICE",
+		Type: utils.MetaRemoveBalance,
+		Path: "*balance.VoiceBalance",
+	}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rmvAct.execute(nil, evNM, "1001"); err != nil {
+		t.Error(err)
+	}
+	delete(exp.Balances, "VoiceBalance")
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "1001", true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if utils.ToJSON(rcv) != utils.ToJSON(exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	// balance was already removed
+	if err := rmvAct.execute(nil, evNM, "1001"); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	// missing account
+	if err := rmvAct.execute(nil, evNM, "1002"); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	// no target
+	if err := rmvAct.execute(nil, evNM, utils.EmptyString); err == nil ||
+		err.Error() != "MANDATORY_IE_MISSING: [Account]" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestBalanceActionsCancelled(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	filters := engine.NewFilterS(cfg, nil, dm)
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"MONETARY": {
+				ID:    "MONETARY",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(int64(10), 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acnt, false); err != nil {
+		t.Fatal(err)
+	}
+	evNM := utils.MapStorage{
+		utils.MetaReq:  map[string]interface{}{utils.AccountField: "1001"},
+		utils.MetaOpts: map[string]interface{}{},
+	}
+	acts, err := newActionersFromActions(cfg, filters, dm, nil, []*engine.APAction{
+		{
+			ID:    "TOPUP",
+			Type:  utils.MetaTopUp,
+			Path:  "*balance.MONETARY.Units",
+			Value: config.NewRSRParsersMustCompile("10", utils.InfieldSep),
+		},
+		{
+			ID:    "SET_UNITS",
+			Type:  utils.MetaSetBalance,
+			Path:  "*balance.MONETARY.Units",
+			Value: config.NewRSRParsersMustCompile("100", utils.InfieldSep),
+		},
+	}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, act := range acts {
+		if err := act.execute(ctx, evNM, "1001"); err != context.Canceled {
+			t.Errorf("Expected %+v, received %+v", context.Canceled, err)
+		}
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "1001", true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if utils.ToJSON(rcv) != utils.ToJSON(acnt) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(acnt), utils.ToJSON(rcv))
+	}
+}
+
+func TestSetBalanceField(t *testing.T) {
+	blnc := &utils.Balance{ID: "TestBalance"}
+	if err := setBalanceField(blnc, []string{utils.Type}, utils.MetaAbstract); err != nil {
+		t.Error(err)
+	}
+	if err := setBalanceField(blnc, []string{utils.Units}, "1m"); err != nil {
+		t.Error(err)
+	}
+	if err := setBalanceField(blnc, []string{utils.FilterIDs}, "*string:~*req.Account:1001"); err != nil {
+		t.Error(err)
+	}
+	if err := setBalanceField(blnc, []string{utils.Opts, utils.MetaBalanceLimit}, "-10"); err != nil {
+		t.Error(err)
+	}
+	exp := &utils.Balance{
+		ID:        "TestBalance",
+		Type:      utils.MetaAbstract,
+		FilterIDs: []string{"*string:~*req.Account:1001"},
+		Units:     utils.NewDecimal(60000000000, 0),
+		Opts: map[string]interface{}{
+			utils.MetaBalanceLimit: "-10",
+		},
+	}
+	if !reflect.DeepEqual(exp, blnc) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(blnc))
+	}
+	if err := setBalanceField(blnc, []string{"Unsupported"}, "1"); err == nil ||
+		err.Error() != "unsupported balance field: <Unsupported>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -21,6 +21,7 @@ package actions
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -39,6 +40,7 @@ func NewActionS(cfg *config.CGRConfig, fltrS *engine.FilterS, dm *engine.DataMan
 		fltrS:   fltrS,
 		dm:      dm,
 		crnLk:   new(sync.RWMutex),
+		execs:   newExecRegistry(),
 	}
 	aS.schedInit() // initialize cron and schedule actions
 	return
@@ -52,6 +54,7 @@ type ActionS struct {
 	dm      *engine.DataManager
 	crn     *cron.Cron
 	crnLk   *sync.RWMutex
+	execs   *execRegistry // actions in progress
 }
 
 // ListenAndServe keeps the service alive
@@ -128,7 +131,8 @@ func (aS *ActionS) scheduleActions(cgrEvs []*utils.CGREvent, aPrflIDs []string,
 				go aS.asapExecuteActions(sActs)
 				continue
 			}
-			if _, err = crn.AddFunc(sActs.schedule, sActs.ScheduledExecute); err != nil {
+			sActs := sActs // each cron job needs its own reference
+			if _, err = crn.AddFunc(sActs.schedule, func() { aS.cronExecuteActions(sActs) }); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf(
 						"<%s> scheduling ActionProfile with id: <%s:%s>, error: <%s>",
@@ -136,6 +140,9 @@ func (aS *ActionS) scheduleActions(cgrEvs []*utils.CGREvent, aPrflIDs []string,
 				partExec = true
 				continue
 			}
+			if crnReset { // the previous runs could be missed while the scheduler was down
+				go aS.catchUpMissedRuns(sActs, time.Now())
+			}
 		}
 	}
 	if partExec {
@@ -238,16 +245,20 @@ func (aS *ActionS) scheduledActions(tnt string, cgrEv *utils.CGREvent, aPrflIDs
 		}
 		for trg, acts := range trgActs {
 			if trg == utils.MetaNone { // only one scheduledActs set
-				schedActs = append(schedActs, newScheduledActs(aPf.Tenant, aPf.ID, trg, utils.EmptyString, aPf.Schedule,
-					ctx, evNm.Clone(), acts))
+				sActs := newScheduledActs(aPf.Tenant, aPf.ID, trg, utils.EmptyString, aPf.Schedule,
+					ctx, evNm.Clone(), acts)
+				sActs.execs = aS.execs
+				schedActs = append(schedActs, sActs)
 				continue
 			}
 			if len(aPf.Targets[trg]) == 0 {
 				continue // no items selected
 			}
 			for trgID := range aPf.Targets[trg] {
-				schedActs = append(schedActs, newScheduledActs(aPf.Tenant, aPf.ID, trg, trgID, aPf.Schedule,
-					ctx, evNm, acts))
+				sActs := newScheduledActs(aPf.Tenant, aPf.ID, trg, trgID, aPf.Schedule,
+					ctx, evNm, acts)
+				sActs.execs = aS.execs
+				schedActs = append(schedActs, sActs)
 			}
 		}
 	}
@@ -315,3 +326,238 @@ func (aS *ActionS) V1ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl
 	*rpl = utils.OK
 	return
 }
+
+// cronExecuteActions executes the scheduledActs on cron and records the execution into the ActionSchedule
+func (aS *ActionS) cronExecuteActions(sActs *scheduledActs) {
+	aExec := &engine.ActionExecution{
+		Target:  sActs.trgKey(),
+		RunTime: time.Now(),
+	}
+	aExec.ExecTime = aExec.RunTime
+	if err := sActs.Execute(); err != nil {
+		aExec.Error = err.Error()
+	}
+	aS.updateActionSchedule(sActs.tenant, sActs.apID, func(aSched *engine.ActionSchedule) {
+		aSched.AddExecution(aExec, aS.cfg.ActionSCfg().ExecutionsHistory)
+	})
+}
+
+// updateActionSchedule applies the changes on the stored ActionSchedule of the ActionProfile
+// uses locks to avoid concurrent access
+func (aS *ActionS) updateActionSchedule(tnt, apID string, update func(*engine.ActionSchedule)) (err error) {
+	if _, err = guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+		var aSched *engine.ActionSchedule
+		if aSched, gErr = aS.dm.GetActionSchedule(tnt, apID); gErr != nil {
+			if gErr != utils.ErrNotFound {
+				return
+			}
+			gErr = nil
+			aSched = &engine.ActionSchedule{Tenant: tnt, ID: apID}
+		} else {
+			aSched = aSched.Clone() // do not modify the cached version in case of errors
+		}
+		update(aSched)
+		gErr = aS.dm.SetActionSchedule(aSched)
+		return
+	}, aS.cfg.GeneralCfg().LockingTimeout,
+		utils.ActionSchedulePrefix+utils.ConcatenatedKey(tnt, apID)); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf(
+				"<%s> saving schedule of ActionProfile with id: <%s:%s>, error: <%s>",
+				utils.ActionS, tnt, apID, err))
+	}
+	return
+}
+
+// catchUpMissedRuns handles the runs of scheduledActs missed up to now
+// based on the MissedRuns policy of the ActionProfile
+func (aS *ActionS) catchUpMissedRuns(sActs *scheduledActs, now time.Time) (err error) {
+	var lastRun time.Time
+	var aSched *engine.ActionSchedule
+	if aSched, err = aS.dm.GetActionSchedule(sActs.tenant, sActs.apID); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+	} else {
+		lastRun = aSched.LastRuns[sActs.trgKey()]
+	}
+	if lastRun.IsZero() { // never scheduled before, the missed runs are tracked from now on
+		return aS.updateActionSchedule(sActs.tenant, sActs.apID, func(aSched *engine.ActionSchedule) {
+			aSched.SetLastRun(sActs.trgKey(), now)
+		})
+	}
+	var sched cron.Schedule
+	if sched, err = cron.ParseStandard(sActs.schedule); err != nil {
+		return
+	}
+	var ap *engine.ActionProfile
+	if ap, err = aS.dm.GetActionProfile(sActs.tenant, sActs.apID,
+		true, true, utils.NonTransactional); err != nil {
+		return
+	}
+	maxMissed := aS.cfg.ActionSCfg().MaxMissedRuns
+	var missed []time.Time
+	var lastMissed time.Time
+	var skipped int
+	for nxtRun := sched.Next(lastRun); !nxtRun.IsZero() && !nxtRun.After(now); nxtRun = sched.Next(nxtRun) {
+		lastMissed = nxtRun
+		if ap.MissedRuns != utils.MetaRunAll && len(missed) != 0 {
+			missed[0] = nxtRun // only the most recent run is of interest
+			continue
+		}
+		missed = append(missed, nxtRun)
+		if ap.MissedRuns == utils.MetaRunAll &&
+			maxMissed >= 0 && len(missed) > maxMissed { // keep only the most recent runs
+			missed = missed[1:]
+			skipped++
+		}
+	}
+	if lastMissed.IsZero() {
+		return
+	}
+	if skipped != 0 {
+		utils.Logger.Warning(
+			fmt.Sprintf(
+				"<%s> skipping %d missed runs of ActionProfile with id: <%s:%s> on target: <%s>, over the limit of %d",
+				utils.ActionS, skipped, sActs.tenant, sActs.apID, sActs.trgKey(), maxMissed))
+	}
+	aExecs := make([]*engine.ActionExecution, len(missed))
+	for i, runTime := range missed {
+		aExecs[i] = &engine.ActionExecution{
+			Target:  sActs.trgKey(),
+			RunTime: runTime,
+			CatchUp: true,
+		}
+	}
+	switch ap.MissedRuns {
+	case utils.EmptyString, utils.MetaSkip:
+		utils.Logger.Info(
+			fmt.Sprintf(
+				"<%s> skipping missed runs of ActionProfile with id: <%s:%s> on target: <%s>",
+				utils.ActionS, sActs.tenant, sActs.apID, sActs.trgKey()))
+	case utils.MetaRunOnce, utils.MetaRunAll:
+		for _, aExec := range aExecs {
+			aExec.ExecTime = time.Now()
+			if errExec := sActs.Execute(); errExec != nil {
+				aExec.Error = errExec.Error()
+			}
+		}
+	default:
+		utils.Logger.Warning(
+			fmt.Sprintf(
+				"<%s> unsupported missed runs policy: <%s> for ActionProfile with id: <%s:%s>, skipping missed runs",
+				utils.ActionS, ap.MissedRuns, sActs.tenant, sActs.apID))
+	}
+	return aS.updateActionSchedule(sActs.tenant, sActs.apID, func(aSched *engine.ActionSchedule) {
+		aSched.SetLastRun(sActs.trgKey(), lastMissed) // the skipped runs are handled as well
+		for _, aExec := range aExecs {
+			aSched.AddExecution(aExec, aS.cfg.ActionSCfg().ExecutionsHistory)
+		}
+	})
+}
+
+// V1GetActionSchedules returns the past and upcoming executions of the scheduled ActionProfiles
+func (aS *ActionS) V1GetActionSchedules(args *utils.ArgActionSv1GetActionSchedules,
+	rpl *[]*engine.ActionScheduleInfo) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = aS.cfg.GeneralCfg().DefaultTenant
+	}
+	aPrflIDs := args.ActionProfileIDs
+	if len(aPrflIDs) == 0 {
+		prfx := utils.ActionProfilePrefix + tnt + utils.ConcatenatedKeySep
+		var keys []string
+		if keys, err = aS.dm.DataDB().GetKeysForPrefix(prfx); err != nil {
+			return
+		}
+		aPrflIDs = make([]string, len(keys))
+		for i, key := range keys {
+			aPrflIDs[i] = key[len(prfx):]
+		}
+		sort.Strings(aPrflIDs)
+	}
+	upcoming := args.Upcoming
+	if upcoming <= 0 {
+		upcoming = 1
+	}
+	now := time.Now()
+	aSchedInfos := make([]*engine.ActionScheduleInfo, 0, len(aPrflIDs))
+	for _, aPrflID := range aPrflIDs {
+		var ap *engine.ActionProfile
+		if ap, err = aS.dm.GetActionProfile(tnt, aPrflID,
+			true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+				continue
+			}
+			return
+		}
+		if ap.Schedule == utils.EmptyString ||
+			ap.Schedule == utils.MetaASAP { // not scheduled on cron
+			continue
+		}
+		aSchedInfo := &engine.ActionScheduleInfo{
+			Tenant:     ap.Tenant,
+			ID:         ap.ID,
+			Schedule:   ap.Schedule,
+			MissedRuns: ap.MissedRuns,
+		}
+		var sched cron.Schedule
+		if sched, err = cron.ParseStandard(ap.Schedule); err != nil {
+			return
+		}
+		for nxtRun := sched.Next(now); !nxtRun.IsZero() &&
+			len(aSchedInfo.Upcoming) < upcoming; nxtRun = sched.Next(nxtRun) {
+			aSchedInfo.Upcoming = append(aSchedInfo.Upcoming, nxtRun)
+		}
+		var aSched *engine.ActionSchedule
+		if aSched, err = aS.dm.GetActionSchedule(tnt, aPrflID); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+		} else {
+			aSched = aSched.Clone()
+			aSchedInfo.LastRuns = aSched.LastRuns
+			aSchedInfo.Executions = aSched.Executions
+		}
+		aSchedInfos = append(aSchedInfos, aSchedInfo)
+	}
+	if len(aSchedInfos) == 0 {
+		return utils.ErrNotFound
+	}
+	*rpl = aSchedInfos
+	return
+}
+
+// V1GetExecutions returns the actions in progress
+func (aS *ActionS) V1GetExecutions(args *utils.ArgActionSv1GetExecutions,
+	rpl *[]*engine.ActionExecutionInfo) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = aS.cfg.GeneralCfg().DefaultTenant
+	}
+	aExecs := aS.execs.executions(tnt, utils.NewStringSet(args.ActionProfileIDs))
+	if len(aExecs) == 0 {
+		return utils.ErrNotFound
+	}
+	*rpl = aExecs
+	return
+}
+
+// V1CancelExecution cancels the action in progress with the given execution ID
+func (aS *ActionS) V1CancelExecution(args *utils.TenantIDWithOpts, rpl *string) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = aS.cfg.GeneralCfg().DefaultTenant
+	}
+	if err = aS.execs.cancel(tnt, args.ID); err != nil {
+		return
+	}
+	*rpl = utils.OK
+	return
+}
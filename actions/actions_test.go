@@ -208,7 +208,7 @@ func TestScheduledActions(t *testing.T) {
 			utils.Destination:  1002,
 		},
 	}
-	actPrf.Actions[0].Type = "*topup"
+	actPrf.Actions[0].Type = "*unsupported_type"
 	if err := acts.dm.SetActionProfile(actPrf, true); err != nil {
 		t.Error(err)
 	}
@@ -524,7 +524,7 @@ func TestLogActionExecute(t *testing.T) {
 	}
 
 	logAction := actLog{}
-	if err := logAction.execute(nil, evNM); err != nil {
+	if err := logAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 
@@ -613,7 +613,7 @@ func TestCDRLogActionExecute(t *testing.T) {
 		},
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := cdrLogAction.execute(nil, evNM); err != nil {
+	if err := cdrLogAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -724,7 +724,7 @@ func TestCDRLogActionWithOpts(t *testing.T) {
 			"EventFieldOpt": "eventValue",
 		},
 	}
-	if err := cdrLogAction.execute(nil, evNM); err != nil {
+	if err := cdrLogAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -776,7 +776,7 @@ func TestExportAction(t *testing.T) {
 			"EventFieldOpt": "eventValue",
 		},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -834,11 +834,51 @@ func TestExportActionWithEeIDs(t *testing.T) {
 			"EventFieldOpt": "eventValue",
 		},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
 
+func TestExportActionCancel(t *testing.T) {
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	release := make(chan struct{})
+	defer close(release)
+	sMock := &testMockCDRsConn{
+		calls: map[string]func(arg interface{}, rply interface{}) error{
+			utils.EeSv1ProcessEvent: func(arg interface{}, rply interface{}) error {
+				<-release // EEs not answering
+				return nil
+			},
+		},
+	}
+	internalEEsChann := make(chan rpcclient.ClientConnector, 1)
+	internalEEsChann <- sMock
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ActionSCfg().EEsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)}
+	connMgr := engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs): internalEEsChann,
+	})
+	exportAction := &actExport{
+		tnt:     "cgrates.org",
+		config:  cfg,
+		connMgr: connMgr,
+		aCfg: &engine.APAction{
+			ID:   "ACT_EXPORT",
+			Type: utils.MetaExport,
+		},
+	}
+	evNM := utils.MapStorage{
+		utils.MetaReq:  map[string]interface{}{utils.AccountField: "1001"},
+		utils.MetaOpts: map[string]interface{}{},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := exportAction.execute(ctx, evNM, utils.EmptyString); err != context.DeadlineExceeded {
+		t.Errorf("Expected error: %v, received: %v", context.DeadlineExceeded, err)
+	}
+}
+
 func TestExportActionResetThresholdStaticTenantID(t *testing.T) {
 	// Clear cache because connManager sets the internal connection in cache
 	engine.Cache.Clear([]string{utils.CacheRPCConnections})
@@ -881,7 +921,7 @@ func TestExportActionResetThresholdStaticTenantID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -928,7 +968,7 @@ func TestExportActionResetThresholdStaticID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -983,7 +1023,7 @@ func TestExportActionResetThresholdVariableID(t *testing.T) {
 		},
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -1030,7 +1070,7 @@ func TestExportActionResetStatStaticTenantID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -1077,7 +1117,7 @@ func TestExportActionResetStatStaticID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -1132,7 +1172,293 @@ func TestExportActionResetStatVariableID(t *testing.T) {
 		},
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
+
+type testCountActioner struct {
+	aCfg  *engine.APAction
+	execs int
+}
+
+func (tA *testCountActioner) id() string            { return tA.aCfg.ID }
+func (tA *testCountActioner) cfg() *engine.APAction { return tA.aCfg }
+func (tA *testCountActioner) execute(_ context.Context, _ utils.MapStorage, _ string) (err error) {
+	tA.execs++
+	return
+}
+
+func TestCatchUpMissedRuns(t *testing.T) {
+	engine.Cache.Clear(nil)
+	defer engine.Cache.Clear(nil)
+	defaultCfg := config.NewDefaultCGRConfig()
+	defaultCfg.ActionSCfg().ExecutionsHistory = 2
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	acts := NewActionS(defaultCfg, filters, dm, nil)
+
+	ap := &engine.ActionProfile{
+		Tenant:   "cgrates.org",
+		ID:       "MONTHLY_TOPUP",
+		Schedule: "0 0 1 * *",
+		Targets:  map[string]utils.StringSet{utils.MetaAccounts: utils.NewStringSet([]string{"1001"})},
+		Actions:  []*engine.APAction{{ID: "TOPUP", Type: utils.MetaTopUp}},
+	}
+	if err := dm.SetActionProfile(ap, true); err != nil {
+		t.Fatal(err)
+	}
+	tA := &testCountActioner{aCfg: ap.Actions[0]}
+	sActs := newScheduledActs(ap.Tenant, ap.ID, utils.MetaAccounts, "1001", ap.Schedule,
+		context.Background(), utils.MapStorage{}, []actioner{tA})
+
+	// first time the tracking starts, nothing is missed
+	startTime := time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC)
+	if err := acts.catchUpMissedRuns(sActs, startTime); err != nil {
+		t.Fatal(err)
+	}
+	eSched := &engine.ActionSchedule{
+		Tenant:   "cgrates.org",
+		ID:       "MONTHLY_TOPUP",
+		LastRuns: map[string]time.Time{"*accounts:1001": startTime},
+	}
+	if rcv, err := dm.GetActionSchedule("cgrates.org", "MONTHLY_TOPUP"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eSched, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eSched), utils.ToJSON(rcv))
+	}
+
+	// two runs missed and skipped by default
+	if err := acts.catchUpMissedRuns(sActs, time.Date(2021, 3, 15, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if tA.execs != 0 {
+		t.Errorf("Expected no execution, received: %d", tA.execs)
+	}
+	rcv, err := dm.GetActionSchedule("cgrates.org", "MONTHLY_TOPUP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastRun := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC); !rcv.LastRuns["*accounts:1001"].Equal(lastRun) {
+		t.Errorf("Expected last run %v, received %v", lastRun, rcv.LastRuns["*accounts:1001"])
+	}
+	if len(rcv.Executions) != 1 || !rcv.Executions[0].CatchUp ||
+		!rcv.Executions[0].ExecTime.IsZero() {
+		t.Errorf("Unexpected executions: %s", utils.ToJSON(rcv.Executions))
+	}
+
+	// run all the missed runs, only the last executions are kept
+	ap.MissedRuns = utils.MetaRunAll
+	if err := dm.SetActionProfile(ap, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := acts.catchUpMissedRuns(sActs, time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if tA.execs != 3 {
+		t.Errorf("Expected 3 executions, received: %d", tA.execs)
+	}
+	if rcv, err = dm.GetActionSchedule("cgrates.org", "MONTHLY_TOPUP"); err != nil {
+		t.Fatal(err)
+	}
+	if len(rcv.Executions) != 2 ||
+		!rcv.Executions[1].RunTime.Equal(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)) ||
+		rcv.Executions[1].ExecTime.IsZero() {
+		t.Errorf("Unexpected executions: %s", utils.ToJSON(rcv.Executions))
+	}
+
+	// run only once for multiple missed runs
+	ap.MissedRuns = utils.MetaRunOnce
+	if err := dm.SetActionProfile(ap, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := acts.catchUpMissedRuns(sActs, time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if tA.execs != 4 {
+		t.Errorf("Expected 4 executions, received: %d", tA.execs)
+	}
+	if rcv, err = dm.GetActionSchedule("cgrates.org", "MONTHLY_TOPUP"); err != nil {
+		t.Fatal(err)
+	}
+	if lastRun := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC); !rcv.LastRuns["*accounts:1001"].Equal(lastRun) {
+		t.Errorf("Expected last run %v, received %v", lastRun, rcv.LastRuns["*accounts:1001"])
+	}
+
+	var aSchedInfos []*engine.ActionScheduleInfo
+	if err := acts.V1GetActionSchedules(&utils.ArgActionSv1GetActionSchedules{
+		Upcoming: 2,
+	}, &aSchedInfos); err != nil {
+		t.Fatal(err)
+	}
+	if len(aSchedInfos) != 1 || aSchedInfos[0].ID != "MONTHLY_TOPUP" ||
+		aSchedInfos[0].MissedRuns != utils.MetaRunOnce ||
+		len(aSchedInfos[0].Upcoming) != 2 || len(aSchedInfos[0].Executions) != 2 {
+		t.Errorf("Unexpected schedules: %s", utils.ToJSON(aSchedInfos))
+	} else if aSchedInfos[0].Upcoming[0].Day() != 1 {
+		t.Errorf("Unexpected upcoming runs: %v", aSchedInfos[0].Upcoming)
+	}
+	if err := acts.V1GetActionSchedules(&utils.ArgActionSv1GetActionSchedules{
+		ActionProfileIDs: []string{"MISSING"},
+	}, &aSchedInfos); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestCatchUpMissedRunsMaxMissedRuns(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	defaultCfg.ActionSCfg().MaxMissedRuns = 2
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	acts := NewActionS(defaultCfg, filters, dm, nil)
+
+	ap := &engine.ActionProfile{
+		Tenant:     "cgrates.org",
+		ID:         "MONTHLY_TOPUP_LIMITED",
+		Schedule:   "0 0 1 * *",
+		MissedRuns: utils.MetaRunAll,
+		Targets:    map[string]utils.StringSet{utils.MetaAccounts: utils.NewStringSet([]string{"1001"})},
+		Actions:    []*engine.APAction{{ID: "TOPUP", Type: utils.MetaTopUp}},
+	}
+	if err := dm.SetActionProfile(ap, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetActionSchedule(&engine.ActionSchedule{
+		Tenant:   "cgrates.org",
+		ID:       "MONTHLY_TOPUP_LIMITED",
+		LastRuns: map[string]time.Time{"*accounts:1001": time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tA := &testCountActioner{aCfg: ap.Actions[0]}
+	sActs := newScheduledActs(ap.Tenant, ap.ID, utils.MetaAccounts, "1001", ap.Schedule,
+		context.Background(), utils.MapStorage{}, []actioner{tA})
+
+	// five runs missed, only the last two are executed
+	if err := acts.catchUpMissedRuns(sActs, time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if tA.execs != 2 {
+		t.Errorf("Expected 2 executions, received: %d", tA.execs)
+	}
+	rcv, err := dm.GetActionSchedule("cgrates.org", "MONTHLY_TOPUP_LIMITED")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastRun := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC); !rcv.LastRuns["*accounts:1001"].Equal(lastRun) {
+		t.Errorf("Expected last run %v, received %v", lastRun, rcv.LastRuns["*accounts:1001"])
+	}
+	if len(rcv.Executions) != 2 ||
+		!rcv.Executions[0].RunTime.Equal(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)) ||
+		!rcv.Executions[1].RunTime.Equal(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected executions: %s", utils.ToJSON(rcv.Executions))
+	}
+
+	// no missed run is executed but they are still marked as handled
+	defaultCfg.ActionSCfg().MaxMissedRuns = 0
+	if err := acts.catchUpMissedRuns(sActs, time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if tA.execs != 2 {
+		t.Errorf("Expected 2 executions, received: %d", tA.execs)
+	}
+	if rcv, err = dm.GetActionSchedule("cgrates.org", "MONTHLY_TOPUP_LIMITED"); err != nil {
+		t.Fatal(err)
+	}
+	if lastRun := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC); !rcv.LastRuns["*accounts:1001"].Equal(lastRun) {
+		t.Errorf("Expected last run %v, received %v", lastRun, rcv.LastRuns["*accounts:1001"])
+	}
+}
+
+type testBlockingActioner struct {
+	aCfg    *engine.APAction
+	started chan struct{}
+}
+
+func (tA *testBlockingActioner) id() string            { return tA.aCfg.ID }
+func (tA *testBlockingActioner) cfg() *engine.APAction { return tA.aCfg }
+func (tA *testBlockingActioner) execute(ctx context.Context, _ utils.MapStorage, _ string) (err error) {
+	close(tA.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestActionSExecutions(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	acts := NewActionS(defaultCfg, filters, dm, nil)
+
+	tA := &testBlockingActioner{
+		aCfg:    &engine.APAction{ID: "HTTP_POST", Type: utils.MetaHTTPPost},
+		started: make(chan struct{}),
+	}
+	sActs := newScheduledActs("cgrates.org", "AP_BLOCKING", utils.MetaNone, utils.EmptyString,
+		utils.MetaASAP, context.Background(), utils.MapStorage{}, []actioner{tA})
+	sActs.execs = acts.execs
+	errChan := make(chan error, 1)
+	go func() { errChan <- sActs.Execute() }()
+	<-tA.started
+
+	var aExecs []*engine.ActionExecutionInfo
+	if err := acts.V1GetExecutions(&utils.ArgActionSv1GetExecutions{
+		ActionProfileIDs: []string{"AP_BLOCKING"}}, &aExecs); err != nil {
+		t.Fatal(err)
+	} else if len(aExecs) != 1 || aExecs[0].ActionID != "HTTP_POST" ||
+		aExecs[0].Type != utils.MetaHTTPPost || aExecs[0].Target != utils.MetaNone ||
+		!aExecs[0].Deadline.IsZero() {
+		t.Fatalf("Unexpected executions: %s", utils.ToJSON(aExecs))
+	}
+	var rpl string
+	if err := acts.V1CancelExecution(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{Tenant: "cgrates.net", ID: aExecs[0].ID}}, &rpl); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	if err := acts.V1CancelExecution(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: aExecs[0].ID}}, &rpl); err != nil {
+		t.Error(err)
+	} else if rpl != utils.OK {
+		t.Errorf("Unexpected reply: %q", rpl)
+	}
+	select {
+	case err := <-errChan:
+		if err != utils.ErrPartiallyExecuted {
+			t.Errorf("Expected %+v, received %+v", utils.ErrPartiallyExecuted, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("execution not cancelled")
+	}
+	if err := acts.V1GetExecutions(&utils.ArgActionSv1GetExecutions{}, &aExecs); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	expErr := utils.NewErrMandatoryIeMissing(utils.ID)
+	if err := acts.V1CancelExecution(&utils.TenantIDWithOpts{}, &rpl); err == nil ||
+		err.Error() != expErr.Error() {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestExecuteActionTTL(t *testing.T) {
+	tA := &testBlockingActioner{
+		aCfg: &engine.APAction{ID: "EXPORT", Type: utils.MetaExport,
+			TTL: 10 * time.Millisecond},
+		started: make(chan struct{}),
+	}
+	eR := newExecRegistry()
+	sActs := newScheduledActs("cgrates.org", "AP_TTL", utils.MetaNone, utils.EmptyString,
+		utils.MetaASAP, context.Background(), utils.MapStorage{}, []actioner{tA})
+	info := newActionExecutionInfo(sActs, tA)
+	if err := executeAction(context.Background(), eR, info, tA,
+		utils.MapStorage{}, utils.EmptyString); err != context.DeadlineExceeded {
+		t.Errorf("Expected %+v, received %+v", context.DeadlineExceeded, err)
+	}
+	if !info.Deadline.Equal(info.StartTime.Add(10 * time.Millisecond)) {
+		t.Errorf("Unexpected deadline: %v", info.Deadline)
+	}
+	if len(eR.execs) != 0 {
+		t.Errorf("Expected the execution removed from registry, received: %s", utils.ToJSON(eR.execs))
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package actions
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newExecRegistry() *execRegistry {
+	return &execRegistry{execs: make(map[string]*actExecution)}
+}
+
+// actExecution is an action in progress which can be cancelled
+type actExecution struct {
+	info   *engine.ActionExecutionInfo
+	cancel context.CancelFunc
+}
+
+// execRegistry keeps the actions in progress, indexed on execution ID
+type execRegistry struct {
+	sync.RWMutex
+	execs map[string]*actExecution
+}
+
+// register adds the execution to the registry
+func (eR *execRegistry) register(info *engine.ActionExecutionInfo, cancel context.CancelFunc) {
+	eR.Lock()
+	eR.execs[info.ID] = &actExecution{info: info, cancel: cancel}
+	eR.Unlock()
+}
+
+// unregister removes the execution from the registry once finished
+func (eR *execRegistry) unregister(execID string) {
+	eR.Lock()
+	delete(eR.execs, execID)
+	eR.Unlock()
+}
+
+// executions returns the executions in progress of the tenant, ordered on start time
+// only the executions of the given ActionProfiles are returned if aPrflIDs is not empty
+func (eR *execRegistry) executions(tnt string, aPrflIDs utils.StringSet) (infos []*engine.ActionExecutionInfo) {
+	eR.RLock()
+	for _, aExec := range eR.execs {
+		if aExec.info.Tenant != tnt ||
+			(len(aPrflIDs) != 0 && !aPrflIDs.Has(aExec.info.ActionProfileID)) {
+			continue
+		}
+		info := *aExec.info
+		infos = append(infos, &info)
+	}
+	eR.RUnlock()
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].StartTime.Equal(infos[j].StartTime) {
+			return infos[i].ID < infos[j].ID
+		}
+		return infos[i].StartTime.Before(infos[j].StartTime)
+	})
+	return
+}
+
+// cancel stops the execution in progress
+func (eR *execRegistry) cancel(tnt, execID string) (err error) {
+	eR.RLock()
+	aExec, has := eR.execs[execID]
+	eR.RUnlock()
+	if !has || aExec.info.Tenant != tnt {
+		return utils.ErrNotFound
+	}
+	aExec.cancel()
+	return
+}
+
+// executeAction runs the actioner with a context cancelled once its TTL is reached
+// the execution is registered in eR so it can be queried and cancelled while in progress
+// the actioner is waited for, so the next actions do not start until it gives up
+func executeAction(ctx context.Context, eR *execRegistry, info *engine.ActionExecutionInfo,
+	act actioner, data utils.MapStorage, trgID string) (err error) {
+	var cancel context.CancelFunc
+	if ttl := act.cfg().TTL; ttl > 0 {
+		ctx, cancel = context.WithTimeout(ctx, ttl)
+		info.Deadline = info.StartTime.Add(ttl)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	if eR != nil {
+		eR.register(info, cancel)
+		defer eR.unregister(info.ID)
+	}
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	return act.execute(ctx, data, trgID)
+}
+
+// ctxErr returns the error of the context, checked by the actions before any write
+// nil contexts are never done
+func ctxErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// newActionExecutionInfo builds the information about the execution of act as part of sActs
+func newActionExecutionInfo(sActs *scheduledActs, act actioner) *engine.ActionExecutionInfo {
+	return &engine.ActionExecutionInfo{
+		ID:              utils.GenUUID(),
+		Tenant:          sActs.tenant,
+		ActionProfileID: sActs.apID,
+		ActionID:        act.id(),
+		Type:            act.cfg().Type,
+		Target:          sActs.trgKey(),
+		StartTime:       time.Now(),
+	}
+}
@@ -35,6 +35,9 @@ import (
 // actionTarget returns the target attached to an action
 func actionTarget(act string) (trgt string) {
 	switch act {
+	case utils.MetaTopUp, utils.MetaDebit, utils.MetaSetBalance,
+		utils.MetaResetBalance, utils.MetaRemoveBalance:
+		trgt = utils.MetaAccounts
 	default:
 		trgt = utils.MetaNone
 	}
@@ -44,7 +47,7 @@ func actionTarget(act string) (trgt string) {
 func newScheduledActs(tenant, apID, trgTyp, trgID, schedule string,
 	ctx context.Context, data utils.MapStorage, acts []actioner) (sActs *scheduledActs) {
 	return &scheduledActs{tenant, apID, trgTyp, trgID, schedule, ctx, data, acts,
-		ltcache.NewTransCache(map[string]*ltcache.CacheConfig{})}
+		ltcache.NewTransCache(map[string]*ltcache.CacheConfig{}), nil}
 }
 
 // scheduled is a set of actions which will be executed directly or by the cron.schedule
@@ -55,7 +58,16 @@ type scheduledActs struct {
 	data                        utils.MapStorage
 	acts                        []actioner
 
-	cch *ltcache.TransCache // cache data between actions here
+	cch   *ltcache.TransCache // cache data between actions here
+	execs *execRegistry       // registry of the actions in progress
+}
+
+// trgKey identifies the target of the scheduledActs inside the ActionSchedule
+func (s *scheduledActs) trgKey() string {
+	if s.trgID == utils.EmptyString {
+		return s.trgTyp
+	}
+	return utils.ConcatenatedKey(s.trgTyp, s.trgID)
 }
 
 // Execute is called when we want the ActionProfile to be executed
@@ -67,8 +79,8 @@ func (s *scheduledActs) ScheduledExecute() {
 func (s *scheduledActs) Execute() (err error) {
 	var partExec bool
 	for _, act := range s.acts {
-		//ctx, cancel := context.WithTimeout(s.ctx, act.cfg().TTL)
-		if err := act.execute(s.ctx, s.data); err != nil {
+		if err := executeAction(s.ctx, s.execs, newActionExecutionInfo(s, act),
+			act, s.data, s.trgID); err != nil {
 			utils.Logger.Warning(fmt.Sprintf("executing action: <%s>, error: <%s>", act.id(), err))
 			partExec = true
 		}
@@ -113,6 +125,16 @@ func newActioner(cfg *config.CGRConfig, fltrS *engine.FilterS, dm *engine.DataMa
 		return &actResetStat{config: cfg, connMgr: connMgr, aCfg: aCfg, tnt: tnt}, nil
 	case utils.MetaResetThreshold:
 		return &actResetThreshold{config: cfg, connMgr: connMgr, aCfg: aCfg, tnt: tnt}, nil
+	case utils.MetaTopUp:
+		return &actTopUp{&actBalance{config: cfg, dm: dm, aCfg: aCfg, tnt: tnt}}, nil
+	case utils.MetaDebit:
+		return &actDebit{&actBalance{config: cfg, dm: dm, aCfg: aCfg, tnt: tnt}}, nil
+	case utils.MetaSetBalance:
+		return &actSetBalance{&actBalance{config: cfg, dm: dm, aCfg: aCfg, tnt: tnt}}, nil
+	case utils.MetaResetBalance:
+		return &actResetBalance{&actBalance{config: cfg, dm: dm, aCfg: aCfg, tnt: tnt}}, nil
+	case utils.MetaRemoveBalance:
+		return &actRemoveBalance{&actBalance{config: cfg, dm: dm, aCfg: aCfg, tnt: tnt}}, nil
 	default:
 		return nil, fmt.Errorf("unsupported action type: <%s>", aCfg.Type)
 
@@ -123,7 +145,7 @@ func newActioner(cfg *config.CGRConfig, fltrS *engine.FilterS, dm *engine.DataMa
 type actioner interface {
 	id() string
 	cfg() *engine.APAction
-	execute(ctx context.Context, data utils.MapStorage) (err error)
+	execute(ctx context.Context, data utils.MapStorage, trgID string) (err error)
 }
 
 // actLogger will log data to CGRateS logger
@@ -140,7 +162,7 @@ func (aL *actLog) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actLog) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actLog) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var body []byte
 	if body, err = json.Marshal(data); err != nil {
 		return
@@ -166,7 +188,7 @@ func (aL *actCDRLog) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actCDRLog) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actCDRLog) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	if len(aL.config.ActionSCfg().CDRsConns) == 0 {
 		//eroare predefinita
 		return fmt.Errorf("no connection with CDR Server")
@@ -203,6 +225,9 @@ func (aL *actCDRLog) execute(ctx context.Context, data utils.MapStorage) (err er
 	if err = cdrLogReq.SetFields(template); err != nil {
 		return
 	}
+	if err = ctxErr(ctx); err != nil {
+		return
+	}
 	var rply string
 	if err := aL.connMgr.Call(aL.config.ActionSCfg().CDRsConns, nil,
 		utils.CDRsV1ProcessEvent,
@@ -229,7 +254,7 @@ func (aL *actHTTPPost) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actHTTPPost) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actHTTPPost) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var body []byte
 	if body, err = json.Marshal(data); err != nil {
 		return
@@ -248,7 +273,9 @@ func (aL *actHTTPPost) execute(ctx context.Context, data utils.MapStorage) (err
 		}()
 		return
 	}
-	if err = pstr.PostValues(body, make(http.Header)); err != nil && config.CgrConfig().GeneralCfg().FailedPostsDir != utils.MetaNone {
+	if err = pstr.PostValuesWithContext(ctx, body, make(http.Header)); err != nil &&
+		ctx.Err() == nil && // cancelled or expired, not a failed post
+		config.CgrConfig().GeneralCfg().FailedPostsDir != utils.MetaNone {
 		engine.AddFailedPost(aL.cfg().Path, utils.MetaHTTPjson, utils.ActionsPoster+utils.HierarchySep+aL.cfg().Type, body, make(map[string]interface{}))
 		err = nil
 	}
@@ -271,7 +298,7 @@ func (aL *actExport) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actExport) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actExport) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var exporterIDs []string
 	if expIDs, has := aL.cfg().Opts[utils.MetaExporterIDs]; has { // if templateID is not present we use default template
 		exporterIDs = strings.Split(utils.IfaceAsString(expIDs), utils.InfieldSep)
@@ -287,9 +314,26 @@ func (aL *actExport) execute(ctx context.Context, data utils.MapStorage) (err er
 		},
 	}
 
-	var rply map[string]map[string]interface{}
-	return aL.connMgr.Call(aL.config.ActionSCfg().EEsConns, nil,
-		utils.EeSv1ProcessEvent, args, &rply)
+	if err = ctxErr(ctx); err != nil {
+		return
+	}
+	if ctx == nil {
+		var rply map[string]map[string]interface{}
+		return aL.connMgr.Call(aL.config.ActionSCfg().EEsConns, nil,
+			utils.EeSv1ProcessEvent, args, &rply)
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		var rply map[string]map[string]interface{}
+		errChan <- aL.connMgr.Call(aL.config.ActionSCfg().EEsConns, nil,
+			utils.EeSv1ProcessEvent, args, &rply)
+	}()
+	select {
+	case err = <-errChan:
+	case <-ctx.Done(): // cancelled or expired, the export is not waited anymore
+		err = ctx.Err()
+	}
+	return
 }
 
 type actResetStat struct {
@@ -308,7 +352,7 @@ func (aL *actResetStat) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actResetStat) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actResetStat) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var tenID string
 	if tenID, err = aL.cfg().Value.ParseDataProvider(data); err != nil {
 		return
@@ -320,6 +364,9 @@ func (aL *actResetStat) execute(ctx context.Context, data utils.MapStorage) (err
 	if args.Tenant == utils.EmptyString { // in case that user pass only ID we populate the tenant from the event
 		args.Tenant = aL.tnt
 	}
+	if err = ctxErr(ctx); err != nil {
+		return
+	}
 	var rply string
 	return aL.connMgr.Call(aL.config.ActionSCfg().StatSConns, nil,
 		utils.StatSv1ResetStatQueue, args, &rply)
@@ -341,7 +388,7 @@ func (aL *actResetThreshold) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actResetThreshold) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actResetThreshold) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var tenID string
 	if tenID, err = aL.cfg().Value.ParseDataProvider(data); err != nil {
 		return
@@ -353,6 +400,9 @@ func (aL *actResetThreshold) execute(ctx context.Context, data utils.MapStorage)
 	if args.Tenant == utils.EmptyString { // in case that user pass only ID we populate the tenant from the event
 		args.Tenant = aL.tnt
 	}
+	if err = ctxErr(ctx); err != nil {
+		return
+	}
 	var rply string
 	return aL.connMgr.Call(aL.config.ActionSCfg().ThresholdSConns, nil,
 		utils.ThresholdSv1ResetThreshold, args, &rply)
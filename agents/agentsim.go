@@ -0,0 +1,266 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+	"github.com/cgrates/sipingo"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// AgentSimulator sends protocol requests towards one of the agents,
+// building them out of templates populated under *diamreq
+type AgentSimulator interface {
+	SendRequest(tpl []*config.FCTemplate, ev utils.DataProvider) error
+	Close() error
+}
+
+// NewAgentSimulator returns the AgentSimulator for the agent type
+func NewAgentSimulator(agentType, network, addr, acctAddr, secret, originHost, originRealm,
+	dictsDir, timezone string, rplyTimeout time.Duration) (AgentSimulator, error) {
+	switch agentType {
+	case utils.MetaDiameter:
+		return NewDiameterSimulator(network, addr, originHost, originRealm,
+			dictsDir, timezone, rplyTimeout)
+	case utils.MetaRadius:
+		return NewRadiusSimulator(network, addr, acctAddr, secret, dictsDir, timezone)
+	case utils.MetaSIP:
+		return NewSIPSimulator(network, addr, timezone, rplyTimeout), nil
+	default:
+		return nil, fmt.Errorf("unsupported agent type: <%s>", agentType)
+	}
+}
+
+// simBuildRequest populates the *diamreq fields of the template out of the event
+func simBuildRequest(tpl []*config.FCTemplate, ev utils.DataProvider,
+	timezone string) (reqNM *utils.OrderedNavigableMap, err error) {
+	aReq := NewAgentRequest(ev, nil, nil, nil, nil, nil,
+		config.CgrConfig().GeneralCfg().DefaultTenant, timezone, nil, nil, nil)
+	if err = aReq.SetFields(tpl); err != nil {
+		return
+	}
+	return aReq.diamreq, nil
+}
+
+// NewDiameterSimulator connects to the DiameterAgent and returns the simulator sending CCRs
+func NewDiameterSimulator(network, addr, originHost, originRealm, dictsDir, timezone string,
+	rplyTimeout time.Duration) (ds *DiameterSimulator, err error) {
+	ds = &DiameterSimulator{timezone: timezone, rplyTimeout: rplyTimeout}
+	if ds.dc, err = NewDiameterClient(addr, originHost, originRealm, 0,
+		utils.CGRateS, 1, dictsDir, network); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DiameterSimulator sends Credit-Control-Requests over one Diameter connection
+type DiameterSimulator struct {
+	dc          *DiameterClient
+	timezone    string
+	rplyTimeout time.Duration
+}
+
+// SendRequest sends the CCR and checks the Result-Code of the answer
+func (ds *DiameterSimulator) SendRequest(tpl []*config.FCTemplate, ev utils.DataProvider) (err error) {
+	var reqNM *utils.OrderedNavigableMap
+	if reqNM, err = simBuildRequest(tpl, ev, ds.timezone); err != nil {
+		return
+	}
+	m := diam.NewRequest(diam.CreditControl, 4, dict.Default)
+	if err = updateDiamMsgFromNavMap(m, reqNM, ds.timezone); err != nil {
+		return
+	}
+	var ans *diam.Message
+	if ans, err = ds.dc.SendRequest(m, ds.rplyTimeout); err != nil {
+		return
+	}
+	var rcAVP *diam.AVP
+	if rcAVP, err = ans.FindAVP(avp.ResultCode, dict.UndefinedVendorID); err != nil {
+		return
+	}
+	var rc interface{}
+	if rc, err = diamAVPAsIface(rcAVP); err != nil {
+		return
+	} else if rc != uint32(diam.Success) {
+		return fmt.Errorf("Wrong result code: <%v>", rc)
+	}
+	return
+}
+
+// Close is part of AgentSimulator interface
+func (ds *DiameterSimulator) Close() error {
+	ds.dc.conn.Close()
+	return nil
+}
+
+// NewRadiusSimulator connects to the auth and acct listeners of the RadiusAgent
+func NewRadiusSimulator(network, authAddr, acctAddr, secret, dictsDir,
+	timezone string) (rs *RadiusSimulator, err error) {
+	dict := radigo.RFC2865Dictionary()
+	if dictsDir != "" {
+		if dict, err = radigo.NewDictionaryFromFolderWithRFC2865(dictsDir); err != nil {
+			return
+		}
+	}
+	rs = &RadiusSimulator{timezone: timezone, ids: make(chan uint8, 256)}
+	for i := 0; i < 256; i++ {
+		rs.ids <- uint8(i)
+	}
+	if rs.authClnt, err = radigo.NewClient(network, authAddr, secret, dict, 1, nil); err != nil {
+		return nil, err
+	}
+	if rs.acctClnt, err = radigo.NewClient(network, acctAddr, secret, dict, 1, nil); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// RadiusSimulator sends Access-Requests and Accounting-Requests
+type RadiusSimulator struct {
+	authClnt *radigo.Client
+	acctClnt *radigo.Client
+	timezone string
+	ids      chan uint8 // free packet identifiers, one request in flight per identifier
+}
+
+// SendRequest sends an Accounting-Request if the template populates the
+// Acct-Status-Type, an Access-Request otherwise
+func (rs *RadiusSimulator) SendRequest(tpl []*config.FCTemplate, ev utils.DataProvider) (err error) {
+	var reqNM *utils.OrderedNavigableMap
+	if reqNM, err = simBuildRequest(tpl, ev, rs.timezone); err != nil {
+		return
+	}
+	id := <-rs.ids
+	defer func() { rs.ids <- id }()
+	req := rs.authClnt.NewRequest(radigo.AccessRequest, id)
+	if err = radReplyAppendAttributes(req, reqNM); err != nil {
+		return
+	}
+	if req.Code == radigo.AccessRequest &&
+		len(req.AttributesWithName("Acct-Status-Type", utils.EmptyString)) != 0 {
+		req.Code = radigo.AccountingRequest
+	}
+	clnt := rs.authClnt
+	if req.Code == radigo.AccountingRequest {
+		clnt = rs.acctClnt
+	}
+	var rpl *radigo.Packet
+	if rpl, err = clnt.SendRequest(req); err != nil {
+		return
+	}
+	if rpl.Code != radigo.AccessAccept && rpl.Code != radigo.AccountingResponse {
+		return fmt.Errorf("unexpected reply code: <%s>", rpl.Code)
+	}
+	return
+}
+
+// Close is part of AgentSimulator interface
+func (rs *RadiusSimulator) Close() error {
+	return nil
+}
+
+// NewSIPSimulator returns the simulator sending SIP requests to the SIPAgent
+func NewSIPSimulator(network, addr, timezone string, rplyTimeout time.Duration) *SIPSimulator {
+	return &SIPSimulator{network: network, addr: addr,
+		timezone: timezone, rplyTimeout: rplyTimeout}
+}
+
+// SIPSimulator sends SIP requests, each over its own socket so the replies are not mixed
+type SIPSimulator struct {
+	network     string
+	addr        string
+	timezone    string
+	rplyTimeout time.Duration
+}
+
+// SendRequest sends the SIP request and acknowledges the final reply of an INVITE.
+// Reply codes of 400 and above are considered errors
+func (ss *SIPSimulator) SendRequest(tpl []*config.FCTemplate, ev utils.DataProvider) (err error) {
+	var reqNM *utils.OrderedNavigableMap
+	if reqNM, err = simBuildRequest(tpl, ev, ss.timezone); err != nil {
+		return
+	}
+	req := make(sipingo.Message)
+	if err = updateSIPMsgFromNavMap(req, reqNM); err != nil {
+		return
+	}
+	var conn net.Conn
+	if conn, err = net.Dial(ss.network, ss.addr); err != nil {
+		return
+	}
+	defer conn.Close()
+	if _, err = conn.Write([]byte(req.String())); err != nil {
+		return
+	}
+	var rplCode int
+	buf := make([]byte, bufferSize)
+	for rplCode < 200 { // skip the provisional replies
+		if err = conn.SetReadDeadline(time.Now().Add(ss.rplyTimeout)); err != nil {
+			return
+		}
+		var n int
+		if n, err = conn.Read(buf); err != nil {
+			return
+		}
+		var rpl sipingo.Message
+		if rpl, err = sipingo.NewMessage(string(buf[:n])); err != nil {
+			return
+		}
+		if rplCode, err = sipReplyCode(rpl); err != nil {
+			return
+		}
+	}
+	if req.MethodFrom(requestHeader) == inviteMethod {
+		ack := req.Clone()
+		ack[requestHeader] = strings.Replace(ack[requestHeader], inviteMethod, "ACK", 1)
+		ack["CSeq"] = strings.Replace(ack["CSeq"], inviteMethod, "ACK", 1)
+		ack.PrepareReply()
+		if _, err = conn.Write([]byte(ack.String())); err != nil {
+			return
+		}
+	}
+	if rplCode >= 400 {
+		return fmt.Errorf("unexpected reply code: <%d>", rplCode)
+	}
+	return
+}
+
+// Close is part of AgentSimulator interface
+func (ss *SIPSimulator) Close() error {
+	return nil
+}
+
+// sipReplyCode returns the status code out of the status line of a SIP reply
+func sipReplyCode(rpl sipingo.Message) (code int, err error) {
+	statusLine := strings.Fields(rpl[requestHeader])
+	if len(statusLine) < 2 {
+		return 0, fmt.Errorf("invalid status line: <%s>", rpl[requestHeader])
+	}
+	return strconv.Atoi(statusLine[1])
+}
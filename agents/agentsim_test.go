@@ -0,0 +1,295 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+	"github.com/cgrates/sipingo"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+// testSIPServer replies with rplyStatus to the first request and forwards the following ones
+func testSIPServer(t *testing.T, rplyStatus string) (addr string, reqs chan sipingo.Message) {
+	conn, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs = make(chan sipingo.Message, 2)
+	go func() {
+		defer conn.Close()
+		buf := make([]byte, bufferSize)
+		for i := 0; i < 2; i++ {
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			n, raddr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			req, err := sipingo.NewMessage(string(buf[:n]))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reqs <- req
+			if i == 0 {
+				rpl := req.Clone()
+				rpl.PrepareReply()
+				rpl[requestHeader] = rplyStatus
+				conn.WriteTo([]byte(rpl.String()), raddr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String(), reqs
+}
+
+func TestSIPSimulatorSendRequest(t *testing.T) {
+	tpl := []*config.FCTemplate{
+		{Tag: "Request", Path: utils.MetaDiamreq + utils.NestingSep + requestHeader,
+			Type:  utils.MetaComposed,
+			Value: config.NewRSRParsersMustCompile("INVITE sip:;~*req.Destination;@127.0.0.1` SIP/2.0`", utils.InfieldSep)},
+		{Tag: "CallID", Path: utils.MetaDiamreq + utils.NestingSep + "Call-ID",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.OriginID", utils.InfieldSep)},
+		{Tag: "CSeq", Path: utils.MetaDiamreq + utils.NestingSep + "CSeq",
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("1 INVITE", utils.InfieldSep)},
+	}
+	for _, fld := range tpl {
+		fld.ComputePath()
+	}
+	ev := utils.MapStorage{utils.OriginID: "abcdef", utils.Destination: "1002"}
+
+	addr, reqs := testSIPServer(t, "SIP/2.0 302 Moved Temporarily")
+	ss := NewSIPSimulator(utils.UDP, addr, utils.EmptyString, time.Second)
+	if err := ss.SendRequest(tpl, ev); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-reqs; req[requestHeader] != "INVITE sip:1002@127.0.0.1 SIP/2.0" ||
+		req["Call-ID"] != "abcdef" {
+		t.Errorf("Unexpected INVITE: %s", utils.ToJSON(req))
+	}
+	if ack := <-reqs; ack[requestHeader] != "ACK sip:1002@127.0.0.1 SIP/2.0" ||
+		ack["CSeq"] != "1 ACK" {
+		t.Errorf("Unexpected ACK: %s", utils.ToJSON(ack))
+	}
+
+	addr, _ = testSIPServer(t, "SIP/2.0 503 Service Unavailable")
+	ss = NewSIPSimulator(utils.UDP, addr, utils.EmptyString, time.Second)
+	expErr := "unexpected reply code: <503>"
+	if err := ss.SendRequest(tpl, ev); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+}
+
+func TestSIPReplyCode(t *testing.T) {
+	if code, err := sipReplyCode(sipingo.Message{requestHeader: "SIP/2.0 100 Trying"}); err != nil {
+		t.Error(err)
+	} else if code != 100 {
+		t.Errorf("Expected: 100, received: %d", code)
+	}
+	if _, err := sipReplyCode(sipingo.Message{requestHeader: "SIP/2.0"}); err == nil {
+		t.Error("Expected error for invalid status line")
+	}
+}
+
+// testRadServer mocks a RADIUS server replying with rplyCode to the first request received
+func testRadServer(t *testing.T, secret string, rplyCode radigo.PacketCode) (addr string, reqs chan *radigo.Packet) {
+	conn, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs = make(chan *radigo.Packet, 1)
+	go func() {
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		var buf [4096]byte
+		n, raddr, err := conn.ReadFrom(buf[:])
+		if err != nil {
+			close(reqs)
+			return
+		}
+		req := radigo.NewPacket(0, 0, dictRad, coder, secret)
+		if err = req.Decode(buf[:n]); err != nil {
+			t.Error(err)
+		}
+		reqAuth := make([]byte, 16)
+		copy(reqAuth, buf[4:20])
+		req.SetAVPValues()
+		reqs <- req
+		rpl := radigo.NewPacket(rplyCode, req.Identifier, dictRad, coder, secret)
+		if n, err = rpl.Encode(buf[:]); err != nil {
+			t.Error(err)
+		}
+		copy(buf[4:20], reqAuth)
+		copy(buf[4:20], radDAAuthenticator(buf[:n], secret))
+		conn.WriteTo(buf[:n], raddr)
+	}()
+	return conn.LocalAddr().String(), reqs
+}
+
+func TestRadiusSimulatorSendRequest(t *testing.T) {
+	authTpl := []*config.FCTemplate{
+		{Tag: "UserName", Path: utils.MetaDiamreq + utils.NestingSep + "User-Name",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Account", utils.InfieldSep)},
+	}
+	acctTpl := []*config.FCTemplate{
+		{Tag: "UserName", Path: utils.MetaDiamreq + utils.NestingSep + "User-Name",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Account", utils.InfieldSep)},
+		{Tag: "AcctStatusType", Path: utils.MetaDiamreq + utils.NestingSep + "Acct-Status-Type",
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("1", utils.InfieldSep)},
+	}
+	for _, tpl := range [][]*config.FCTemplate{authTpl, acctTpl} {
+		for _, fld := range tpl {
+			fld.ComputePath()
+		}
+	}
+	ev := utils.MapStorage{utils.AccountField: "1001"}
+
+	authAddr, authReqs := testRadServer(t, "CGRateS.org", radigo.AccessAccept)
+	acctAddr, acctReqs := testRadServer(t, "CGRateS.org", radigo.AccountingResponse)
+	rs, err := NewRadiusSimulator(utils.UDP, authAddr, acctAddr, "CGRateS.org",
+		utils.EmptyString, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without Acct-Status-Type an Access-Request is sent to the auth address
+	if err = rs.SendRequest(authTpl, ev); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-authReqs; req.Code != radigo.AccessRequest {
+		t.Errorf("Expected code: %d, received: %d", radigo.AccessRequest, req.Code)
+	} else if avps := req.AttributesWithName("User-Name", ""); len(avps) != 1 ||
+		avps[0].GetStringValue() != "1001" {
+		t.Errorf("Unexpected User-Name in request: %s", utils.ToJSON(avps))
+	}
+	// with Acct-Status-Type an Accounting-Request is sent to the acct address
+	if err = rs.SendRequest(acctTpl, ev); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-acctReqs; req.Code != radigo.AccountingRequest {
+		t.Errorf("Expected code: %d, received: %d", radigo.AccountingRequest, req.Code)
+	}
+
+	authAddr, _ = testRadServer(t, "CGRateS.org", radigo.AccessReject)
+	if rs, err = NewRadiusSimulator(utils.UDP, authAddr, acctAddr, "CGRateS.org",
+		utils.EmptyString, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	expErr := "unexpected reply code: <AccessReject>"
+	if err = rs.SendRequest(authTpl, ev); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+}
+
+// testDiamServer mocks a Diameter server answering the CCRs with the result codes received on rcs,
+// each real answer being preceded by one with an unknown Hop-by-Hop Identifier
+func testDiamServer(t *testing.T, rcs chan uint32) (addr string) {
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dSM := sm.New(&sm.Settings{
+		OriginHost:       datatype.DiameterIdentity("diam-server"),
+		OriginRealm:      datatype.DiameterIdentity("cgrates.org"),
+		VendorID:         datatype.Unsigned32(0),
+		ProductName:      datatype.UTF8String(utils.CGRateS),
+		FirmwareRevision: datatype.Unsigned32(1),
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	})
+	dSM.HandleFunc("CCR", func(c diam.Conn, m *diam.Message) {
+		other := m.Answer(diam.UnableToComply)
+		other.Header.HopByHopID++
+		if _, err := other.WriteTo(c); err != nil {
+			t.Error(err)
+		}
+		if _, err := m.Answer(<-rcs).WriteTo(c); err != nil {
+			t.Error(err)
+		}
+	})
+	go diam.Serve(l, dSM)
+	t.Cleanup(func() { l.Close() })
+	return l.Addr().String()
+}
+
+func TestDiameterSimulatorSendRequest(t *testing.T) {
+	tpl := []*config.FCTemplate{
+		{Tag: "SessionId", Path: utils.MetaDiamreq + utils.NestingSep + "Session-Id",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.OriginID", utils.InfieldSep)},
+		{Tag: "OriginHost", Path: utils.MetaDiamreq + utils.NestingSep + "Origin-Host",
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("cgr-tester", utils.InfieldSep)},
+		{Tag: "OriginRealm", Path: utils.MetaDiamreq + utils.NestingSep + "Origin-Realm",
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep)},
+		{Tag: "RequestType", Path: utils.MetaDiamreq + utils.NestingSep + "CC-Request-Type",
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("1", utils.InfieldSep)},
+		{Tag: "RequestNumber", Path: utils.MetaDiamreq + utils.NestingSep + "CC-Request-Number",
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("0", utils.InfieldSep)},
+	}
+	for _, fld := range tpl {
+		fld.ComputePath()
+	}
+	ev := utils.MapStorage{utils.OriginID: "abcdef"}
+
+	rcs := make(chan uint32, 1)
+	ds, err := NewDiameterSimulator(utils.TCP, testDiamServer(t, rcs), "cgr-tester", "cgrates.org",
+		utils.EmptyString, utils.EmptyString, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ds.Close()
+	unmatched := make(chan *diam.Message, 2)
+	go func() {
+		for m := range ds.dc.received {
+			unmatched <- m
+		}
+	}()
+	// the answer is matched on the Hop-by-Hop Identifier, ignoring the one answering another request
+	rcs <- diam.Success
+	if err = ds.SendRequest(tpl, ev); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case m := <-unmatched:
+		if m.Header.CommandCode != diam.CreditControl {
+			t.Errorf("Unexpected unmatched message: %s", m)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the unmatched answer to be received")
+	}
+
+	rcs <- diam.UnableToComply
+	expErr := "Wrong result code: <5012>"
+	if err = ds.SendRequest(tpl, ev); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+}
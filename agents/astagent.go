@@ -376,6 +376,11 @@ func (sma *AsteriskAgent) V1WarnDisconnect(args map[string]interface{}, reply *s
 	return utils.ErrNotImplemented
 }
 
+// V1AlterSession is used to implement the sessions.BiRPClient interface
+func (*AsteriskAgent) V1AlterSession(args utils.AttrAlterSession, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
 // CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
 func (sma *AsteriskAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
 	return utils.BiRPCCall(sma, clnt, serviceMethod, args, reply)
@@ -407,6 +412,11 @@ func (sma *AsteriskAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector,
 	return sma.V1WarnDisconnect(args, reply)
 }
 
+// BiRPCv1AlterSession is used to implement the sessions.BiRPClient interface
+func (sma *AsteriskAgent) BiRPCv1AlterSession(clnt rpcclient.ClientConnector, args utils.AttrAlterSession, reply *string) (err error) {
+	return sma.V1AlterSession(args, reply)
+}
+
 // Handlers is used to implement the rpcclient.BiRPCConector interface
 func (sma *AsteriskAgent) Handlers() map[string]interface{} {
 	return map[string]interface{}{
@@ -425,5 +435,8 @@ func (sma *AsteriskAgent) Handlers() map[string]interface{} {
 		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
 			return sma.BiRPCv1WarnDisconnect(clnt, args, rply)
 		},
+		utils.SessionSv1AlterSession: func(clnt *rpc2.Client, args utils.AttrAlterSession, rply *string) (err error) {
+			return sma.BiRPCv1AlterSession(clnt, args, rply)
+		},
 	}
 }
@@ -750,6 +750,11 @@ func (*DiameterAgent) V1WarnDisconnect(args map[string]interface{}, reply *strin
 	return utils.ErrNotImplemented
 }
 
+// V1AlterSession is used to implement the sessions.BiRPClient interface
+func (*DiameterAgent) V1AlterSession(args utils.AttrAlterSession, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
 // CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
 func (da *DiameterAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
 	return utils.BiRPCCall(da, clnt, serviceMethod, args, reply)
@@ -782,6 +787,11 @@ func (da *DiameterAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, a
 	return da.V1WarnDisconnect(args, reply)
 }
 
+// BiRPCv1AlterSession is used to implement the sessions.BiRPClient interface
+func (da *DiameterAgent) BiRPCv1AlterSession(clnt rpcclient.ClientConnector, args utils.AttrAlterSession, reply *string) (err error) {
+	return da.V1AlterSession(args, reply)
+}
+
 // Handlers is used to implement the rpcclient.BiRPCConector interface
 func (da *DiameterAgent) Handlers() map[string]interface{} {
 	return map[string]interface{}{
@@ -800,5 +810,8 @@ func (da *DiameterAgent) Handlers() map[string]interface{} {
 		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
 			return da.BiRPCv1WarnDisconnect(clnt, args, rply)
 		},
+		utils.SessionSv1AlterSession: func(clnt *rpc2.Client, args utils.AttrAlterSession, rply *string) (err error) {
+			return da.BiRPCv1AlterSession(clnt, args, rply)
+		},
 	}
 }
@@ -86,7 +86,8 @@ func NewDiameterClient(addr, originHost, originRealm string, vendorId int, produ
 	if err != nil {
 		return nil, err
 	}
-	dc = &DiameterClient{conn: conn, handlers: dSM, received: make(chan *diam.Message)}
+	dc = &DiameterClient{conn: conn, handlers: dSM, received: make(chan *diam.Message),
+		answers: make(map[uint32]chan *diam.Message)}
 	dSM.HandleFunc("ALL", dc.handleALL)
 	return dc, nil
 }
@@ -95,6 +96,8 @@ type DiameterClient struct {
 	conn     diam.Conn
 	handlers diam.Handler
 	received chan *diam.Message
+	answers  map[uint32]chan *diam.Message // answers waited by SendRequest, indexed on Hop-by-Hop Identifier
+	ansLck   sync.Mutex
 }
 
 func (dc *DiameterClient) SendMessage(m *diam.Message) error {
@@ -102,7 +105,38 @@ func (dc *DiameterClient) SendMessage(m *diam.Message) error {
 	return err
 }
 
+// SendRequest sends the request and waits for the answer matching its Hop-by-Hop Identifier
+func (dc *DiameterClient) SendRequest(m *diam.Message, rplyTimeout time.Duration) (ans *diam.Message, err error) {
+	ansCh := make(chan *diam.Message, 1)
+	dc.ansLck.Lock()
+	dc.answers[m.Header.HopByHopID] = ansCh
+	dc.ansLck.Unlock()
+	defer func() {
+		dc.ansLck.Lock()
+		delete(dc.answers, m.Header.HopByHopID)
+		dc.ansLck.Unlock()
+	}()
+	if err = dc.SendMessage(m); err != nil {
+		return
+	}
+	select {
+	case ans = <-ansCh:
+	case <-time.After(rplyTimeout):
+		err = utils.ErrTimedOut
+	}
+	return
+}
+
 func (dc *DiameterClient) handleALL(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 { // answer
+		dc.ansLck.Lock()
+		ansCh, has := dc.answers[m.Header.HopByHopID]
+		dc.ansLck.Unlock()
+		if has {
+			ansCh <- m
+			return
+		}
+	}
 	utils.Logger.Warning(fmt.Sprintf("<DiameterClient> Received unexpected message from %s:\n%s", c.RemoteAddr(), m))
 	dc.received <- m
 }
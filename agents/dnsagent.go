@@ -91,12 +91,12 @@ func (da *DNSAgent) handleMessage(w dns.ResponseWriter, req *dns.Msg) {
 	dnsDP := newDNSDataProvider(req, w)
 	reqVars := make(utils.NavigableMap2)
 	reqVars[QueryType] = utils.NewNMData(dns.TypeToString[req.Question[0].Qtype])
+	reqVars[QueryName] = utils.NewNMData(req.Question[0].Name)
 	rply := new(dns.Msg)
 	rply.SetReply(req)
 	// message preprocesing
 	switch req.Question[0].Qtype {
 	case dns.TypeNAPTR:
-		reqVars[QueryName] = utils.NewNMData(req.Question[0].Name)
 		e164, err := e164FromNAPTR(req.Question[0].Name)
 		if err != nil {
 			utils.Logger.Warning(
@@ -482,6 +482,11 @@ func (fsa *FSsessions) V1WarnDisconnect(args map[string]interface{}, reply *stri
 	return
 }
 
+// V1AlterSession is used to implement the sessions.BiRPClient interface
+func (*FSsessions) V1AlterSession(args utils.AttrAlterSession, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
 // CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
 func (fsa *FSsessions) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
 	return utils.BiRPCCall(fsa, clnt, serviceMethod, args, reply)
@@ -514,6 +519,11 @@ func (fsa *FSsessions) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, arg
 	return fsa.V1WarnDisconnect(args, reply)
 }
 
+// BiRPCv1AlterSession is used to implement the sessions.BiRPClient interface
+func (fsa *FSsessions) BiRPCv1AlterSession(clnt rpcclient.ClientConnector, args utils.AttrAlterSession, reply *string) (err error) {
+	return fsa.V1AlterSession(args, reply)
+}
+
 // Handlers is used to implement the rpcclient.BiRPCConector interface
 func (fsa *FSsessions) Handlers() map[string]interface{} {
 	return map[string]interface{}{
@@ -532,5 +542,8 @@ func (fsa *FSsessions) Handlers() map[string]interface{} {
 		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
 			return fsa.BiRPCv1WarnDisconnect(clnt, args, rply)
 		},
+		utils.SessionSv1AlterSession: func(clnt *rpc2.Client, args utils.AttrAlterSession, rply *string) (err error) {
+			return fsa.BiRPCv1AlterSession(clnt, args, rply)
+		},
 	}
 }
@@ -446,6 +446,11 @@ func (*KamailioAgent) V1WarnDisconnect(args map[string]interface{}, reply *strin
 	return utils.ErrNotImplemented
 }
 
+// V1AlterSession is used to implement the sessions.BiRPClient interface
+func (*KamailioAgent) V1AlterSession(args utils.AttrAlterSession, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
 // CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
 func (ka *KamailioAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
 	return utils.BiRPCCall(ka, clnt, serviceMethod, args, reply)
@@ -478,6 +483,11 @@ func (ka *KamailioAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, a
 	return ka.V1WarnDisconnect(args, reply)
 }
 
+// BiRPCv1AlterSession is used to implement the sessions.BiRPClient interface
+func (ka *KamailioAgent) BiRPCv1AlterSession(clnt rpcclient.ClientConnector, args utils.AttrAlterSession, reply *string) (err error) {
+	return ka.V1AlterSession(args, reply)
+}
+
 // Handlers is used to implement the rpcclient.BiRPCConector interface
 func (ka *KamailioAgent) Handlers() map[string]interface{} {
 	return map[string]interface{}{
@@ -496,5 +506,8 @@ func (ka *KamailioAgent) Handlers() map[string]interface{} {
 		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
 			return ka.BiRPCv1WarnDisconnect(clnt, args, rply)
 		},
+		utils.SessionSv1AlterSession: func(clnt *rpc2.Client, args utils.AttrAlterSession, rply *string) (err error) {
+			return ka.BiRPCv1AlterSession(clnt, args, rply)
+		},
 	}
 }
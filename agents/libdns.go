@@ -125,34 +125,53 @@ func dnsWriteMsg(w dns.ResponseWriter, msg *dns.Msg) (err error) {
 
 // appendDNSAnswer will append the right answer payload to the message
 func appendDNSAnswer(msg *dns.Msg) (err error) {
+	hdr := dns.RR_Header{
+		Name:   msg.Question[0].Name,
+		Rrtype: msg.Question[0].Qtype,
+		Class:  dns.ClassINET,
+		Ttl:    60}
 	switch msg.Question[0].Qtype {
 	case dns.TypeA:
-		msg.Answer = append(msg.Answer,
-			&dns.A{
-				Hdr: dns.RR_Header{
-					Name:   msg.Question[0].Name,
-					Rrtype: dns.TypeA,
-					Class:  dns.ClassINET,
-					Ttl:    60},
-			},
-		)
+		msg.Answer = append(msg.Answer, &dns.A{Hdr: hdr})
+	case dns.TypeAAAA:
+		msg.Answer = append(msg.Answer, &dns.AAAA{Hdr: hdr})
 	case dns.TypeNAPTR:
-		msg.Answer = append(msg.Answer,
-			&dns.NAPTR{
-				Hdr: dns.RR_Header{
-					Name:   msg.Question[0].Name,
-					Rrtype: msg.Question[0].Qtype,
-					Class:  dns.ClassINET,
-					Ttl:    60},
-			},
-		)
+		msg.Answer = append(msg.Answer, &dns.NAPTR{Hdr: hdr})
+	case dns.TypeSRV:
+		msg.Answer = append(msg.Answer, &dns.SRV{Hdr: hdr})
+	case dns.TypeTXT:
+		msg.Answer = append(msg.Answer, &dns.TXT{Hdr: hdr})
 	default:
 		return fmt.Errorf("unsupported DNS type: <%v>", msg.Question[0].Qtype)
 	}
 	return
 }
 
+// dnsAnswerUint16 checks the type of the answer and converts the item data for the uint16 fields
+func dnsAnswerUint16(msg *dns.Msg, fld string, qType uint16, itmData interface{}) (val uint16, err error) {
+	if msg.Question[0].Qtype != qType {
+		return 0, fmt.Errorf("field <%s> only works with %s", fld, dns.TypeToString[qType])
+	}
+	var itm int64
+	if itm, err = utils.IfaceAsInt64(itmData); err != nil {
+		return 0, fmt.Errorf("item: <%s>, err: %s", fld, err.Error())
+	}
+	return uint16(itm), nil
+}
+
+// dnsAnswerIP checks the type of the answer and parses the item data as IP address
+func dnsAnswerIP(msg *dns.Msg, fld string, qType uint16, itmData interface{}) (ip net.IP, err error) {
+	if msg.Question[0].Qtype != qType {
+		return nil, fmt.Errorf("field <%s> only works with %s", fld, dns.TypeToString[qType])
+	}
+	if ip = net.ParseIP(utils.IfaceAsString(itmData)); ip == nil {
+		return nil, fmt.Errorf("item: <%s>, err: invalid IP address: <%s>", fld, utils.IfaceAsString(itmData))
+	}
+	return
+}
+
 // updateDNSMsgFromNM will update DNS message with values from NavigableMap
+// a field already populated for the current answer will start a new one
 func updateDNSMsgFromNM(msg *dns.Msg, nm *utils.OrderedNavigableMap) (err error) {
 	msgFields := make(utils.StringSet) // work around to NMap issue
 	for el := nm.GetFirstElement(); el != nil; el = el.Next() {
@@ -168,6 +187,15 @@ func updateDNSMsgFromNM(msg *dns.Msg, nm *utils.OrderedNavigableMap) (err error)
 		if len(cfgItm.Path) == 0 {
 			return errors.New("empty path in config item")
 		}
+		itmData := cfgItm.Data
+		if cfgItm.Path[0] == utils.Rcode { // header field, not part of the answers
+			var itm int64
+			if itm, err = utils.IfaceAsInt64(itmData); err != nil {
+				return fmt.Errorf("item: <%s>, err: %s", cfgItm.Path[0], err.Error())
+			}
+			msg.Rcode = int(itm)
+			continue
+		}
 		apnd := len(msg.Answer) == 0
 		if msgFields.Has(cfgItm.Path[0]) { // force append if the same path was already used
 			apnd = true
@@ -178,52 +206,81 @@ func updateDNSMsgFromNM(msg *dns.Msg, nm *utils.OrderedNavigableMap) (err error)
 			}
 			msgFields = make(utils.StringSet) // reset the fields inside since we have a new message
 		}
-		itmData := cfgItm.Data
+		answer := msg.Answer[len(msg.Answer)-1]
+		var u16 uint16
+		var ip net.IP
 		switch cfgItm.Path[0] {
-		case utils.Rcode:
+		case utils.Ttl:
 			var itm int64
 			if itm, err = utils.IfaceAsInt64(itmData); err != nil {
 				return fmt.Errorf("item: <%s>, err: %s", cfgItm.Path[0], err.Error())
 			}
-			msg.Rcode = int(itm)
+			answer.Header().Ttl = uint32(itm)
 		case utils.Order:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Order)
-			}
-			var itm int64
-			if itm, err = utils.IfaceAsInt64(itmData); err != nil {
-				return fmt.Errorf("item: <%s>, err: %s", cfgItm.Path[0], err.Error())
+			if u16, err = dnsAnswerUint16(msg, utils.Order, dns.TypeNAPTR, itmData); err != nil {
+				return
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Order = uint16(itm)
+			answer.(*dns.NAPTR).Order = u16
 		case utils.Preference:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Preference)
-			}
-			var itm int64
-			if itm, err = utils.IfaceAsInt64(itmData); err != nil {
-				return fmt.Errorf("item: <%s>, err: %s", cfgItm.Path[0], err.Error())
+			if u16, err = dnsAnswerUint16(msg, utils.Preference, dns.TypeNAPTR, itmData); err != nil {
+				return
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Preference = uint16(itm)
+			answer.(*dns.NAPTR).Preference = u16
 		case utils.Flags:
 			if msg.Question[0].Qtype != dns.TypeNAPTR {
 				return fmt.Errorf("field <%s> only works with NAPTR", utils.Flags)
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Flags = utils.IfaceAsString(itmData)
+			answer.(*dns.NAPTR).Flags = utils.IfaceAsString(itmData)
 		case utils.Service:
 			if msg.Question[0].Qtype != dns.TypeNAPTR {
 				return fmt.Errorf("field <%s> only works with NAPTR", utils.Service)
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Service = utils.IfaceAsString(itmData)
+			answer.(*dns.NAPTR).Service = utils.IfaceAsString(itmData)
 		case utils.Regexp:
 			if msg.Question[0].Qtype != dns.TypeNAPTR {
 				return fmt.Errorf("field <%s> only works with NAPTR", utils.Regexp)
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Regexp = utils.IfaceAsString(itmData)
+			answer.(*dns.NAPTR).Regexp = utils.IfaceAsString(itmData)
 		case utils.Replacement:
 			if msg.Question[0].Qtype != dns.TypeNAPTR {
 				return fmt.Errorf("field <%s> only works with NAPTR", utils.Replacement)
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Replacement = utils.IfaceAsString(itmData)
+			answer.(*dns.NAPTR).Replacement = utils.IfaceAsString(itmData)
+		case utils.DNSA:
+			if ip, err = dnsAnswerIP(msg, utils.DNSA, dns.TypeA, itmData); err != nil {
+				return
+			}
+			answer.(*dns.A).A = ip
+		case utils.DNSAAAA:
+			if ip, err = dnsAnswerIP(msg, utils.DNSAAAA, dns.TypeAAAA, itmData); err != nil {
+				return
+			}
+			answer.(*dns.AAAA).AAAA = ip
+		case utils.Priority:
+			if u16, err = dnsAnswerUint16(msg, utils.Priority, dns.TypeSRV, itmData); err != nil {
+				return
+			}
+			answer.(*dns.SRV).Priority = u16
+		case utils.Weight:
+			if u16, err = dnsAnswerUint16(msg, utils.Weight, dns.TypeSRV, itmData); err != nil {
+				return
+			}
+			answer.(*dns.SRV).Weight = u16
+		case utils.Port:
+			if u16, err = dnsAnswerUint16(msg, utils.Port, dns.TypeSRV, itmData); err != nil {
+				return
+			}
+			answer.(*dns.SRV).Port = u16
+		case utils.Target:
+			if msg.Question[0].Qtype != dns.TypeSRV {
+				return fmt.Errorf("field <%s> only works with SRV", utils.Target)
+			}
+			answer.(*dns.SRV).Target = dns.Fqdn(utils.IfaceAsString(itmData))
+		case utils.Txt:
+			if msg.Question[0].Qtype != dns.TypeTXT {
+				return fmt.Errorf("field <%s> only works with TXT", utils.Txt)
+			}
+			answer.(*dns.TXT).Txt = []string{utils.IfaceAsString(itmData)}
 		}
 
 		msgFields.Add(cfgItm.Path[0]) // detect new branch
@@ -339,3 +339,150 @@ func TestUpdateDNSMsgFromNM(t *testing.T) {
 	}
 
 }
+
+// testDNSReplyNM populates the reply fields out of the template, as the DNSAgent does
+func testDNSReplyNM(t *testing.T, tpl []*config.FCTemplate) *utils.OrderedNavigableMap {
+	for _, fld := range tpl {
+		fld.ComputePath()
+	}
+	aReq := NewAgentRequest(utils.MapStorage{}, nil, nil, nil, nil, nil,
+		"cgrates.org", utils.EmptyString, nil, nil, nil)
+	if err := aReq.SetFields(tpl); err != nil {
+		t.Fatal(err)
+	}
+	return aReq.Reply
+}
+
+func TestUpdateDNSMsgFromNMMultipleAnswers(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("3.6.9.4.7.1.7.1.5.6.8.9.4.e164.arpa.", dns.TypeNAPTR)
+	rplyNM := testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "Rcode", Path: utils.MetaRep + utils.NestingSep + utils.Rcode,
+			Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("0", utils.InfieldSep)},
+		{Tag: "Order1", Path: utils.MetaRep + utils.NestingSep + utils.Order,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("100", utils.InfieldSep)},
+		{Tag: "Regexp1", Path: utils.MetaRep + utils.NestingSep + utils.Regexp,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("!^(.*)$!sip:1@172.16.1.11!", utils.InfieldSep)},
+		{Tag: "Order2", NewBranch: true, Path: utils.MetaRep + utils.NestingSep + utils.Order,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("200", utils.InfieldSep)},
+		{Tag: "Regexp2", Path: utils.MetaRep + utils.NestingSep + utils.Regexp,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("!^(.*)$!sip:1@172.16.1.12!", utils.InfieldSep)},
+		{Tag: "Ttl2", Path: utils.MetaRep + utils.NestingSep + utils.Ttl,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("300", utils.InfieldSep)},
+	})
+	if err := updateDNSMsgFromNM(m, rplyNM); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Answer) != 2 {
+		t.Fatalf("wrong number of answers: %s", utils.ToJSON(m.Answer))
+	}
+	if answr := m.Answer[0].(*dns.NAPTR); answr.Order != 100 ||
+		answr.Regexp != "!^(.*)$!sip:1@172.16.1.11!" || answr.Hdr.Ttl != 60 {
+		t.Errorf("unexpected first answer: %s", utils.ToJSON(answr))
+	}
+	if answr := m.Answer[1].(*dns.NAPTR); answr.Order != 200 ||
+		answr.Regexp != "!^(.*)$!sip:1@172.16.1.12!" || answr.Hdr.Ttl != 300 {
+		t.Errorf("unexpected second answer: %s", utils.ToJSON(answr))
+	}
+
+	// Rcode alone does not create an answer
+	m = new(dns.Msg)
+	m.SetQuestion("3.6.9.4.7.1.7.1.5.6.8.9.4.e164.arpa.", dns.TypeNAPTR)
+	if err := updateDNSMsgFromNM(m, testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "Rcode", Path: utils.MetaRep + utils.NestingSep + utils.Rcode,
+			Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("3", utils.InfieldSep)},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if m.Rcode != dns.RcodeNameError || len(m.Answer) != 0 {
+		t.Errorf("unexpected message: %s", utils.ToJSON(m))
+	}
+}
+
+func TestUpdateDNSMsgFromNMSRV(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("_sip._udp.cgrates.org.", dns.TypeSRV)
+	rplyNM := testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "Priority1", Path: utils.MetaRep + utils.NestingSep + utils.Priority,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("10", utils.InfieldSep)},
+		{Tag: "Weight1", Path: utils.MetaRep + utils.NestingSep + utils.Weight,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("60", utils.InfieldSep)},
+		{Tag: "Port1", Path: utils.MetaRep + utils.NestingSep + utils.Port,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("5060", utils.InfieldSep)},
+		{Tag: "Target1", Path: utils.MetaRep + utils.NestingSep + utils.Target,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("sip1.cgrates.org", utils.InfieldSep)},
+		{Tag: "Priority2", NewBranch: true, Path: utils.MetaRep + utils.NestingSep + utils.Priority,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("20", utils.InfieldSep)},
+		{Tag: "Target2", Path: utils.MetaRep + utils.NestingSep + utils.Target,
+			Type: utils.MetaGroup, Value: config.NewRSRParsersMustCompile("sip2.cgrates.org.", utils.InfieldSep)},
+	})
+	if err := updateDNSMsgFromNM(m, rplyNM); err != nil {
+		t.Fatal(err)
+	}
+	exp := []dns.RR{
+		&dns.SRV{Hdr: dns.RR_Header{Name: "_sip._udp.cgrates.org.", Rrtype: dns.TypeSRV,
+			Class: dns.ClassINET, Ttl: 60},
+			Priority: 10, Weight: 60, Port: 5060, Target: "sip1.cgrates.org."},
+		&dns.SRV{Hdr: dns.RR_Header{Name: "_sip._udp.cgrates.org.", Rrtype: dns.TypeSRV,
+			Class: dns.ClassINET, Ttl: 60},
+			Priority: 20, Target: "sip2.cgrates.org."},
+	}
+	if !reflect.DeepEqual(exp, m.Answer) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(m.Answer))
+	}
+	if _, err := m.Pack(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateDNSMsgFromNMAddressAndTXT(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeAAAA)
+	if err := updateDNSMsgFromNM(m, testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "AAAA", Path: utils.MetaRep + utils.NestingSep + utils.DNSAAAA,
+			Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("2001:db8::1", utils.InfieldSep)},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if answr := m.Answer[0].(*dns.AAAA); answr.AAAA.String() != "2001:db8::1" {
+		t.Errorf("received: <%v>", answr.AAAA)
+	}
+
+	m = new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeA)
+	if err := updateDNSMsgFromNM(m, testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "A", Path: utils.MetaRep + utils.NestingSep + utils.DNSA,
+			Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("192.168.1.1", utils.InfieldSep)},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if answr := m.Answer[0].(*dns.A); answr.A.String() != "192.168.1.1" {
+		t.Errorf("received: <%v>", answr.A)
+	}
+	if err := updateDNSMsgFromNM(m, testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "A", Path: utils.MetaRep + utils.NestingSep + utils.DNSA,
+			Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("192.168.1", utils.InfieldSep)},
+	})); err == nil ||
+		err.Error() != `item: <A>, err: invalid IP address: <192.168.1>` {
+		t.Error(err)
+	}
+	if err := updateDNSMsgFromNM(m, testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "Port", Path: utils.MetaRep + utils.NestingSep + utils.Port,
+			Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("5060", utils.InfieldSep)},
+	})); err == nil ||
+		err.Error() != `field <Port> only works with SRV` {
+		t.Error(err)
+	}
+
+	m = new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeTXT)
+	if err := updateDNSMsgFromNM(m, testDNSReplyNM(t, []*config.FCTemplate{
+		{Tag: "Txt", Path: utils.MetaRep + utils.NestingSep + utils.Txt,
+			Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("cgr_cost=0.1", utils.InfieldSep)},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if answr := m.Answer[0].(*dns.TXT); !reflect.DeepEqual(answr.Txt, []string{"cgr_cost=0.1"}) {
+		t.Errorf("received: <%v>", answr.Txt)
+	}
+}
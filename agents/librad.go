@@ -19,8 +19,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
@@ -188,3 +193,71 @@ func radauthReq(flags utils.FlagsWithParams, req *radigo.Packet, aReq *AgentRequ
 
 	return true, nil
 }
+
+// radDATimeout is the time we wait for the reply of a Dynamic Authorization request
+var radDATimeout = time.Second
+
+// radDAAuthenticator computes the authenticator of the Dynamic Authorization packets(RFC 5176)
+// the authenticator field of the raw packet should be populated with the value which is hashed
+func radDAAuthenticator(raw []byte, secret string) []byte {
+	hash := md5.New()
+	hash.Write(raw)
+	hash.Write([]byte(secret))
+	return hash.Sum(nil)
+}
+
+// radDAExchange sends the Dynamic Authorization request over UDP and returns the reply
+// radigo.Client is not used since it does not sign this type of packets
+func radDAExchange(addr, secret string, dict *radigo.Dictionary,
+	req *radigo.Packet) (rpl *radigo.Packet, err error) {
+	var buf [4096]byte
+	var n int
+	if n, err = req.Encode(buf[:]); err != nil {
+		return
+	}
+	copy(buf[4:20], make([]byte, 16)) // request authenticator is computed over zeroed field
+	reqAuth := radDAAuthenticator(buf[:n], secret)
+	copy(buf[4:20], reqAuth)
+	var conn net.Conn
+	if conn, err = net.DialTimeout(utils.UDP, addr, radDATimeout); err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(radDATimeout))
+	if _, err = conn.Write(buf[:n]); err != nil {
+		return
+	}
+	var rplBuf [4096]byte
+	if n, err = conn.Read(rplBuf[:]); err != nil {
+		if nErr, canCast := err.(net.Error); canCast && nErr.Timeout() {
+			err = utils.ErrTimedOut
+		}
+		return
+	}
+	if n < 20 || int(binary.BigEndian.Uint16(rplBuf[2:4])) != n {
+		return nil, errors.New("unexpected packet length received")
+	}
+	if rplBuf[1] != req.Identifier {
+		return nil, fmt.Errorf("unexpected packet identifier: <%d>", rplBuf[1])
+	}
+	rplAuth := make([]byte, 16)
+	copy(rplAuth, rplBuf[4:20])
+	copy(rplBuf[4:20], reqAuth) // response authenticator is computed over the request one
+	if !bytes.Equal(rplAuth, radDAAuthenticator(rplBuf[:n], secret)) {
+		return nil, errors.New("reply packet is not authentic")
+	}
+	copy(rplBuf[4:20], rplAuth)
+	rpl = radigo.NewPacket(0, 0, dict, radigo.NewCoder(), secret)
+	err = rpl.Decode(rplBuf[:n])
+	return
+}
+
+// radErrorCauseValue returns the value of the Error-Cause attribute, 0 if missing
+func radErrorCauseValue(pkt *radigo.Packet) (errCause uint32) {
+	for _, avp := range pkt.AttributesWithNumber(radErrorCause, radigo.NoVendor) {
+		if len(avp.RawValue) == 4 {
+			errCause = binary.BigEndian.Uint32(avp.RawValue)
+		}
+	}
+	return
+}
@@ -0,0 +1,315 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SMPP v3.4 command ids
+const (
+	smppGenericNack     uint32 = 0x80000000
+	smppBindReceiver    uint32 = 0x00000001
+	smppBindTransmitter uint32 = 0x00000002
+	smppSubmitSm        uint32 = 0x00000004
+	smppDeliverSm       uint32 = 0x00000005
+	smppUnbind          uint32 = 0x00000006
+	smppBindTransceiver uint32 = 0x00000009
+	smppEnquireLink     uint32 = 0x00000015
+	smppRespFlag        uint32 = 0x80000000 // set on the command id of the responses
+)
+
+// SMPP v3.4 command statuses
+const (
+	smppESMERok         uint32 = 0x00000000
+	smppESMERinvcmdid   uint32 = 0x00000003
+	smppESMERinvbndsts  uint32 = 0x00000004
+	smppESMERalybnd     uint32 = 0x00000005
+	smppESMERsyserr     uint32 = 0x00000008
+	smppESMERbindfail   uint32 = 0x0000000D
+	smppESMERinvpaswd   uint32 = 0x0000000E
+	smppESMERinvsysid   uint32 = 0x0000000F
+	smppESMERsubmitfail uint32 = 0x00000045
+	smppESMERxPAppn     uint32 = 0x00000065
+)
+
+const (
+	smppHeaderLen      = 16
+	smppMaxPDULen      = 65536
+	smppMessagePayload = 0x0424 // TLV tag carrying the message when short_message is empty
+	smppUDHIndicator   = 0x40   // esm_class bit signaling a User Data Header
+
+	// fields of submit_sm and deliver_sm, named as in the specification
+	smppServiceType          = "service_type"
+	smppSourceAddrTon        = "source_addr_ton"
+	smppSourceAddrNpi        = "source_addr_npi"
+	smppSourceAddr           = "source_addr"
+	smppDestAddrTon          = "dest_addr_ton"
+	smppDestAddrNpi          = "dest_addr_npi"
+	smppDestinationAddr      = "destination_addr"
+	smppEsmClass             = "esm_class"
+	smppProtocolID           = "protocol_id"
+	smppPriorityFlag         = "priority_flag"
+	smppScheduleDeliveryTime = "schedule_delivery_time"
+	smppValidityPeriod       = "validity_period"
+	smppRegisteredDelivery   = "registered_delivery"
+	smppReplaceIfPresentFlag = "replace_if_present_flag"
+	smppDataCoding           = "data_coding"
+	smppSmDefaultMsgID       = "sm_default_msg_id"
+	smppSmLength             = "sm_length"
+	smppShortMessage         = "short_message"
+
+	// fields of the reply
+	smppCommandStatus = "command_status"
+	smppMessageID     = "message_id"
+
+	// request variables
+	smppCommandID = "CommandID"
+	smppSystemID  = "SystemID"
+	smppMsgID     = "MessageID"
+)
+
+var smppCommandNames = map[uint32]string{
+	smppSubmitSm:  "submit_sm",
+	smppDeliverSm: "deliver_sm",
+}
+
+// gsm7Alphabet is the GSM 03.38 default alphabet, indexed by septet
+var gsm7Alphabet = []rune("@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞ\x1bÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà")
+
+// gsm7Extension is the GSM 03.38 extension table, reached through the escape septet
+var gsm7Extension = map[byte]rune{
+	0x0A: '\f', 0x14: '^', 0x28: '{', 0x29: '}', 0x2F: '\\',
+	0x3C: '[', 0x3D: '~', 0x3E: ']', 0x40: '|', 0x65: '€',
+}
+
+// smppPDU is one SMPP protocol data unit
+type smppPDU struct {
+	CommandID      uint32
+	CommandStatus  uint32
+	SequenceNumber uint32
+	Body           []byte
+}
+
+// readSMPPPDU reads one PDU out of the stream
+func readSMPPPDU(r io.Reader) (pdu *smppPDU, err error) {
+	hdr := make([]byte, smppHeaderLen)
+	if _, err = io.ReadFull(r, hdr); err != nil {
+		return
+	}
+	cmdLen := binary.BigEndian.Uint32(hdr[0:4])
+	if cmdLen < smppHeaderLen || cmdLen > smppMaxPDULen {
+		return nil, fmt.Errorf("invalid command_length: <%d>", cmdLen)
+	}
+	pdu = &smppPDU{
+		CommandID:      binary.BigEndian.Uint32(hdr[4:8]),
+		CommandStatus:  binary.BigEndian.Uint32(hdr[8:12]),
+		SequenceNumber: binary.BigEndian.Uint32(hdr[12:16]),
+		Body:           make([]byte, cmdLen-smppHeaderLen),
+	}
+	if _, err = io.ReadFull(r, pdu.Body); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Bytes returns the PDU encoded for the wire
+func (pdu *smppPDU) Bytes() (b []byte) {
+	b = make([]byte, smppHeaderLen, smppHeaderLen+len(pdu.Body))
+	binary.BigEndian.PutUint32(b[0:4], uint32(smppHeaderLen+len(pdu.Body)))
+	binary.BigEndian.PutUint32(b[4:8], pdu.CommandID)
+	binary.BigEndian.PutUint32(b[8:12], pdu.CommandStatus)
+	binary.BigEndian.PutUint32(b[12:16], pdu.SequenceNumber)
+	return append(b, pdu.Body...)
+}
+
+// newSMPPResp builds the response of the request PDU
+func newSMPPResp(req *smppPDU, status uint32, body []byte) *smppPDU {
+	cmdID := req.CommandID | smppRespFlag
+	if req.CommandID&smppRespFlag != 0 { // no response for responses
+		cmdID = smppGenericNack
+	}
+	return &smppPDU{
+		CommandID:      cmdID,
+		CommandStatus:  status,
+		SequenceNumber: req.SequenceNumber,
+		Body:           body,
+	}
+}
+
+// smppCString encodes a C-Octet String
+func smppCString(s string) []byte {
+	return append([]byte(s), 0)
+}
+
+// smppBodyReader decodes the mandatory parameters of a PDU body
+type smppBodyReader struct {
+	*bytes.Reader
+}
+
+func (br smppBodyReader) cString() (s string, err error) {
+	var b []byte
+	for {
+		var c byte
+		if c, err = br.ReadByte(); err != nil {
+			return utils.EmptyString, errors.New("unterminated C-Octet String")
+		}
+		if c == 0 {
+			return string(b), nil
+		}
+		b = append(b, c)
+	}
+}
+
+func (br smppBodyReader) octet() (c byte, err error) {
+	if c, err = br.ReadByte(); err != nil {
+		err = io.ErrUnexpectedEOF
+	}
+	return
+}
+
+// decodeSMPPBind returns the system_id and the password of a bind PDU
+func decodeSMPPBind(body []byte) (systemID, passwd string, err error) {
+	br := smppBodyReader{bytes.NewReader(body)}
+	if systemID, err = br.cString(); err != nil {
+		return
+	}
+	passwd, err = br.cString()
+	return
+}
+
+// decodeSMPPSm decodes the body of a submit_sm or deliver_sm, with the
+// short_message converted to text out of its data_coding
+func decodeSMPPSm(body []byte) (ev utils.MapStorage, err error) {
+	br := smppBodyReader{bytes.NewReader(body)}
+	ev = make(utils.MapStorage)
+	for _, fld := range []struct {
+		name    string
+		isOctet bool
+	}{
+		{smppServiceType, false},
+		{smppSourceAddrTon, true}, {smppSourceAddrNpi, true}, {smppSourceAddr, false},
+		{smppDestAddrTon, true}, {smppDestAddrNpi, true}, {smppDestinationAddr, false},
+		{smppEsmClass, true}, {smppProtocolID, true}, {smppPriorityFlag, true},
+		{smppScheduleDeliveryTime, false}, {smppValidityPeriod, false},
+		{smppRegisteredDelivery, true}, {smppReplaceIfPresentFlag, true},
+		{smppDataCoding, true}, {smppSmDefaultMsgID, true}, {smppSmLength, true},
+	} {
+		if !fld.isOctet {
+			if ev[fld.name], err = br.cString(); err != nil {
+				return nil, fmt.Errorf("decoding %s: %s", fld.name, err.Error())
+			}
+			continue
+		}
+		var c byte
+		if c, err = br.octet(); err != nil {
+			return nil, fmt.Errorf("decoding %s: %s", fld.name, err.Error())
+		}
+		ev[fld.name] = int(c)
+	}
+	sm := make([]byte, ev[smppSmLength].(int))
+	if _, err = io.ReadFull(br, sm); err != nil {
+		return nil, fmt.Errorf("decoding %s: %s", smppShortMessage, err.Error())
+	}
+	for br.Len() >= 4 { // optional parameters
+		var tlv [4]byte
+		br.Read(tlv[:])
+		val := make([]byte, binary.BigEndian.Uint16(tlv[2:4]))
+		if _, err = io.ReadFull(br, val); err != nil {
+			return nil, fmt.Errorf("decoding TLV 0x%04x: %s",
+				binary.BigEndian.Uint16(tlv[0:2]), err.Error())
+		}
+		if binary.BigEndian.Uint16(tlv[0:2]) == smppMessagePayload && len(sm) == 0 {
+			sm = val
+		}
+	}
+	if ev[smppEsmClass].(int)&smppUDHIndicator != 0 && len(sm) != 0 {
+		if udhLen := int(sm[0]) + 1; udhLen <= len(sm) {
+			sm = sm[udhLen:]
+		}
+	}
+	ev[smppShortMessage] = decodeSMPPText(sm, ev[smppDataCoding].(int))
+	return
+}
+
+// decodeSMPPText converts the message to text based on the data_coding
+func decodeSMPPText(sm []byte, dataCoding int) string {
+	switch dataCoding {
+	case 0x00: // SMSC default alphabet, one septet per octet
+		var sb strings.Builder
+		for i := 0; i < len(sm); i++ {
+			if sm[i] == 0x1B && i+1 < len(sm) {
+				i++
+				if r, has := gsm7Extension[sm[i]]; has {
+					sb.WriteRune(r)
+					continue
+				}
+			}
+			if int(sm[i]) < len(gsm7Alphabet) {
+				sb.WriteRune(gsm7Alphabet[sm[i]])
+			}
+		}
+		return sb.String()
+	case 0x03: // Latin 1
+		rs := make([]rune, len(sm))
+		for i, c := range sm {
+			rs[i] = rune(c)
+		}
+		return string(rs)
+	case 0x08: // UCS2
+		u16 := make([]uint16, len(sm)/2)
+		for i := range u16 {
+			u16[i] = binary.BigEndian.Uint16(sm[2*i:])
+		}
+		return string(utf16.Decode(u16))
+	default: // IA5 and the 8-bit codings are passed as they are
+		return string(sm)
+	}
+}
+
+// smppReplyFields returns the fields populated in the reply
+func smppReplyFields(navMp *utils.OrderedNavigableMap) (flds map[string]string, err error) {
+	flds = make(map[string]string)
+	for el := navMp.GetFirstElement(); el != nil; el = el.Next() {
+		val := el.Value
+		var nmIt utils.NMInterface
+		if nmIt, err = navMp.Field(val); err != nil {
+			return
+		}
+		itm, isNMItem := nmIt.(*config.NMItem)
+		if !isNMItem {
+			return nil, fmt.Errorf("cannot encode reply value: %s, err: not NMItems", utils.ToJSON(val))
+		}
+		if itm == nil {
+			continue
+		}
+		flds[strings.Join(itm.Path, utils.NestingSep)] = utils.IfaceAsString(itm.Data)
+	}
+	return
+}
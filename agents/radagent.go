@@ -20,12 +20,16 @@ package agents
 
 import (
 	"fmt"
+	"net"
+	"sync/atomic"
 
+	"github.com/cenkalti/rpc2"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
+	"github.com/cgrates/rpcclient"
 )
 
 const (
@@ -38,6 +42,18 @@ const (
 	MSCHAPResponseAVP  = "MS-CHAP-Response"
 	MicrosoftVendor    = "Microsoft"
 	MSCHAP2SuccessAVP  = "MS-CHAP2-Success"
+	AcctSessionIDAVP   = "Acct-Session-Id"
+)
+
+// Dynamic Authorization Extensions to RADIUS (RFC 5176), not defined in radigo
+const (
+	radDisconnectRequest radigo.PacketCode = 40 // answered with Disconnect-ACK(41) or Disconnect-NAK(42)
+	radCoARequest        radigo.PacketCode = 43 // answered with CoA-ACK(44) or CoA-NAK(45)
+)
+
+const (
+	radErrorCause = 101    // Error-Cause attribute number
+	radDAPort     = "3799" // default port of the Dynamic Authorization Server
 )
 
 func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
@@ -52,8 +68,9 @@ func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 		}
 	}
 	dicts := radigo.NewDictionaries(dts)
-	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr}
 	secrets := radigo.NewSecrets(cgrCfg.RadiusAgentCfg().ClientSecrets)
+	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr,
+		dicts: dicts, secrets: secrets}
 	ra.rsAuth = radigo.NewServer(cgrCfg.RadiusAgentCfg().ListenNet,
 		cgrCfg.RadiusAgentCfg().ListenAuth, secrets, dicts,
 		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
@@ -71,6 +88,15 @@ type RadiusAgent struct {
 	filterS *engine.FilterS
 	rsAuth  *radigo.Server
 	rsAcct  *radigo.Server
+	dicts   *radigo.Dictionaries // used when sending requests towards clients
+	secrets *radigo.Secrets
+	daReqID uint32 // identifier of the last Dynamic Authorization request
+}
+
+// radPktData is the cached packet data needed to build the Dynamic Authorization requests
+type radPktData struct {
+	pkt        *radigo.Packet
+	remoteAddr string
 }
 
 // handleAuth handles RADIUS Authorization request
@@ -171,6 +197,10 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 		return
 	}
 	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	if originID := cgrEv.Event[utils.OriginID]; originID != nil {
+		remoteAddr, _ := agReq.Vars.FieldAsString([]string{utils.RemoteHost})
+		ra.cacheRadPacket(req, remoteAddr, utils.IfaceAsString(originID))
+	}
 	var reqType string
 	for _, typ := range []string{
 		utils.MetaDryRun, utils.MetaAuthorize,
@@ -222,7 +252,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1AuthorizeReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1AuthorizeEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1AuthorizeEvent,
 			authArgs, rply)
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -240,7 +270,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1InitSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1InitiateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1InitiateSession,
 			initArgs, rply)
 		rply.SetMaxUsageNeeded(initArgs.InitSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -253,7 +283,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1UpdateSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1UpdateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1UpdateSession,
 			updateArgs, rply)
 		rply.SetMaxUsageNeeded(updateArgs.UpdateSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -269,7 +299,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		var rply string
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1TerminateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1TerminateSession,
 			terminateArgs, &rply)
 		if err = agReq.setCGRReply(nil, err); err != nil {
 			return
@@ -291,7 +321,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1ProcessMessageReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessMessage, evArgs, rply)
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessMessage, evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
 		} else if evArgs.Debit {
@@ -308,7 +338,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			Paginator: cgrArgs,
 		}
 		rply := new(sessions.V1ProcessEventReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessEvent,
 			evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
@@ -329,7 +359,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 	// separate request so we can capture the Terminate/Event also here
 	if reqProcessor.Flags.GetBool(utils.MetaCDRs) {
 		var rplyCDRs string
-		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessCDR,
+		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessCDR,
 			cgrEv, &rplyCDRs); err != nil {
 			agReq.CGRReply.Set(utils.PathItems{{Field: utils.Error}}, utils.NewNMData(err.Error()))
 		}
@@ -369,3 +399,205 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 	err = <-errListen
 	return
 }
+
+// cacheRadPacket keeps the request so we can build the Disconnect-Request or CoA-Request for the session
+// the packet is cached under the OriginID computed by the request processor, as SessionS knows the session
+func (ra *RadiusAgent) cacheRadPacket(req *radigo.Packet, remoteAddr, originID string) {
+	if originID == utils.EmptyString ||
+		(ra.cgrCfg.RadiusAgentCfg().DMRTemplate == utils.EmptyString &&
+			ra.cgrCfg.RadiusAgentCfg().CoATemplate == utils.EmptyString) {
+		return
+	}
+	if err := engine.Cache.Set(utils.CacheRadiusPackets, originID,
+		&radPktData{pkt: req, remoteAddr: remoteAddr},
+		nil, true, utils.NonTransactional); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed caching packet with OriginID: <%s>, err: %s",
+			utils.RadiusAgent, originID, err.Error()))
+	}
+}
+
+// sendDARequest builds the Dynamic Authorization request out of template
+// and sends it to the client which originated the session
+func (ra *RadiusAgent) sendDARequest(code radigo.PacketCode, tplID, originID string,
+	cgrRplyNM utils.NavigableMap2) (err error) {
+	pktIface, has := engine.Cache.Get(utils.CacheRadiusPackets, originID)
+	if !has {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot retrieve packet from cache with OriginID: <%s>",
+				utils.RadiusAgent, originID))
+		return utils.ErrMandatoryIeMissing
+	}
+	pd := pktIface.(*radPktData)
+	aReq := NewAgentRequest(
+		newRADataProvider(pd.pkt),
+		utils.NavigableMap2{utils.RemoteHost: utils.NewNMData(pd.remoteAddr)},
+		&cgrRplyNM, nil, nil, nil,
+		ra.cgrCfg.GeneralCfg().DefaultTenant,
+		ra.cgrCfg.GeneralCfg().DefaultTimezone, ra.filterS, nil, nil)
+	if err = aReq.SetFields(ra.cgrCfg.TemplatesCfg()[tplID]); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, code, originID, err.Error()))
+		return utils.ErrServerError
+	}
+	clntHost := pd.remoteAddr
+	if host, _, errSplit := net.SplitHostPort(pd.remoteAddr); errSplit == nil {
+		clntHost = host
+	}
+	dict := ra.dicts.GetInstance(clntHost)
+	secret := ra.secrets.GetSecret(clntHost)
+	req := radigo.NewPacket(code, uint8(atomic.AddUint32(&ra.daReqID, 1)),
+		dict, radigo.NewCoder(), secret)
+	if err = radReplyAppendAttributes(req, aReq.diamreq); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, code, originID, err.Error()))
+		return utils.ErrServerError
+	}
+	daAddr, has := ra.cgrCfg.RadiusAgentCfg().ClientDAAddresses[clntHost]
+	if !has {
+		daAddr = net.JoinHostPort(clntHost, radDAPort)
+	}
+	var rpl *radigo.Packet
+	if rpl, err = radDAExchange(daAddr, secret, dict, req); err != nil {
+		return
+	}
+	if rpl.Code != code+1 { // ACK code follows the request one
+		return fmt.Errorf("unexpected reply code: <%d>, Error-Cause: <%d>",
+			rpl.Code, radErrorCauseValue(rpl))
+	}
+	return
+}
+
+// Call implements rpcclient.ClientConnector interface
+func (ra *RadiusAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(ra, serviceMethod, args, reply)
+}
+
+// V1DisconnectSession is part of the sessions.BiRPClient
+// sends a Disconnect-Request to the client if dmr_template is configured
+func (ra *RadiusAgent) V1DisconnectSession(args utils.AttrDisconnectSession, reply *string) (err error) {
+	if ra.cgrCfg.RadiusAgentCfg().DMRTemplate == utils.EmptyString {
+		return utils.ErrNotImplemented
+	}
+	originID, has := args.EventStart[utils.OriginID]
+	if !has {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot disconnect session, missing OriginID in event: %s",
+				utils.RadiusAgent, utils.ToJSON(args.EventStart)))
+		return utils.ErrMandatoryIeMissing
+	}
+	if err = ra.sendDARequest(radDisconnectRequest, ra.cgrCfg.RadiusAgentCfg().DMRTemplate,
+		utils.IfaceAsString(originID),
+		utils.NavigableMap2{utils.DisconnectCause: utils.NewNMData(args.Reason)}); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1AlterSession is part of the sessions.BiRPClient
+// sends a CoA-Request to the client if coa_template is configured
+func (ra *RadiusAgent) V1AlterSession(args utils.AttrAlterSession, reply *string) (err error) {
+	if ra.cgrCfg.RadiusAgentCfg().CoATemplate == utils.EmptyString {
+		return utils.ErrNotImplemented
+	}
+	originID, has := args.EventStart[utils.OriginID]
+	if !has {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot alter session, missing OriginID in event: %s",
+				utils.RadiusAgent, utils.ToJSON(args.EventStart)))
+		return utils.ErrMandatoryIeMissing
+	}
+	cgrRplyNM := make(utils.NavigableMap2, len(args.Event))
+	for k, v := range args.Event {
+		cgrRplyNM[k] = utils.NewNMData(v)
+	}
+	if err = ra.sendDARequest(radCoARequest, ra.cgrCfg.RadiusAgentCfg().CoATemplate,
+		utils.IfaceAsString(originID), cgrRplyNM); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetActiveSessionIDs is part of the sessions.BiRPClient
+func (*RadiusAgent) V1GetActiveSessionIDs(ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return utils.ErrNotImplemented
+}
+
+// V1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
+func (ra *RadiusAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.BiRPCCall(ra, clnt, serviceMethod, args, reply)
+}
+
+// BiRPCv1DisconnectSession is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) error {
+	return ra.V1DisconnectSession(args, reply)
+}
+
+// BiRPCv1GetActiveSessionIDs is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return ra.V1GetActiveSessionIDs(ignParam, sessionIDs)
+}
+
+// BiRPCv1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error) {
+	return ra.V1ReAuthorize(originID, reply)
+}
+
+// BiRPCv1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error) {
+	return ra.V1DisconnectPeer(args, reply)
+}
+
+// BiRPCv1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error) {
+	return ra.V1WarnDisconnect(args, reply)
+}
+
+// BiRPCv1AlterSession is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1AlterSession(clnt rpcclient.ClientConnector, args utils.AttrAlterSession, reply *string) (err error) {
+	return ra.V1AlterSession(args, reply)
+}
+
+// Handlers is used to implement the rpcclient.BiRPCConector interface
+func (ra *RadiusAgent) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.SessionSv1DisconnectSession: func(clnt *rpc2.Client, args utils.AttrDisconnectSession, rply *string) error {
+			return ra.BiRPCv1DisconnectSession(clnt, args, rply)
+		},
+		utils.SessionSv1GetActiveSessionIDs: func(clnt *rpc2.Client, args string, rply *[]*sessions.SessionID) error {
+			return ra.BiRPCv1GetActiveSessionIDs(clnt, args, rply)
+		},
+		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args string, rply *string) (err error) {
+			return ra.BiRPCv1ReAuthorize(clnt, args, rply)
+		},
+		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
+			return ra.BiRPCv1DisconnectPeer(clnt, args, rply)
+		},
+		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
+			return ra.BiRPCv1WarnDisconnect(clnt, args, rply)
+		},
+		utils.SessionSv1AlterSession: func(clnt *rpc2.Client, args utils.AttrAlterSession, rply *string) (err error) {
+			return ra.BiRPCv1AlterSession(clnt, args, rply)
+		},
+	}
+}
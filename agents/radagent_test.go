@@ -0,0 +1,310 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestRAsSessionSClientIface(t *testing.T) {
+	_ = sessions.BiRPClient(new(RadiusAgent))
+}
+
+// testRadDAServer mocks the Dynamic Authorization Server of a NAS
+// replying with rplyCode to the first request received
+func testRadDAServer(t *testing.T, secret string, rplyCode radigo.PacketCode,
+	rplyAVPs []*radigo.AVP) (addr string, reqs chan *radigo.Packet) {
+	conn, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs = make(chan *radigo.Packet, 1)
+	go func() {
+		defer conn.Close()
+		var buf [4096]byte
+		n, raddr, err := conn.ReadFrom(buf[:])
+		if err != nil {
+			return
+		}
+		reqAuth := make([]byte, 16)
+		copy(reqAuth, buf[4:20])
+		copy(buf[4:20], make([]byte, 16))
+		if !bytes.Equal(reqAuth, radDAAuthenticator(buf[:n], secret)) {
+			t.Error("request not authentic")
+			close(reqs)
+			return
+		}
+		req := radigo.NewPacket(0, 0, dictRad, coder, secret)
+		if err = req.Decode(buf[:n]); err != nil {
+			t.Error(err)
+		}
+		req.SetAVPValues()
+		reqs <- req
+		rpl := radigo.NewPacket(rplyCode, req.Identifier, dictRad, coder, secret)
+		rpl.AVPs = rplyAVPs
+		if n, err = rpl.Encode(buf[:]); err != nil {
+			t.Error(err)
+		}
+		copy(buf[4:20], reqAuth)
+		copy(buf[4:20], radDAAuthenticator(buf[:n], secret))
+		conn.WriteTo(buf[:n], raddr)
+	}()
+	return conn.LocalAddr().String(), reqs
+}
+
+func TestRadiusAgentDARequests(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RadiusAgentCfg().DMRTemplate = "*dmrTest"
+	cfg.RadiusAgentCfg().CoATemplate = "*coaTest"
+	cfg.TemplatesCfg()["*dmrTest"] = []*config.FCTemplate{
+		{Tag: "AcctSessionId", Path: utils.MetaDiamreq + utils.NestingSep + AcctSessionIDAVP,
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep)},
+		{Tag: "UserName", Path: utils.MetaDiamreq + utils.NestingSep + "User-Name",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.User-Name", utils.InfieldSep)},
+	}
+	cfg.TemplatesCfg()["*coaTest"] = []*config.FCTemplate{
+		{Tag: "AcctSessionId", Path: utils.MetaDiamreq + utils.NestingSep + AcctSessionIDAVP,
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep)},
+		{Tag: "SessionTimeout", Path: utils.MetaDiamreq + utils.NestingSep + "Session-Timeout",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*cgrep.SessionTimeout", utils.InfieldSep)},
+	}
+	for _, tpl := range []string{"*dmrTest", "*coaTest"} {
+		for _, fld := range cfg.TemplatesCfg()[tpl] {
+			fld.ComputePath()
+		}
+	}
+	ra := &RadiusAgent{
+		cgrCfg:  cfg,
+		dicts:   radigo.NewDictionaries(map[string]*radigo.Dictionary{utils.MetaDefault: dictRad}),
+		secrets: radigo.NewSecrets(map[string]string{utils.MetaDefault: "CGRateS.org"}),
+	}
+	pkt := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "CGRateS.org")
+	if err := pkt.AddAVPWithName("User-Name", "1001", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := pkt.AddAVPWithName(AcctSessionIDAVP, "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Cache.Set(utils.CacheRadiusPackets, "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0",
+		&radPktData{pkt: pkt, remoteAddr: "127.0.0.1:51234"}, nil, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Cache.Remove(utils.CacheRadiusPackets, "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0",
+		true, utils.NonTransactional)
+	evStart := map[string]interface{}{utils.OriginID: "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0"}
+
+	daAddr, reqs := testRadDAServer(t, "CGRateS.org", radDisconnectRequest+1, nil)
+	cfg.RadiusAgentCfg().ClientDAAddresses = map[string]string{"127.0.0.1": daAddr}
+	var reply string
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{EventStart: evStart,
+		Reason: "INSUFFICIENT_CREDIT"}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected: %q, received: %q", utils.OK, reply)
+	}
+	req := <-reqs
+	if req.Code != radDisconnectRequest {
+		t.Errorf("Expected code: %d, received: %d", radDisconnectRequest, req.Code)
+	}
+	if avps := req.AttributesWithName("User-Name", ""); len(avps) != 1 ||
+		avps[0].GetStringValue() != "1001" {
+		t.Errorf("Unexpected User-Name in request: %s", utils.ToJSON(avps))
+	}
+
+	// CoA refused by the NAS with Error-Cause: Session Context Not Found
+	daAddr, reqs = testRadDAServer(t, "CGRateS.org", radCoARequest+2,
+		[]*radigo.AVP{{Number: radErrorCause, RawValue: []byte{0, 0, 1, 247}}})
+	cfg.RadiusAgentCfg().ClientDAAddresses = map[string]string{"127.0.0.1": daAddr}
+	expErr := "unexpected reply code: <45>, Error-Cause: <503>"
+	if err := ra.V1AlterSession(utils.AttrAlterSession{EventStart: evStart,
+		Event: map[string]interface{}{"SessionTimeout": 30}}, &reply); err == nil ||
+		err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	req = <-reqs
+	if req.Code != radCoARequest {
+		t.Errorf("Expected code: %d, received: %d", radCoARequest, req.Code)
+	}
+	if avps := req.AttributesWithName("Session-Timeout", ""); len(avps) != 1 ||
+		avps[0].GetStringValue() != "30" {
+		t.Errorf("Unexpected Session-Timeout in request: %s", utils.ToJSON(avps))
+	}
+
+	// missing session
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{utils.OriginID: "unknown"}}, &reply); err != utils.ErrMandatoryIeMissing {
+		t.Errorf("Expected error: %v, received: %v", utils.ErrMandatoryIeMissing, err)
+	}
+}
+
+func TestRadiusAgentCacheRadPacket(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RadiusAgentCfg().DMRTemplate = "*dmr"
+	ra := &RadiusAgent{
+		cgrCfg:  cfg,
+		filterS: engine.NewFilterS(cfg, nil, nil),
+	}
+	reqProcessor := &config.RequestProcessor{
+		ID:     "RadiusAccounting",
+		Tenant: config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep),
+		Flags:  utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+		RequestFields: []*config.FCTemplate{
+			{Tag: utils.OriginID, Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID,
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.Acct-Session-Id;-;~*req.Sip-From-Tag", utils.InfieldSep)},
+		},
+	}
+	for _, fld := range reqProcessor.RequestFields {
+		fld.ComputePath()
+	}
+	pkt := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "CGRateS.org")
+	if err := pkt.AddAVPWithName(AcctSessionIDAVP, "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := pkt.AddAVPWithName("Sip-From-Tag", "51585361", ""); err != nil {
+		t.Fatal(err)
+	}
+	pkt.SetAVPValues()
+	originID := "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0-51585361"
+	defer engine.Cache.Remove(utils.CacheRadiusPackets, originID, true, utils.NonTransactional)
+	agReq := NewAgentRequest(newRADataProvider(pkt),
+		utils.NavigableMap2{utils.RemoteHost: utils.NewNMData("127.0.0.1:51234")},
+		nil, nil, nil, reqProcessor.Tenant, cfg.GeneralCfg().DefaultTenant,
+		cfg.GeneralCfg().DefaultTimezone, ra.filterS, nil, nil)
+	if processed, err := ra.processRequest(pkt, reqProcessor, agReq, pkt.Reply()); err != nil {
+		t.Fatal(err)
+	} else if !processed {
+		t.Fatal("expecting the request to be processed")
+	}
+	if pktIface, has := engine.Cache.Get(utils.CacheRadiusPackets, originID); !has {
+		t.Errorf("expecting the packet cached under the OriginID: <%s>", originID)
+	} else if pd := pktIface.(*radPktData); pd.pkt != pkt || pd.remoteAddr != "127.0.0.1:51234" {
+		t.Errorf("unexpected cached packet data: %+v", pd)
+	}
+	if _, has := engine.Cache.Get(utils.CacheRadiusPackets,
+		"e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0"); has {
+		t.Error("not expecting the packet cached under the Acct-Session-Id")
+	}
+}
+
+func TestRadiusAgentForceDisconnectOverSessionS(t *testing.T) {
+	cfgJSONStr := `{
+"radius_agent": {
+	"sessions_conns": ["*birpc_internal"],
+	"dmr_template": "*dmr",
+},
+"sessions": {
+	"enabled": true,
+	"chargers_conns": ["*internal"],
+},
+}`
+	cfg, err := config.NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	chrgSConn := &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.ChargerSv1ProcessEvent: func(arg interface{}, rply interface{}) error {
+			cgrEv := arg.(*utils.CGREvent).Clone()
+			cgrEv.Event[utils.RunID] = utils.MetaDefault
+			*rply.(*[]*engine.ChrgSProcessEventReply) = []*engine.ChrgSProcessEventReply{
+				{ChargerSProfile: "DEFAULT", CGREvent: cgrEv}}
+			return nil
+		},
+	}}
+	chrgSChan := make(chan rpcclient.ClientConnector, 1)
+	chrgSChan <- chrgSConn
+	sSChan := make(chan rpcclient.ClientConnector, 1)
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers):      chrgSChan,
+		utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS): sSChan,
+	})
+	sS := sessions.NewSessionS(cfg, dm, connMgr)
+	sSChan <- sS
+
+	ra := &RadiusAgent{
+		cgrCfg:  cfg,
+		connMgr: connMgr,
+		dicts:   radigo.NewDictionaries(map[string]*radigo.Dictionary{utils.MetaDefault: dictRad}),
+		secrets: radigo.NewSecrets(map[string]string{utils.MetaDefault: "CGRateS.org"}),
+	}
+	originID := "TestRadiusAgentForceDisconnectOverSessionS"
+	pkt := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "CGRateS.org")
+	if err := pkt.AddAVPWithName(AcctSessionIDAVP, originID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Cache.Set(utils.CacheRadiusPackets, originID,
+		&radPktData{pkt: pkt, remoteAddr: "127.0.0.1:51234"}, nil, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Cache.Remove(utils.CacheRadiusPackets, originID, true, utils.NonTransactional)
+	daAddr, reqs := testRadDAServer(t, "CGRateS.org", radDisconnectRequest+1, nil)
+	cfg.RadiusAgentCfg().ClientDAAddresses = map[string]string{"127.0.0.1": daAddr}
+
+	var initRply sessions.V1InitSessionReply
+	if err := ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1InitiateSession,
+		&sessions.V1InitSessionArgs{
+			InitSession: true,
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     originID,
+				Event: map[string]interface{}{
+					utils.OriginID:     originID,
+					utils.ToR:          utils.MetaVoice,
+					utils.RequestType:  utils.MetaNone,
+					utils.AccountField: "1001",
+					utils.Usage:        time.Minute,
+				},
+			},
+		}, &initRply); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	if err := sS.BiRPCv1ForceDisconnect(nil, &utils.SessionFilter{
+		Filters: []string{"*string:~*req.OriginID:" + originID}}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected: %q, received: %q", utils.OK, reply)
+	}
+	select {
+	case req := <-reqs:
+		if req.Code != radDisconnectRequest {
+			t.Errorf("Expected code: %d, received: %d", radDisconnectRequest, req.Code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Disconnect-Request not sent by SessionS")
+	}
+}
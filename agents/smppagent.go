@@ -0,0 +1,434 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewSMPPAgent will construct a SMPPAgent
+func NewSMPPAgent(connMgr *engine.ConnManager, cfg *config.CGRConfig,
+	filterS *engine.FilterS) (sa *SMPPAgent, err error) {
+	sa = &SMPPAgent{
+		connMgr:  connMgr,
+		filterS:  filterS,
+		cfg:      cfg,
+		stopChan: make(chan struct{}),
+	}
+	msgTemplates := sa.cfg.TemplatesCfg()
+	// Inflate *template field types
+	for _, procsr := range sa.cfg.SMPPAgentCfg().RequestProcessors {
+		if tpls, err := config.InflateTemplates(procsr.RequestFields, msgTemplates); err != nil {
+			return nil, err
+		} else if tpls != nil {
+			procsr.RequestFields = tpls
+		}
+		if tpls, err := config.InflateTemplates(procsr.ReplyFields, msgTemplates); err != nil {
+			return nil, err
+		} else if tpls != nil {
+			procsr.ReplyFields = tpls
+		}
+	}
+	return
+}
+
+// SMPPAgent is a server for the SMPP binds, charging the submit_sm and deliver_sm PDUs
+type SMPPAgent struct {
+	connMgr  *engine.ConnManager
+	filterS  *engine.FilterS
+	cfg      *config.CGRConfig
+	stopChan chan struct{}
+}
+
+// ListenAndServe accepts the SMPP connections until Shutdown
+func (sa *SMPPAgent) ListenAndServe() (err error) {
+	var l net.Listener
+	if l, err = net.Listen(utils.TCP, sa.cfg.SMPPAgentCfg().Listen); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: %s unable to listen to: %s",
+				utils.SMPPAgent, err.Error(), sa.cfg.SMPPAgentCfg().Listen))
+		return
+	}
+	utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s>",
+		utils.SMPPAgent, sa.cfg.SMPPAgentCfg().Listen))
+	stop := sa.stopChan
+	go func() {
+		<-stop
+		l.Close()
+	}()
+	for {
+		var conn net.Conn
+		if conn, err = l.Accept(); err != nil {
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+			utils.Logger.Err(
+				fmt.Sprintf("<%s> unable to accept connection because of error %s",
+					utils.SMPPAgent, err.Error()))
+			return
+		}
+		go sa.serveConn(conn, stop)
+	}
+}
+
+// Shutdown will stop the SMPPAgent server and close the binds
+func (sa *SMPPAgent) Shutdown() {
+	close(sa.stopChan)
+}
+
+// InitStopChan allows the agent to be started again after Shutdown
+func (sa *SMPPAgent) InitStopChan() {
+	sa.stopChan = make(chan struct{})
+}
+
+// serveConn handles the PDUs of one connection, the submit_sm and
+// deliver_sm are processed concurrently and answered as they complete
+func (sa *SMPPAgent) serveConn(conn net.Conn, stop chan struct{}) {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+		}
+		conn.Close()
+	}()
+	var wLk sync.Mutex
+	write := func(pdu *smppPDU) {
+		wLk.Lock()
+		defer wLk.Unlock()
+		if _, err := conn.Write(pdu.Bytes()); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s sending PDU with command_id: 0x%08x to %s",
+					utils.SMPPAgent, err.Error(), pdu.CommandID, conn.RemoteAddr()))
+		}
+	}
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait() // answer the PDUs in progress before closing
+		close(done)
+	}()
+	var systemID string // populated once bound
+	for {
+		pdu, err := readSMPPPDU(conn)
+		if err != nil {
+			if err != io.EOF {
+				select {
+				case <-stop:
+				default:
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> error: %s reading from %s",
+							utils.SMPPAgent, err.Error(), conn.RemoteAddr()))
+				}
+			}
+			return
+		}
+		switch pdu.CommandID {
+		case smppBindReceiver, smppBindTransmitter, smppBindTransceiver:
+			var status uint32
+			if systemID != utils.EmptyString {
+				status = smppESMERalybnd
+			} else {
+				var sysID, passwd string
+				if sysID, passwd, err = decodeSMPPBind(pdu.Body); err != nil {
+					status = smppESMERbindfail
+				} else if status = sa.authorizeBind(sysID, passwd); status == smppESMERok {
+					systemID = sysID
+				}
+			}
+			var body []byte
+			if status == smppESMERok {
+				body = smppCString(sa.cfg.SMPPAgentCfg().SystemID)
+			}
+			write(newSMPPResp(pdu, status, body))
+		case smppEnquireLink:
+			write(newSMPPResp(pdu, smppESMERok, nil))
+		case smppUnbind:
+			write(newSMPPResp(pdu, smppESMERok, nil))
+			return
+		case smppSubmitSm, smppDeliverSm:
+			if systemID == utils.EmptyString {
+				write(newSMPPResp(pdu, smppESMERinvbndsts, nil))
+				continue
+			}
+			wg.Add(1)
+			go func(pdu *smppPDU, systemID string) {
+				write(sa.handleSm(pdu, systemID, conn.RemoteAddr().String()))
+				wg.Done()
+			}(pdu, systemID)
+		default:
+			if pdu.CommandID&smppRespFlag != 0 { // we do not send requests so ignore the responses
+				continue
+			}
+			write(&smppPDU{
+				CommandID:      smppGenericNack,
+				CommandStatus:  smppESMERinvcmdid,
+				SequenceNumber: pdu.SequenceNumber,
+			})
+		}
+	}
+}
+
+// authorizeBind checks the bind against the bind_credentials
+func (sa *SMPPAgent) authorizeBind(systemID, passwd string) uint32 {
+	creds := sa.cfg.SMPPAgentCfg().BindCredentials
+	if len(creds) == 0 {
+		return smppESMERok
+	}
+	if expPasswd, has := creds[systemID]; !has {
+		return smppESMERinvsysid
+	} else if expPasswd != passwd {
+		return smppESMERinvpaswd
+	}
+	return smppESMERok
+}
+
+// handleSm processes the submit_sm or deliver_sm, returning its response
+func (sa *SMPPAgent) handleSm(pdu *smppPDU, systemID, remoteHost string) (resp *smppPDU) {
+	msgID := utils.UUIDSha1Prefix()
+	respBody := func(rplyFlds map[string]string) []byte {
+		if pdu.CommandID == smppDeliverSm { // message_id is unused within deliver_sm_resp
+			return smppCString(utils.EmptyString)
+		}
+		if id, has := rplyFlds[smppMessageID]; has {
+			return smppCString(id)
+		}
+		return smppCString(msgID)
+	}
+	ev, err := decodeSMPPSm(pdu.Body)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s decoding PDU with sequence_number: %d from %s",
+				utils.SMPPAgent, err.Error(), pdu.SequenceNumber, remoteHost))
+		return newSMPPResp(pdu, smppESMERsyserr, nil)
+	}
+	var processed bool
+	cgrRplyNM := utils.NavigableMap2{}
+	rplyNM := utils.NewOrderedNavigableMap()
+	opts := utils.NewOrderedNavigableMap()
+	reqVars := utils.NavigableMap2{
+		utils.RemoteHost: utils.NewNMData(remoteHost),
+		smppCommandID:    utils.NewNMData(smppCommandNames[pdu.CommandID]),
+		smppSystemID:     utils.NewNMData(systemID),
+		smppMsgID:        utils.NewNMData(msgID),
+	}
+	for _, reqProcessor := range sa.cfg.SMPPAgentCfg().RequestProcessors {
+		agReq := NewAgentRequest(ev, reqVars, &cgrRplyNM, rplyNM,
+			opts, reqProcessor.Tenant, sa.cfg.GeneralCfg().DefaultTenant,
+			utils.FirstNonEmpty(reqProcessor.Timezone, sa.cfg.SMPPAgentCfg().Timezone,
+				config.CgrConfig().GeneralCfg().DefaultTimezone),
+			sa.filterS, nil, nil)
+		var lclProcessed bool
+		if lclProcessed, err = sa.processRequest(reqProcessor, agReq); lclProcessed {
+			processed = lclProcessed
+		}
+		if err != nil ||
+			(lclProcessed && !reqProcessor.Flags.GetBool(utils.MetaContinue)) {
+			break
+		}
+	}
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s processing PDU: %s from %s",
+				utils.SMPPAgent, err.Error(), utils.ToJSON(ev), remoteHost))
+		return newSMPPResp(pdu, smppESMERsyserr, nil)
+	}
+	if !processed {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> no request processor enabled, ignoring PDU: %s from %s",
+				utils.SMPPAgent, utils.ToJSON(ev), remoteHost))
+		return newSMPPResp(pdu, smppESMERsyserr, nil)
+	}
+	var rplyFlds map[string]string
+	if rplyFlds, err = smppReplyFields(rplyNM); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s encoding out %s",
+				utils.SMPPAgent, err.Error(), utils.ToJSON(rplyNM)))
+		return newSMPPResp(pdu, smppESMERsyserr, nil)
+	}
+	status := smppESMERok
+	if statusStr, has := rplyFlds[smppCommandStatus]; has {
+		var st uint64
+		if st, err = strconv.ParseUint(statusStr, 0, 32); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> invalid %s: <%s> in reply",
+					utils.SMPPAgent, smppCommandStatus, statusStr))
+			return newSMPPResp(pdu, smppESMERsyserr, nil)
+		}
+		status = uint32(st)
+	} else if cgrErr, _ := cgrRplyNM.FieldAsString([]string{utils.Error}); cgrErr != utils.EmptyString {
+		status = smppESMERsubmitfail
+		if pdu.CommandID == smppDeliverSm {
+			status = smppESMERxPAppn
+		}
+	}
+	if status != smppESMERok {
+		return newSMPPResp(pdu, status, nil)
+	}
+	return newSMPPResp(pdu, status, respBody(rplyFlds))
+}
+
+// processRequest represents one processor processing the request
+func (sa *SMPPAgent) processRequest(reqProcessor *config.RequestProcessor,
+	agReq *AgentRequest) (processed bool, err error) {
+	if pass, err := sa.filterS.Pass(agReq.Tenant,
+		reqProcessor.Filters, agReq); err != nil || !pass {
+		return pass, err
+	}
+	if err = agReq.SetFields(reqProcessor.RequestFields); err != nil {
+		return
+	}
+	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	var reqType string
+	for _, typ := range []string{
+		utils.MetaDryRun, utils.MetaAuthorize,
+		utils.MetaMessage, utils.MetaCDRs,
+		utils.MetaEvent, utils.MetaNone} {
+		if reqProcessor.Flags.Has(typ) { // request type is identified through flags
+			reqType = typ
+			break
+		}
+	}
+	var cgrArgs utils.Paginator
+	if reqType == utils.MetaAuthorize ||
+		reqType == utils.MetaMessage ||
+		reqType == utils.MetaEvent {
+		if cgrArgs, err = utils.GetRoutePaginatorFromOpts(cgrEv.Opts); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> args extraction failed because <%s>",
+				utils.SMPPAgent, err.Error()))
+			err = nil // reset the error and continue the processing
+		}
+	}
+	if reqProcessor.Flags.Has(utils.MetaLog) {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> LOG, processorID: %s, SMPP message: %s",
+				utils.SMPPAgent, reqProcessor.ID, agReq.Request.String()))
+	}
+	switch reqType {
+	default:
+		return false, fmt.Errorf("unknown request type: <%s>", reqType)
+	case utils.MetaNone: // do nothing on CGRateS side
+	case utils.MetaDryRun:
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> DRY_RUN, processorID: %s, CGREvent: %s",
+				utils.SMPPAgent, reqProcessor.ID, utils.ToJSON(cgrEv)))
+	case utils.MetaAuthorize:
+		authArgs := sessions.NewV1AuthorizeArgs(
+			reqProcessor.Flags.GetBool(utils.MetaAttributes),
+			reqProcessor.Flags.ParamsSlice(utils.MetaAttributes, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaThresholds),
+			reqProcessor.Flags.ParamsSlice(utils.MetaThresholds, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaStats),
+			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaResources),
+			reqProcessor.Flags.Has(utils.MetaAccounts),
+			reqProcessor.Flags.GetBool(utils.MetaRoutes),
+			reqProcessor.Flags.Has(utils.MetaRoutesIgnoreErrors),
+			reqProcessor.Flags.Has(utils.MetaRoutesEventCost),
+			cgrEv, cgrArgs, reqProcessor.Flags.Has(utils.MetaFD),
+			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
+		)
+		rply := new(sessions.V1AuthorizeReply)
+		err = sa.connMgr.Call(sa.cfg.SMPPAgentCfg().SessionSConns, nil, utils.SessionSv1AuthorizeEvent,
+			authArgs, rply)
+		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
+		if err = agReq.setCGRReply(rply, err); err != nil {
+			return
+		}
+	case utils.MetaMessage:
+		evArgs := sessions.NewV1ProcessMessageArgs(
+			reqProcessor.Flags.GetBool(utils.MetaAttributes),
+			reqProcessor.Flags.ParamsSlice(utils.MetaAttributes, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaThresholds),
+			reqProcessor.Flags.ParamsSlice(utils.MetaThresholds, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaStats),
+			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaResources),
+			reqProcessor.Flags.Has(utils.MetaAccounts),
+			reqProcessor.Flags.GetBool(utils.MetaRoutes),
+			reqProcessor.Flags.Has(utils.MetaRoutesIgnoreErrors),
+			reqProcessor.Flags.Has(utils.MetaRoutesEventCost),
+			cgrEv, cgrArgs, reqProcessor.Flags.Has(utils.MetaFD),
+			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
+		)
+		rply := new(sessions.V1ProcessMessageReply)
+		err = sa.connMgr.Call(sa.cfg.SMPPAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessMessage,
+			evArgs, rply)
+		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
+			cgrEv.Event[utils.Usage] = 0 // avoid further debits
+		} else if evArgs.Debit {
+			cgrEv.Event[utils.Usage] = rply.MaxUsage // make sure the CDR reflects the debit
+		}
+		rply.SetMaxUsageNeeded(evArgs.Debit)
+		if err = agReq.setCGRReply(rply, err); err != nil {
+			return
+		}
+	case utils.MetaEvent:
+		evArgs := &sessions.V1ProcessEventArgs{
+			Flags:     reqProcessor.Flags.SliceFlags(),
+			CGREvent:  cgrEv,
+			Paginator: cgrArgs,
+		}
+		rply := new(sessions.V1ProcessEventReply)
+		err = sa.connMgr.Call(sa.cfg.SMPPAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessEvent,
+			evArgs, rply)
+		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
+			cgrEv.Event[utils.Usage] = 0 // avoid further debits
+		} else if needsMaxUsage(reqProcessor.Flags[utils.MetaRALs]) {
+			cgrEv.Event[utils.Usage] = rply.MaxUsage // make sure the CDR reflects the debit
+		}
+		if err = agReq.setCGRReply(rply, err); err != nil {
+			return
+		}
+	case utils.MetaCDRs: // allow CDR processing
+	}
+	// separate request so we can capture the Event also here
+	if reqProcessor.Flags.GetBool(utils.MetaCDRs) &&
+		!reqProcessor.Flags.Has(utils.MetaDryRun) {
+		var rplyCDRs string
+		if err = sa.connMgr.Call(sa.cfg.SMPPAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessCDR,
+			cgrEv, &rplyCDRs); err != nil {
+			agReq.CGRReply.Set(utils.PathItems{{Field: utils.Error}}, utils.NewNMData(err.Error()))
+		}
+	}
+	if err := agReq.SetFields(reqProcessor.ReplyFields); err != nil {
+		return false, err
+	}
+	if reqProcessor.Flags.Has(utils.MetaLog) {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> LOG, SMPP reply: %s",
+				utils.SMPPAgent, agReq.Reply))
+	}
+	if reqType == utils.MetaDryRun {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> DRY_RUN, SMPP reply: %s",
+				utils.SMPPAgent, agReq.Reply))
+	}
+	return true, nil
+}
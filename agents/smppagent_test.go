@@ -0,0 +1,253 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// testSMPPSmBody builds the body of a submit_sm without optional parameters
+func testSMPPSmBody(src, dst string, dataCoding byte, sm []byte) []byte {
+	var b bytes.Buffer
+	b.Write(smppCString(utils.EmptyString)) // service_type
+	b.Write([]byte{1, 1})
+	b.Write(smppCString(src))
+	b.Write([]byte{1, 1})
+	b.Write(smppCString(dst))
+	b.Write([]byte{0, 0, 0}) // esm_class, protocol_id, priority_flag
+	b.Write(smppCString(utils.EmptyString))
+	b.Write(smppCString(utils.EmptyString))
+	b.Write([]byte{1, 0, dataCoding, 0, byte(len(sm))})
+	b.Write(sm)
+	return b.Bytes()
+}
+
+// testSMPPClient is a minimal ESME sending one PDU at a time
+type testSMPPClient struct {
+	t    *testing.T
+	conn net.Conn
+	seq  uint32
+}
+
+func (c *testSMPPClient) request(cmdID uint32, body []byte) (resp *smppPDU) {
+	c.seq++
+	if _, err := c.conn.Write((&smppPDU{CommandID: cmdID,
+		SequenceNumber: c.seq, Body: body}).Bytes()); err != nil {
+		c.t.Fatal(err)
+	}
+	var err error
+	if resp, err = readSMPPPDU(c.conn); err != nil {
+		c.t.Fatal(err)
+	}
+	if resp.SequenceNumber != c.seq {
+		c.t.Errorf("Expected sequence_number: %d, received: %d", c.seq, resp.SequenceNumber)
+	}
+	return
+}
+
+func TestSMPPAgentServeConn(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SMPPAgentCfg().BindCredentials = map[string]string{"esme1": "secret"}
+	cfg.SMPPAgentCfg().RequestProcessors = []*config.RequestProcessor{{
+		ID:      "SMSCharging",
+		Filters: []string{"*string:~*vars.CommandID:submit_sm"},
+		Tenant:  config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep),
+		Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaMessage, utils.MetaAccounts}),
+		RequestFields: []*config.FCTemplate{
+			{Tag: utils.ToR, Path: utils.MetaCgreq + utils.NestingSep + utils.ToR,
+				Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile(utils.MetaSMS, utils.InfieldSep)},
+			{Tag: utils.OriginID, Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID,
+				Type: utils.MetaVariable, Value: config.NewRSRParsersMustCompile("~*vars.MessageID", utils.InfieldSep)},
+			{Tag: utils.AccountField, Path: utils.MetaCgreq + utils.NestingSep + utils.AccountField,
+				Type: utils.MetaVariable, Value: config.NewRSRParsersMustCompile("~*req.source_addr", utils.InfieldSep)},
+			{Tag: utils.Destination, Path: utils.MetaCgreq + utils.NestingSep + utils.Destination,
+				Type: utils.MetaVariable, Value: config.NewRSRParsersMustCompile("~*req.destination_addr", utils.InfieldSep)},
+			{Tag: utils.Usage, Path: utils.MetaCgreq + utils.NestingSep + utils.Usage,
+				Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("1", utils.InfieldSep)},
+		},
+		ReplyFields: []*config.FCTemplate{
+			{Tag: "CommandStatus", Path: utils.MetaRep + utils.NestingSep + smppCommandStatus,
+				Filters: []string{"*string:~*cgreq.Account:1003"},
+				Type:    utils.MetaConstant, Value: config.NewRSRParsersMustCompile("0x58", utils.InfieldSep)},
+		},
+	}}
+	for _, fld := range cfg.SMPPAgentCfg().RequestProcessors[0].RequestFields {
+		fld.ComputePath()
+	}
+	for _, fld := range cfg.SMPPAgentCfg().RequestProcessors[0].ReplyFields {
+		fld.ComputePath()
+	}
+	sS := &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.SessionSv1ProcessMessage: func(arg interface{}, rply interface{}) error {
+			args := arg.(*sessions.V1ProcessMessageArgs)
+			if !args.Debit ||
+				args.Event[utils.ToR] != utils.MetaSMS ||
+				args.Event[utils.Destination] != "1002" ||
+				args.Event[utils.OriginID] == utils.EmptyString {
+				t.Errorf("Unexpected args: %s", utils.ToJSON(args))
+			}
+			if args.Event[utils.AccountField] == "1002" {
+				return errors.New("RALS_ERROR:INSUFFICIENT_CREDIT")
+			}
+			*rply.(*sessions.V1ProcessMessageReply) = sessions.V1ProcessMessageReply{}
+			return nil
+		},
+	}}
+	internalSessionSChan := make(chan rpcclient.ClientConnector, 1)
+	internalSessionSChan <- sS
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): internalSessionSChan,
+	})
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	sa, err := NewSMPPAgent(connMgr, cfg, engine.NewFilterS(cfg, nil, dm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	defer clntConn.Close()
+	go sa.serveConn(srvConn, sa.stopChan)
+	c := &testSMPPClient{t: t, conn: clntConn}
+
+	sm := testSMPPSmBody("1001", "1002", 0, []byte("Hello"))
+	if resp := c.request(smppSubmitSm, sm); resp.CommandStatus != smppESMERinvbndsts {
+		t.Errorf("Expected command_status: %d, received: %d", smppESMERinvbndsts, resp.CommandStatus)
+	}
+	bindBody := append(smppCString("esme1"), smppCString("wrong")...)
+	bindBody = append(bindBody, 0, 0x34, 0, 0, 0)
+	if resp := c.request(smppBindTransceiver, bindBody); resp.CommandStatus != smppESMERinvpaswd {
+		t.Errorf("Expected command_status: %d, received: %d", smppESMERinvpaswd, resp.CommandStatus)
+	}
+	bindBody = append(smppCString("esme1"), smppCString("secret")...)
+	bindBody = append(bindBody, 0, 0x34, 0, 0, 0)
+	if resp := c.request(smppBindTransceiver, bindBody); resp.CommandID != smppBindTransceiver|smppRespFlag ||
+		resp.CommandStatus != smppESMERok ||
+		!bytes.Equal(resp.Body, smppCString("CGRateS")) {
+		t.Errorf("Unexpected bind_transceiver_resp: %+v", resp)
+	}
+	if resp := c.request(smppEnquireLink, nil); resp.CommandID != smppEnquireLink|smppRespFlag ||
+		resp.CommandStatus != smppESMERok {
+		t.Errorf("Unexpected enquire_link_resp: %+v", resp)
+	}
+	if resp := c.request(smppSubmitSm, sm); resp.CommandID != smppSubmitSm|smppRespFlag ||
+		resp.CommandStatus != smppESMERok ||
+		len(resp.Body) < 2 || resp.Body[len(resp.Body)-1] != 0 {
+		t.Errorf("Unexpected submit_sm_resp: %+v", resp)
+	}
+	sm = testSMPPSmBody("1002", "1002", 0, []byte("Hello"))
+	if resp := c.request(smppSubmitSm, sm); resp.CommandStatus != smppESMERsubmitfail {
+		t.Errorf("Expected command_status: %d, received: %d", smppESMERsubmitfail, resp.CommandStatus)
+	}
+	sm = testSMPPSmBody("1003", "1002", 0, []byte("Hello"))
+	if resp := c.request(smppSubmitSm, sm); resp.CommandStatus != 0x58 {
+		t.Errorf("Expected command_status: %d, received: %d", 0x58, resp.CommandStatus)
+	}
+	if resp := c.request(smppDeliverSm, sm); resp.CommandStatus != smppESMERsyserr { // no processor matching
+		t.Errorf("Expected command_status: %d, received: %d", smppESMERsyserr, resp.CommandStatus)
+	}
+	if resp := c.request(0x00000103, nil); resp.CommandID != smppGenericNack ||
+		resp.CommandStatus != smppESMERinvcmdid {
+		t.Errorf("Unexpected generic_nack: %+v", resp)
+	}
+	if resp := c.request(smppUnbind, nil); resp.CommandID != smppUnbind|smppRespFlag ||
+		resp.CommandStatus != smppESMERok {
+		t.Errorf("Unexpected unbind_resp: %+v", resp)
+	}
+	if _, err := readSMPPPDU(clntConn); err != io.EOF {
+		t.Errorf("Expected connection closed after unbind, received: %v", err)
+	}
+}
+
+func TestSMPPAgentAuthorizeBind(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	sa := &SMPPAgent{cfg: cfg}
+	if status := sa.authorizeBind("esme1", "any"); status != smppESMERok {
+		t.Errorf("Expected bind accepted without bind_credentials, received: %d", status)
+	}
+	cfg.SMPPAgentCfg().BindCredentials = map[string]string{"esme1": "secret"}
+	if status := sa.authorizeBind("esme2", "secret"); status != smppESMERinvsysid {
+		t.Errorf("Expected command_status: %d, received: %d", smppESMERinvsysid, status)
+	}
+	if status := sa.authorizeBind("esme1", "secret"); status != smppESMERok {
+		t.Errorf("Expected command_status: %d, received: %d", smppESMERok, status)
+	}
+}
+
+func TestSMPPPDUBytes(t *testing.T) {
+	pdu := &smppPDU{CommandID: smppEnquireLink, SequenceNumber: 7}
+	exp := []byte{0, 0, 0, 16, 0, 0, 0, 0x15, 0, 0, 0, 0, 0, 0, 0, 7}
+	if rcv := pdu.Bytes(); !bytes.Equal(exp, rcv) {
+		t.Errorf("Expected: %v, received: %v", exp, rcv)
+	}
+	if rcv, err := readSMPPPDU(bytes.NewReader(exp)); err != nil {
+		t.Error(err)
+	} else if rcv.CommandID != smppEnquireLink || rcv.SequenceNumber != 7 || len(rcv.Body) != 0 {
+		t.Errorf("Unexpected PDU: %+v", rcv)
+	}
+	binary.BigEndian.PutUint32(exp[0:4], 8)
+	expErr := "invalid command_length: <8>"
+	if _, err := readSMPPPDU(bytes.NewReader(exp)); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+}
+
+func TestDecodeSMPPSm(t *testing.T) {
+	body := testSMPPSmBody("1001", "1002", 0x08, []byte{0x04, 0x1F, 0x04, 0x40, 0x04, 0x38})
+	if ev, err := decodeSMPPSm(body); err != nil {
+		t.Error(err)
+	} else if ev[smppSourceAddr] != "1001" || ev[smppDestinationAddr] != "1002" ||
+		ev[smppDataCoding] != 8 || ev[smppShortMessage] != "При" {
+		t.Errorf("Unexpected event: %s", utils.ToJSON(ev))
+	}
+	// UDH and message_payload
+	body = testSMPPSmBody("1001", "1002", 0x01, nil)
+	body[len(body)-10] = smppUDHIndicator // esm_class
+	body = append(body, 0x04, 0x24, 0, 9, 5, 0, 3, 0x2A, 2, 1, 'H', 'i', '!')
+	if ev, err := decodeSMPPSm(body); err != nil {
+		t.Error(err)
+	} else if ev[smppShortMessage] != "Hi!" {
+		t.Errorf("Unexpected event: %s", utils.ToJSON(ev))
+	}
+	expErr := "decoding source_addr: unterminated C-Octet String"
+	if _, err := decodeSMPPSm([]byte{0, 1, 1, '1'}); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+}
+
+func TestDecodeSMPPText(t *testing.T) {
+	if rcv := decodeSMPPText([]byte{'H', 'i', 0x1B, 0x65, 0x00, 0x11}, 0x00); rcv != "Hi€@_" {
+		t.Errorf("Expected: %q, received: %q", "Hi€@_", rcv)
+	}
+	if rcv := decodeSMPPText([]byte{'c', 0xE9}, 0x03); rcv != "cé" {
+		t.Errorf("Expected: %q, received: %q", "cé", rcv)
+	}
+	if rcv := decodeSMPPText([]byte("Hello"), 0x01); rcv != "Hello" {
+		t.Errorf("Expected: %q, received: %q", "Hello", rcv)
+	}
+}
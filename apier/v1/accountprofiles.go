@@ -175,3 +175,21 @@ func (aSv1 *AccountSv1) DebitUsage(args *utils.ArgsAccountsForEvent,
 	eEc *utils.ExtEventCharges) (err error) {
 	return aSv1.aS.V1DebitUsage(args, eEc)
 }
+
+// MaxCost returns the maximum cost which can be debited for the event, based on matching Account
+func (aSv1 *AccountSv1) MaxCost(args *utils.ArgsAccountsForEvent,
+	eEc *utils.ExtEventCharges) (err error) {
+	return aSv1.aS.V1MaxCost(args, eEc)
+}
+
+// DebitCost performs debit of the cost for the provided event
+func (aSv1 *AccountSv1) DebitCost(args *utils.ArgsAccountsForEvent,
+	eEc *utils.ExtEventCharges) (err error) {
+	return aSv1.aS.V1DebitCost(args, eEc)
+}
+
+// RefundCharges gives back the units debited for an event
+func (aSv1 *AccountSv1) RefundCharges(args *utils.ArgsAccountsRefundCharges,
+	rply *string) (err error) {
+	return aSv1.aS.V1RefundCharges(args, rply)
+}
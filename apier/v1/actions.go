@@ -173,3 +173,18 @@ func (aSv1 *ActionSv1) ScheduleActions(args *utils.ArgActionSv1ScheduleActions,
 func (aSv1 *ActionSv1) ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) error {
 	return aSv1.aS.V1ExecuteActions(args, rpl)
 }
+
+// GetActionSchedules returns the past and upcoming executions of the scheduled ActionProfiles
+func (aSv1 *ActionSv1) GetActionSchedules(args *utils.ArgActionSv1GetActionSchedules, rpl *[]*engine.ActionScheduleInfo) error {
+	return aSv1.aS.V1GetActionSchedules(args, rpl)
+}
+
+// GetExecutions returns the actions in progress
+func (aSv1 *ActionSv1) GetExecutions(args *utils.ArgActionSv1GetExecutions, rpl *[]*engine.ActionExecutionInfo) error {
+	return aSv1.aS.V1GetExecutions(args, rpl)
+}
+
+// CancelExecution cancels the action in progress with the given execution ID
+func (aSv1 *ActionSv1) CancelExecution(args *utils.TenantIDWithOpts, rpl *string) error {
+	return aSv1.aS.V1CancelExecution(args, rpl)
+}
@@ -43,6 +43,7 @@ type StatSv1Interface interface {
 	GetStatQueuesForEvent(args *engine.StatsArgsProcessEvent, reply *[]string) (err error)
 	GetQueueStringMetrics(args *utils.TenantIDWithOpts, reply *map[string]string) (err error)
 	GetQueueFloatMetrics(args *utils.TenantIDWithOpts, reply *map[string]float64) (err error)
+	GetQueueHistory(args *utils.TenantIDWithOpts, reply *engine.StatQueueHistory) (err error)
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -59,6 +60,7 @@ type ResourceSv1Interface interface {
 type RouteSv1Interface interface {
 	GetRoutes(args *engine.ArgsGetRoutes, reply *engine.SortedRoutes) error
 	GetRouteProfilesForEvent(args *utils.CGREvent, reply *[]*engine.RouteProfile) error
+	ReportRouteOutcome(args *engine.ArgsRouteOutcome, reply *string) error
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -184,6 +186,7 @@ type CoreSv1Interface interface {
 type RateSv1Interface interface {
 	Ping(ign *utils.CGREvent, reply *string) error
 	CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.RateProfileCost) error
+	CostForEvents(args *engine.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) error
 }
 
 type RateProfileSv1Interface interface {
@@ -283,6 +286,9 @@ type ReplicatorSv1Interface interface {
 type ActionSv1Interface interface {
 	ScheduleActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) error
 	ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) error
+	GetActionSchedules(args *utils.ArgActionSv1GetActionSchedules, rpl *[]*engine.ActionScheduleInfo) error
+	GetExecutions(args *utils.ArgActionSv1GetExecutions, rpl *[]*engine.ActionExecutionInfo) error
+	CancelExecution(args *utils.TenantIDWithOpts, rpl *string) error
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
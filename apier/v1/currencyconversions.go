@@ -0,0 +1,93 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetCurrencyConversions returns the conversion table of a tenant
+func (apierSv1 *APIerSv1) GetCurrencyConversions(arg *utils.TenantWithOpts, reply *engine.CurrencyConversions) error {
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	cC, err := apierSv1.DataManager.GetCurrencyConversions(tnt, true, true, utils.NonTransactional)
+	if err != nil {
+		if err.Error() != utils.ErrNotFound.Error() {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	*reply = *cC
+	return nil
+}
+
+type CurrencyConversionsWithCache struct {
+	*engine.CurrencyConversionsWithOpts
+	Cache *string
+}
+
+// SetCurrencyConversions add/update the conversion table of a tenant
+func (apierSv1 *APIerSv1) SetCurrencyConversions(args *CurrencyConversionsWithCache, reply *string) error {
+	if args.CurrencyConversionsWithOpts == nil || args.CurrencyConversions == nil ||
+		len(args.ExchangeRates) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.ExchangeRates)
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.SetCurrencyConversions(args.CurrencyConversions); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheCurrencyConversions and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheCurrencyConversions: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apierSv1.CallCache(args.Cache, args.Tenant, utils.CacheCurrencyConversions,
+		args.Tenant, nil, nil, args.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveCurrencyConversions remove the conversion table of a tenant
+func (apierSv1 *APIerSv1) RemoveCurrencyConversions(arg *utils.TenantWithCache, reply *string) error {
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.RemoveCurrencyConversions(tnt); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheCurrencyConversions and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheCurrencyConversions: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err := apierSv1.CallCache(arg.Cache, tnt, utils.CacheCurrencyConversions,
+		tnt, nil, nil, arg.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCurrencyConversionsAPIs(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().DefaultCaching = utils.MetaNone
+	apierSv1 := &APIerSv1{
+		DataManager: engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil),
+		Config:      cfg,
+	}
+	defer engine.Cache.Remove(utils.CacheCurrencyConversions, "cgrates.org", true, utils.NonTransactional)
+
+	var reply string
+	if err := apierSv1.SetCurrencyConversions(&CurrencyConversionsWithCache{
+		CurrencyConversionsWithOpts: &engine.CurrencyConversionsWithOpts{
+			CurrencyConversions: &engine.CurrencyConversions{},
+		},
+	}, &reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.ExchangeRates).Error() {
+		t.Errorf("Expected %v, received %v", utils.NewErrMandatoryIeMissing(utils.ExchangeRates), err)
+	}
+	cC := &engine.CurrencyConversions{
+		ExchangeRates: map[string]*utils.Decimal{
+			"EUR:USD": utils.NewDecimal(12, 1),
+		},
+	}
+	if err := apierSv1.SetCurrencyConversions(&CurrencyConversionsWithCache{
+		CurrencyConversionsWithOpts: &engine.CurrencyConversionsWithOpts{
+			CurrencyConversions: cC,
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	exp := &engine.CurrencyConversions{
+		Tenant:        "cgrates.org",
+		ExchangeRates: cC.ExchangeRates,
+	}
+	var rcv engine.CurrencyConversions
+	if err := apierSv1.GetCurrencyConversions(&utils.TenantWithOpts{}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, &rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := apierSv1.RemoveCurrencyConversions(&utils.TenantWithCache{Tenant: "cgrates.org"}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	if err := apierSv1.GetCurrencyConversions(&utils.TenantWithOpts{Tenant: "cgrates.org"}, &rcv); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
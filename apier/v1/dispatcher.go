@@ -285,6 +285,12 @@ func (dSts *DispatcherStatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithOpts
 	return dSts.dS.StatSv1GetQueueFloatMetrics(args, reply)
 }
 
+// GetQueueHistory implements StatSv1GetQueueHistory
+func (dSts *DispatcherStatSv1) GetQueueHistory(args *utils.TenantIDWithOpts,
+	reply *engine.StatQueueHistory) error {
+	return dSts.dS.StatSv1GetQueueHistory(args, reply)
+}
+
 func (dSts *DispatcherStatSv1) GetQueueIDs(args *utils.TenantWithOpts,
 	reply *[]string) error {
 	return dSts.dS.StatSv1GetQueueIDs(args, reply)
@@ -364,6 +370,12 @@ func (dRoute *DispatcherRouteSv1) GetRouteProfilesForEvent(args *utils.CGREvent,
 	return dRoute.dRoute.RouteSv1GetRouteProfilesForEvent(args, reply)
 }
 
+// ReportRouteOutcome implements RouteSv1ReportRouteOutcome
+func (dRoute *DispatcherRouteSv1) ReportRouteOutcome(args *engine.ArgsRouteOutcome,
+	reply *string) error {
+	return dRoute.dRoute.RouteSv1ReportRouteOutcome(args, reply)
+}
+
 func NewDispatcherAttributeSv1(dps *dispatchers.DispatcherService) *DispatcherAttributeSv1 {
 	return &DispatcherAttributeSv1{dA: dps}
 }
@@ -1338,6 +1350,11 @@ func (dR *DispatcherRateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *
 	return dR.dR.RateSv1CostForEvent(args, rpCost)
 }
 
+// CostForEvents implements RateSv1CostForEvents
+func (dR *DispatcherRateSv1) CostForEvents(args *engine.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) error {
+	return dR.dR.RateSv1CostForEvents(args, rpCosts)
+}
+
 func NewDispatcherActionSv1(dps *dispatchers.DispatcherService) *DispatcherActionSv1 {
 	return &DispatcherActionSv1{dR: dps}
 }
@@ -1366,6 +1383,21 @@ func (dR *DispatcherAccountSv1) Ping(args *utils.CGREvent, reply *string) error
 	return dR.dR.AccountSv1Ping(args, reply)
 }
 
+// MaxCost implements AccountSv1MaxCost
+func (dR *DispatcherAccountSv1) MaxCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) error {
+	return dR.dR.AccountSv1MaxCost(args, eEc)
+}
+
+// DebitCost implements AccountSv1DebitCost
+func (dR *DispatcherAccountSv1) DebitCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) error {
+	return dR.dR.AccountSv1DebitCost(args, eEc)
+}
+
+// RefundCharges implements AccountSv1RefundCharges
+func (dR *DispatcherAccountSv1) RefundCharges(args *utils.ArgsAccountsRefundCharges, reply *string) error {
+	return dR.dR.AccountSv1RefundCharges(args, reply)
+}
+
 func (rS *DispatcherSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
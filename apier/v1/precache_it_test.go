@@ -186,6 +186,11 @@ func testPrecacheGetCacheStatsAfterRestart(t *testing.T) {
 		utils.CacheRateProfilesFilterIndexes: {},
 		utils.CacheRateFilterIndexes:         {},
 		utils.CacheRateProfiles:              {},
+		utils.CacheCurrencyConversions:       {},
+		utils.CacheRateVolumeCounters:        {},
+		utils.CacheActionSchedules:           {},
+		utils.CacheStatQueueHistories:        {},
+		utils.CacheStoredSessions:            {},
 		utils.CacheRatingPlans:               {Items: 4},
 		utils.CacheRatingProfiles:            {Items: 5},
 		utils.CacheResourceFilterIndexes: {
@@ -219,6 +224,7 @@ func testPrecacheGetCacheStatsAfterRestart(t *testing.T) {
 		utils.CacheThresholds:                   {Items: 7},
 		utils.CacheTimings:                      {},
 		utils.CacheDiameterMessages:             {},
+		utils.CacheRadiusPackets:                {},
 		utils.CacheClosedSessions:               {},
 		utils.CacheLoadIDs:                      {},
 		utils.CacheRPCConnections:               {},
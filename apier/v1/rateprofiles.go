@@ -222,6 +222,11 @@ func (rSv1 *RateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.R
 	return rSv1.rS.V1CostForEvent(args, rpCost)
 }
 
+// CostForEvents simulates the costs for a batch of events
+func (rSv1 *RateSv1) CostForEvents(args *engine.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) (err error) {
+	return rSv1.rS.V1CostForEvents(args, rpCosts)
+}
+
 func (rSv1 *RateSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
@@ -146,6 +146,12 @@ func (rS *RouteSv1) GetRouteProfilesForEvent(args *utils.CGREvent,
 	return rS.rS.V1GetRouteProfilesForEvent(args, reply)
 }
 
+// ReportRouteOutcome feeds the circuit breaker of a route with the outcome of a call
+func (rS *RouteSv1) ReportRouteOutcome(args *engine.ArgsRouteOutcome,
+	reply *string) error {
+	return rS.rS.V1ReportRouteOutcome(args, reply)
+}
+
 func (rS *RouteSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
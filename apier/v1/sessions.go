@@ -152,6 +152,11 @@ func (ssv1 *SessionSv1) ReAuthorize(args *utils.SessionFilter, reply *string) er
 	return ssv1.sS.BiRPCv1ReAuthorize(nil, args, reply)
 }
 
+// AlterSession sends the new values of the filtered sessions to the agents
+func (ssv1 *SessionSv1) AlterSession(args *utils.SessionFilterWithEvent, reply *string) error {
+	return ssv1.sS.BiRPCv1AlterSession(nil, args, reply)
+}
+
 // DisconnectPeer sends the DPR for the OriginHost and OriginRealm
 func (ssv1 *SessionSv1) DisconnectPeer(args *utils.DPRArgs, reply *string) error {
 	return ssv1.sS.BiRPCv1DisconnectPeer(nil, args, reply)
@@ -56,6 +56,7 @@ func (ssv1 *SessionSv1) Handlers() map[string]interface{} {
 		utils.SessionSv1DeactivateSessions: ssv1.BiRPCv1DeactivateSessions,
 
 		utils.SessionSv1ReAuthorize:    ssv1.BiRPCV1ReAuthorize,
+		utils.SessionSv1AlterSession:   ssv1.BiRPCV1AlterSession,
 		utils.SessionSv1DisconnectPeer: ssv1.BiRPCV1DisconnectPeer,
 
 		utils.SessionSv1STIRAuthenticate: ssv1.BiRPCV1STIRAuthenticate,
@@ -319,6 +320,18 @@ func (ssv1 *SessionSv1) BiRPCV1ReAuthorize(clnt *rpc2.Client,
 	return ssv1.sS.BiRPCv1ReAuthorize(clnt, args, reply)
 }
 
+// BiRPCV1AlterSession sends the new values of the filtered sessions to the agents
+func (ssv1 *SessionSv1) BiRPCV1AlterSession(clnt *rpc2.Client,
+	args *utils.SessionFilterWithEvent, reply *string) (err error) {
+	if ssv1.caps.IsLimited() {
+		if err = ssv1.caps.Allocate(); err != nil {
+			return
+		}
+		defer ssv1.caps.Deallocate()
+	}
+	return ssv1.sS.BiRPCv1AlterSession(clnt, args, reply)
+}
+
 // BiRPCV1DisconnectPeer sends the DPR for the OriginHost and OriginRealm
 func (ssv1 *SessionSv1) BiRPCV1DisconnectPeer(clnt *rpc2.Client,
 	args *utils.DPRArgs, reply *string) (err error) {
@@ -21,6 +21,7 @@ package v1
 import (
 	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -73,6 +74,9 @@ func (apierSv1 *APIerSv1) SetStatQueueProfile(arg *engine.StatQueueWithCache, re
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
+	if _, err = config.NewRSRParsers(arg.GroupBy, utils.InfieldSep); err != nil {
+		return utils.NewErrServerError(err)
+	}
 	if err = apierSv1.DataManager.SetStatQueueProfile(arg.StatQueueProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
@@ -137,6 +141,18 @@ func (apierSv1 *APIerSv1) RemoveStatQueueProfile(args *utils.TenantIDWithCache,
 	if err := apierSv1.DataManager.RemoveStatQueue(tnt, args.ID, utils.NonTransactional); err != nil {
 		return utils.APIErrorHandler(err)
 	}
+	// remove also the child queues of grouped profiles
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(utils.StatQueuePrefix +
+		utils.ConcatenatedKey(tnt, args.ID) + utils.ConcatenatedKeySep)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	for _, key := range keys {
+		if err := apierSv1.DataManager.RemoveStatQueue(tnt,
+			key[len(utils.StatQueuePrefix+tnt+utils.ConcatenatedKeySep):], utils.NonTransactional); err != nil {
+			return utils.APIErrorHandler(err)
+		}
+	}
 	//generate a loadID for CacheStatQueueProfiles and CacheStatQueues and store it in database
 	//make 1 insert for both StatQueueProfile and StatQueue instead of 2
 	loadID := time.Now().UnixNano()
@@ -197,6 +213,11 @@ func (stsv1 *StatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithOpts, reply *
 	return stsv1.sS.V1GetQueueFloatMetrics(args.TenantID, reply)
 }
 
+// GetQueueHistory returns the metric snapshots of the past windows for a Queue
+func (stsv1 *StatSv1) GetQueueHistory(args *utils.TenantIDWithOpts, reply *engine.StatQueueHistory) (err error) {
+	return stsv1.sS.V1GetQueueHistory(args.TenantID, reply)
+}
+
 // ResetStatQueue resets the stat queue
 func (stsv1 *StatSv1) ResetStatQueue(tntID *utils.TenantIDWithOpts, reply *string) error {
 	return stsv1.sS.V1ResetStatQueue(tntID.TenantID, reply)
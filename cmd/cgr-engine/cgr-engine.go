@@ -562,6 +562,7 @@ func main() {
 		utils.SchedulerS:      new(sync.WaitGroup),
 		utils.SessionS:        new(sync.WaitGroup),
 		utils.SIPAgent:        new(sync.WaitGroup),
+		utils.SMPPAgent:       new(sync.WaitGroup),
 		utils.StatS:           new(sync.WaitGroup),
 		utils.StorDB:          new(sync.WaitGroup),
 		utils.ThresholdS:      new(sync.WaitGroup),
@@ -636,7 +637,7 @@ func main() {
 	dspH := services.NewDispatcherHostsService(cfg, server, connManager, anz, srvDep)
 	chrS := services.NewChargerService(cfg, dmService, cacheS, filterSChan, server,
 		internalChargerSChan, connManager, anz, srvDep)
-	tS := services.NewThresholdService(cfg, dmService, cacheS, filterSChan, server, internalThresholdSChan, anz, srvDep)
+	tS := services.NewThresholdService(cfg, dmService, cacheS, filterSChan, server, internalThresholdSChan, connManager, anz, srvDep)
 	stS := services.NewStatService(cfg, dmService, cacheS, filterSChan, server,
 		internalStatSChan, connManager, anz, srvDep)
 	reS := services.NewResourceService(cfg, dmService, cacheS, filterSChan, server,
@@ -678,8 +679,9 @@ func main() {
 		services.NewEventExporterService(cfg, filterSChan,
 			connManager, server, internalEEsChan, anz, srvDep),
 		services.NewRateService(cfg, cacheS, filterSChan, dmService,
-			server, internalRateSChan, anz, srvDep),
+			connManager, server, internalRateSChan, anz, srvDep),
 		services.NewSIPAgent(cfg, filterSChan, shdChan, connManager, srvDep),
+		services.NewSMPPAgent(cfg, filterSChan, shdChan, connManager, srvDep),
 		services.NewActionService(cfg, dmService, cacheS, filterSChan, connManager, server, internalActionSChan, anz, srvDep),
 		services.NewAccountService(cfg, dmService, cacheS, filterSChan, connManager, server, internalAccountSChan, anz, srvDep),
 	)
@@ -0,0 +1,467 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	metaFixed       = "*fixed"
+	metaUniform     = "*uniform"
+	metaExponential = "*exponential"
+
+	ccrInit           = "*ccr_init"
+	ccrUpdate         = "*ccr_update"
+	ccrTerminate      = "*ccr_terminate"
+	radiusAuth        = "*radius_auth"
+	radiusAcctStart   = "*radius_acct_start"
+	radiusAcctInterim = "*radius_acct_interim"
+	radiusAcctStop    = "*radius_acct_stop"
+	sipInvite         = "*sip_invite"
+)
+
+// agentSimTemplates are the templates used when not defined in the configuration under the same ID
+const agentSimTemplates = `{
+"templates": {
+	"*ccr_common": [
+		{"tag": "SessionId", "path": "*diamreq.Session-Id", "type": "*variable",
+			"value": "~*req.OriginID", "mandatory": true},
+		{"tag": "OriginHost", "path": "*diamreq.Origin-Host", "type": "*variable",
+			"value": "~*req.OriginHost", "mandatory": true},
+		{"tag": "OriginRealm", "path": "*diamreq.Origin-Realm", "type": "*variable",
+			"value": "~*req.OriginRealm", "mandatory": true},
+		{"tag": "DestinationRealm", "path": "*diamreq.Destination-Realm", "type": "*variable",
+			"value": "~*req.OriginRealm", "mandatory": true},
+		{"tag": "AuthApplicationId", "path": "*diamreq.Auth-Application-Id", "type": "*constant",
+			"value": "4"},
+		{"tag": "ServiceContextId", "path": "*diamreq.Service-Context-Id", "type": "*constant",
+			"value": "voice@cgrates.org"},
+		{"tag": "CCRequestNumber", "path": "*diamreq.CC-Request-Number", "type": "*variable",
+			"value": "~*req.RequestNumber"},
+		{"tag": "SubscriptionIdType", "path": "*diamreq.Subscription-Id.Subscription-Id-Type",
+			"type": "*constant", "value": "0"},
+		{"tag": "SubscriptionIdData", "path": "*diamreq.Subscription-Id.Subscription-Id-Data",
+			"type": "*variable", "value": "~*req.Account"},
+		{"tag": "CallingPartyAddress", "path": "*diamreq.Service-Information.IN-Information.Calling-Party-Address",
+			"type": "*variable", "value": "~*req.Account"},
+		{"tag": "RealCalledNumber", "path": "*diamreq.Service-Information.IN-Information.Real-Called-Number",
+			"type": "*variable", "value": "~*req.Destination"},
+	],
+	"*ccr_init": [
+		{"tag": "Common", "type": "*template", "value": "*ccr_common"},
+		{"tag": "CCRequestType", "path": "*diamreq.CC-Request-Type", "type": "*constant",
+			"value": "1"},
+		{"tag": "RequestedCCTime", "path": "*diamreq.Requested-Service-Unit.CC-Time", "type": "*variable",
+			"value": "~*req.RequestedUsage"},
+	],
+	"*ccr_update": [
+		{"tag": "Common", "type": "*template", "value": "*ccr_common"},
+		{"tag": "CCRequestType", "path": "*diamreq.CC-Request-Type", "type": "*constant",
+			"value": "2"},
+		{"tag": "RequestedCCTime", "path": "*diamreq.Requested-Service-Unit.CC-Time", "type": "*variable",
+			"value": "~*req.RequestedUsage"},
+		{"tag": "UsedCCTime", "path": "*diamreq.Used-Service-Unit.CC-Time", "type": "*variable",
+			"value": "~*req.UsedUsage"},
+	],
+	"*ccr_terminate": [
+		{"tag": "Common", "type": "*template", "value": "*ccr_common"},
+		{"tag": "CCRequestType", "path": "*diamreq.CC-Request-Type", "type": "*constant",
+			"value": "3"},
+		{"tag": "UsedCCTime", "path": "*diamreq.Used-Service-Unit.CC-Time", "type": "*variable",
+			"value": "~*req.UsedUsage"},
+		{"tag": "TerminationCause", "path": "*diamreq.Termination-Cause", "type": "*constant",
+			"value": "1"},
+	],
+	"*radius_auth": [
+		{"tag": "UserName", "path": "*diamreq.User-Name", "type": "*variable",
+			"value": "~*req.Account", "mandatory": true},
+		{"tag": "CalledStationId", "path": "*diamreq.Called-Station-Id", "type": "*variable",
+			"value": "~*req.Destination", "mandatory": true},
+		{"tag": "AcctSessionId", "path": "*diamreq.Acct-Session-Id", "type": "*variable",
+			"value": "~*req.OriginID", "mandatory": true},
+		{"tag": "NASIPAddress", "path": "*diamreq.NAS-IP-Address", "type": "*constant",
+			"value": "127.0.0.1"},
+	],
+	"*radius_acct_common": [
+		{"tag": "UserName", "path": "*diamreq.User-Name", "type": "*variable",
+			"value": "~*req.Account", "mandatory": true},
+		{"tag": "CalledStationId", "path": "*diamreq.Called-Station-Id", "type": "*variable",
+			"value": "~*req.Destination", "mandatory": true},
+		{"tag": "AcctSessionId", "path": "*diamreq.Acct-Session-Id", "type": "*variable",
+			"value": "~*req.OriginID", "mandatory": true},
+		{"tag": "NASIPAddress", "path": "*diamreq.NAS-IP-Address", "type": "*constant",
+			"value": "127.0.0.1"},
+		{"tag": "AcctSessionTime", "path": "*diamreq.Acct-Session-Time", "type": "*variable",
+			"value": "~*req.Usage"},
+	],
+	"*radius_acct_start": [
+		{"tag": "AcctStatusType", "path": "*diamreq.Acct-Status-Type", "type": "*constant",
+			"value": "1"},
+		{"tag": "Common", "type": "*template", "value": "*radius_acct_common"},
+	],
+	"*radius_acct_interim": [
+		{"tag": "AcctStatusType", "path": "*diamreq.Acct-Status-Type", "type": "*constant",
+			"value": "3"},
+		{"tag": "Common", "type": "*template", "value": "*radius_acct_common"},
+	],
+	"*radius_acct_stop": [
+		{"tag": "AcctStatusType", "path": "*diamreq.Acct-Status-Type", "type": "*constant",
+			"value": "2"},
+		{"tag": "Common", "type": "*template", "value": "*radius_acct_common"},
+	],
+	"*sip_invite": [
+		{"tag": "Request", "path": "*diamreq.Request", "type": "*composed",
+			"value": "INVITE sip:;~*req.Destination;@;~*req.AgentAddress;` + "` SIP/2.0`" + `"},
+		{"tag": "Via", "path": "*diamreq.Via", "type": "*composed",
+			"value": "` + "`SIP/2.0/UDP 127.0.0.1;branch=z9hG4bK`" + `;~*req.OriginID"},
+		{"tag": "From", "path": "*diamreq.From", "type": "*composed",
+			"value": "<sip:;~*req.Account;` + "`@127.0.0.1>;tag=`" + `;~*req.OriginID"},
+		{"tag": "To", "path": "*diamreq.To", "type": "*composed",
+			"value": "<sip:;~*req.Destination;@;~*req.AgentAddress;>"},
+		{"tag": "CallID", "path": "*diamreq.Call-ID", "type": "*variable",
+			"value": "~*req.OriginID"},
+		{"tag": "CSeq", "path": "*diamreq.CSeq", "type": "*constant",
+			"value": "1 INVITE"},
+		{"tag": "MaxForwards", "path": "*diamreq.Max-Forwards", "type": "*constant",
+			"value": "70"},
+		{"tag": "ContentLength", "path": "*diamreq.Content-Length", "type": "*constant",
+			"value": "0"},
+	],
+},
+}`
+
+var (
+	agentType = cgrTesterFlags.String("agent_type", "",
+		"Simulate calls towards the agent <*diameter|*radius|*sip>.")
+	agentNetwork = cgrTesterFlags.String("agent_network", "",
+		"Network used to reach the agent. Empty for the one in configuration.")
+	agentAddress = cgrTesterFlags.String("agent_address", "",
+		"Address of the agent, the auth one for *radius. Empty for the one in configuration.")
+	agentAcctAddress = cgrTesterFlags.String("agent_acct_address", "",
+		"Accounting address of the *radius agent. Empty for the one in configuration.")
+	radiusSecret = cgrTesterFlags.String("radius_secret", "CGRateS.org", "The secret shared with the *radius agent.")
+	originHost   = cgrTesterFlags.String("origin_host", "cgr-tester", "The Origin-Host used by *diameter.")
+	originRealm  = cgrTesterFlags.String("origin_realm", "cgrates.org", "The Origin-Realm used by *diameter.")
+	dictsDir     = cgrTesterFlags.String("dictionaries_dir", "",
+		"Dictionaries used by *diameter and *radius. Empty for the ones in configuration.")
+	cps       = cgrTesterFlags.Int("cps", 0, "Calls started per second towards the agent, 0 for no limit.")
+	usageDist = cgrTesterFlags.String("usage_distribution", metaFixed,
+		"Distribution of the call durations around usage <*fixed|*uniform|*exponential>.")
+	interimInterval = cgrTesterFlags.String("interim_interval", "0s",
+		"Interval of the CCR-Update or Interim-Update requests, 0 to disable them.")
+	holdCalls = cgrTesterFlags.Bool("hold_calls", false,
+		"Wait in real time between the requests of a call.")
+	replyTimeout = cgrTesterFlags.String("reply_timeout", "1s", "Time to wait for the agent replies.")
+)
+
+// NewAgentSimTester returns the tester simulating calls towards the agent
+func NewAgentSimTester(cfg *config.CGRConfig, aType string,
+	calls, parallel, cps int) (ast *AgentSimTester, err error) {
+	ast = &AgentSimTester{
+		calls:    calls,
+		parallel: parallel,
+		cps:      cps,
+		acct:     *subject,
+		dst:      *destination,
+		dist:     *usageDist,
+		hold:     *holdCalls,
+		stats:    make(map[string]*agentSimStats),
+	}
+	if ast.usage, err = utils.ParseDurationWithNanosecs(*usage); err != nil {
+		return nil, err
+	}
+	if ast.interim, err = utils.ParseDurationWithNanosecs(*interimInterval); err != nil {
+		return nil, err
+	}
+	var rplyTimeout time.Duration
+	if rplyTimeout, err = utils.ParseDurationWithNanosecs(*replyTimeout); err != nil {
+		return nil, err
+	}
+	network, addr, acctAddr, dicts := *agentNetwork, *agentAddress, *agentAcctAddress, *dictsDir
+	switch aType {
+	case utils.MetaDiameter:
+		ast.flow = [3]string{ccrInit, ccrUpdate, ccrTerminate}
+		if network == utils.EmptyString {
+			network = cfg.DiameterAgentCfg().ListenNet
+		}
+		if addr == utils.EmptyString {
+			addr = cfg.DiameterAgentCfg().Listen
+		}
+		if dicts == utils.EmptyString {
+			dicts = cfg.DiameterAgentCfg().DictionariesPath
+		}
+	case utils.MetaRadius:
+		ast.authStep = radiusAuth
+		ast.flow = [3]string{radiusAcctStart, radiusAcctInterim, radiusAcctStop}
+		if network == utils.EmptyString {
+			network = cfg.RadiusAgentCfg().ListenNet
+		}
+		if addr == utils.EmptyString {
+			addr = cfg.RadiusAgentCfg().ListenAuth
+		}
+		if acctAddr == utils.EmptyString {
+			acctAddr = cfg.RadiusAgentCfg().ListenAcct
+		}
+		if dicts == utils.EmptyString {
+			dicts = cfg.RadiusAgentCfg().ClientDictionaries[utils.MetaDefault]
+		}
+	case utils.MetaSIP:
+		ast.authStep = sipInvite
+		if network == utils.EmptyString {
+			network = cfg.SIPAgentCfg().ListenNet
+		}
+		if addr == utils.EmptyString {
+			addr = cfg.SIPAgentCfg().Listen
+		}
+	default:
+		return nil, fmt.Errorf("unsupported agent type: <%s>", aType)
+	}
+	ast.agentAddr = addr
+	if ast.tpls, err = agentSimLoadTemplates(cfg.TemplatesCfg()); err != nil {
+		return nil, err
+	}
+	if ast.sim, err = agents.NewAgentSimulator(aType, network, addr, acctAddr,
+		*radiusSecret, *originHost, *originRealm, dicts,
+		cfg.GeneralCfg().DefaultTimezone, rplyTimeout); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// agentSimLoadTemplates merges the configured templates over the built-in ones
+// and inflates the *template fields
+func agentSimLoadTemplates(cfgTpls config.FcTemplates) (tpls config.FcTemplates, err error) {
+	var dfltCfg *config.CGRConfig
+	if dfltCfg, err = config.NewCGRConfigFromJSONStringWithDefaults(agentSimTemplates); err != nil {
+		return
+	}
+	tpls = make(config.FcTemplates)
+	for tplID, tpl := range dfltCfg.TemplatesCfg() {
+		tpls[tplID] = tpl
+	}
+	for tplID, tpl := range cfgTpls {
+		tpls[tplID] = tpl
+	}
+	for tplID, tpl := range tpls {
+		var inflated []*config.FCTemplate
+		if inflated, err = config.InflateTemplates(tpl, tpls); err != nil {
+			return nil, err
+		} else if inflated != nil {
+			tpls[tplID] = inflated
+		}
+	}
+	return
+}
+
+// AgentSimTester simulates calls towards one of the agents
+type AgentSimTester struct {
+	calls     int
+	parallel  int
+	cps       int
+	acct      string
+	dst       string
+	agentAddr string
+	usage     time.Duration
+	dist      string
+	interim   time.Duration
+	hold      bool
+
+	authStep string    // request sent before the call flow, the only one for *sip
+	flow     [3]string // templates used to start, update and terminate the call
+	tpls     config.FcTemplates
+	sim      agents.AgentSimulator
+
+	statsLck sync.Mutex
+	stats    map[string]*agentSimStats // indexed on template ID
+}
+
+// callDuration returns the duration of a call out of the configured distribution
+func (ast *AgentSimTester) callDuration() time.Duration {
+	switch ast.dist {
+	case metaUniform:
+		return time.Duration(rand.Int63n(2*int64(ast.usage) + 1))
+	case metaExponential:
+		return time.Duration(rand.ExpFloat64() * float64(ast.usage))
+	default:
+		return ast.usage
+	}
+}
+
+// sendRequest sends the request built out of the template and records its latency
+func (ast *AgentSimTester) sendRequest(tplID string, ev utils.MapStorage) (err error) {
+	start := time.Now()
+	err = ast.sim.SendRequest(ast.tpls[tplID], ev)
+	lat := time.Since(start)
+	ast.statsLck.Lock()
+	st, has := ast.stats[tplID]
+	if !has {
+		st = &agentSimStats{errs: make(map[string]int)}
+		ast.stats[tplID] = st
+	}
+	st.latencies = append(st.latencies, lat)
+	if err != nil {
+		st.errs[err.Error()]++
+	}
+	ast.statsLck.Unlock()
+	return
+}
+
+// simulateCall sends the requests of one call, stopping at the first error
+func (ast *AgentSimTester) simulateCall() {
+	callDur := ast.callDuration()
+	reqUsage := callDur
+	if ast.interim != 0 {
+		reqUsage = ast.interim
+	}
+	ev := utils.MapStorage{
+		utils.OriginID:     utils.GenUUID(),
+		utils.Tenant:       *tenant,
+		utils.AccountField: ast.acct,
+		utils.Destination:  ast.dst,
+		utils.Category:     *category,
+		utils.ToR:          *tor,
+		utils.OriginHost:   *originHost,
+		"OriginRealm":      *originRealm,
+		"AgentAddress":     ast.agentAddr,
+		"RequestNumber":    0,
+		utils.Usage:        0,
+		"UsedUsage":        0,
+		"RequestedUsage":   int64(reqUsage.Seconds()),
+	}
+	if ast.authStep != utils.EmptyString {
+		if err := ast.sendRequest(ast.authStep, ev); err != nil ||
+			ast.flow[0] == utils.EmptyString {
+			return
+		}
+	}
+	if err := ast.sendRequest(ast.flow[0], ev); err != nil {
+		return
+	}
+	var usage time.Duration
+	for reqNr := 1; ; reqNr++ {
+		used := callDur - usage
+		step := ast.flow[2]
+		if ast.interim != 0 && used > ast.interim {
+			used = ast.interim
+			step = ast.flow[1]
+		}
+		if ast.hold {
+			time.Sleep(used)
+		}
+		usage += used
+		ev["RequestNumber"] = reqNr
+		ev[utils.Usage] = int64(usage.Seconds())
+		ev["UsedUsage"] = int64(used.Seconds())
+		if err := ast.sendRequest(step, ev); err != nil ||
+			step == ast.flow[2] {
+			return
+		}
+	}
+}
+
+// Test simulates the calls, limited by parallel and cps, and prints the statistics
+func (ast *AgentSimTester) Test() (err error) {
+	defer ast.sim.Close()
+	parallel := ast.parallel
+	if parallel <= 0 {
+		parallel = 1
+	}
+	var tick <-chan time.Time
+	if ast.cps > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(ast.cps))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	log.Printf("Simulating %d calls...", ast.calls)
+	var wg sync.WaitGroup
+	callLimiter := make(chan struct{}, parallel)
+	start := time.Now()
+	for i := 0; i < ast.calls; i++ {
+		if tick != nil {
+			<-tick
+		}
+		callLimiter <- struct{}{} // block till buffer will allow
+		wg.Add(1)
+		go func() {
+			ast.simulateCall()
+			<-callLimiter // release one call from buffer
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	log.Printf("Elapsed: %s resulted: %f calls/s.", elapsed, float64(ast.calls)/elapsed.Seconds())
+	ast.printStats()
+	return
+}
+
+// printStats logs the latencies and errors per template
+func (ast *AgentSimTester) printStats() {
+	tplIDs := make([]string, 0, len(ast.stats))
+	for tplID := range ast.stats {
+		tplIDs = append(tplIDs, tplID)
+	}
+	sort.Strings(tplIDs)
+	for _, tplID := range tplIDs {
+		st := ast.stats[tplID]
+		sort.Slice(st.latencies, func(i, j int) bool { return st.latencies[i] < st.latencies[j] })
+		log.Printf("<%s> requests: %d, errors: %d, latency min: %s, avg: %s, p50: %s, p95: %s, p99: %s, max: %s",
+			tplID, len(st.latencies), st.errCount(), st.latencies[0], st.average(),
+			st.percentile(50), st.percentile(95), st.percentile(99), st.latencies[len(st.latencies)-1])
+		for errMsg, cnt := range st.errs {
+			log.Printf("<%s> error: %s, count: %d", tplID, errMsg, cnt)
+		}
+	}
+}
+
+// agentSimStats holds the latencies and errors of the requests sent with the same template
+type agentSimStats struct {
+	latencies []time.Duration
+	errs      map[string]int // error counts indexed on message
+}
+
+func (st *agentSimStats) errCount() (cnt int) {
+	for _, errCnt := range st.errs {
+		cnt += errCnt
+	}
+	return
+}
+
+func (st *agentSimStats) average() time.Duration {
+	var sum time.Duration
+	for _, lat := range st.latencies {
+		sum += lat
+	}
+	return sum / time.Duration(len(st.latencies))
+}
+
+// percentile expects the latencies sorted
+func (st *agentSimStats) percentile(p int) time.Duration {
+	return st.latencies[(len(st.latencies)-1)*p/100]
+}
@@ -0,0 +1,72 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgentSimTesterCallDuration(t *testing.T) {
+	ast := &AgentSimTester{usage: time.Minute, dist: metaFixed}
+	if rcv := ast.callDuration(); rcv != time.Minute {
+		t.Errorf("Expected: %s, received: %s", time.Minute, rcv)
+	}
+	ast.dist = metaUniform
+	for i := 0; i < 100; i++ {
+		if rcv := ast.callDuration(); rcv < 0 || rcv > 2*time.Minute {
+			t.Fatalf("Expected a duration between 0s and 2m, received: %s", rcv)
+		}
+	}
+	ast.dist = metaExponential
+	for i := 0; i < 100; i++ {
+		if rcv := ast.callDuration(); rcv < 0 {
+			t.Fatalf("Expected a positive duration, received: %s", rcv)
+		}
+	}
+	ast.usage = 0
+	for _, dist := range []string{metaFixed, metaUniform, metaExponential} {
+		ast.dist = dist
+		if rcv := ast.callDuration(); rcv != 0 {
+			t.Errorf("Expected no duration for %s, received: %s", dist, rcv)
+		}
+	}
+}
+
+func TestAgentSimStatsPercentile(t *testing.T) {
+	st := &agentSimStats{latencies: make([]time.Duration, 100)}
+	for i := range st.latencies {
+		st.latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+	for p, exp := range map[int]time.Duration{
+		0:   time.Millisecond,
+		50:  50 * time.Millisecond,
+		95:  95 * time.Millisecond,
+		99:  99 * time.Millisecond,
+		100: 100 * time.Millisecond,
+	} {
+		if rcv := st.percentile(p); rcv != exp {
+			t.Errorf("Expected p%d: %s, received: %s", p, exp, rcv)
+		}
+	}
+	st.latencies = []time.Duration{time.Second}
+	if rcv := st.percentile(99); rcv != time.Second {
+		t.Errorf("Expected: %s, received: %s", time.Second, rcv)
+	}
+}
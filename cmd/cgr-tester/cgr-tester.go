@@ -237,6 +237,16 @@ func main() {
 		}
 		return
 	}
+	if *agentType != "" {
+		ast, err := NewAgentSimTester(tstCfg, *agentType, *runs, *parallel, *cps)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := ast.Test(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	var timeparsed time.Duration
 	var err error
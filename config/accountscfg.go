@@ -18,7 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 // AccountSCfg is the configuration of ActionS
 type AccountSCfg struct {
@@ -26,6 +30,7 @@ type AccountSCfg struct {
 	AttributeSConns     []string
 	RateSConns          []string
 	ThresholdSConns     []string
+	StatSConns          []string
 	IndexedSelects      bool
 	StringIndexedFields *[]string
 	PrefixIndexedFields *[]string
@@ -33,6 +38,9 @@ type AccountSCfg struct {
 	NestedFields        bool
 	MaxIterations       int
 	MaxUsage            *utils.Decimal
+	SweepInterval       time.Duration // check regularly for expired balances
+	ExpiredBalances     string        // action applied on expired balances
+	RefundIDsTTL        time.Duration // how long the refunded CGRIDs are kept on the accounts to reject the duplicated refunds
 }
 
 func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
@@ -75,6 +83,16 @@ func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Stats_conns != nil {
+		acS.StatSConns = make([]string, len(*jsnCfg.Stats_conns))
+		for idx, conn := range *jsnCfg.Stats_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			acS.StatSConns[idx] = conn
+			if conn == utils.MetaInternal {
+				acS.StatSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)
+			}
+		}
+	}
 	if jsnCfg.String_indexed_fields != nil {
 		sif := make([]string, len(*jsnCfg.String_indexed_fields))
 		for i, fID := range *jsnCfg.String_indexed_fields {
@@ -107,16 +125,38 @@ func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Sweep_interval != nil {
+		if acS.SweepInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Sweep_interval); err != nil {
+			return err
+		}
+	}
+	if jsnCfg.Expired_balances != nil {
+		acS.ExpiredBalances = *jsnCfg.Expired_balances
+	}
+	if jsnCfg.Refund_ids_ttl != nil {
+		if acS.RefundIDsTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Refund_ids_ttl); err != nil {
+			return err
+		}
+	}
 	return
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (acS *AccountSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:        acS.Enabled,
-		utils.IndexedSelectsCfg: acS.IndexedSelects,
-		utils.NestedFieldsCfg:   acS.NestedFields,
-		utils.MaxIterations:     acS.MaxIterations,
+		utils.EnabledCfg:         acS.Enabled,
+		utils.IndexedSelectsCfg:  acS.IndexedSelects,
+		utils.NestedFieldsCfg:    acS.NestedFields,
+		utils.MaxIterations:      acS.MaxIterations,
+		utils.SweepIntervalCfg:   utils.EmptyString,
+		utils.ExpiredBalancesCfg: acS.ExpiredBalances,
+		utils.RefundIDsTTLCfg:    utils.EmptyString,
+	}
+	if acS.SweepInterval != 0 {
+		initialMP[utils.SweepIntervalCfg] = acS.SweepInterval.String()
+	}
+	if acS.RefundIDsTTL != 0 {
+		initialMP[utils.RefundIDsTTLCfg] = acS.RefundIDsTTL.String()
 	}
 	if acS.AttributeSConns != nil {
 		attributeSConns := make([]string, len(acS.AttributeSConns))
@@ -148,6 +188,16 @@ func (acS *AccountSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.ThresholdSConnsCfg] = thresholdSConns
 	}
+	if acS.StatSConns != nil {
+		statSConns := make([]string, len(acS.StatSConns))
+		for i, item := range acS.StatSConns {
+			statSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats) {
+				statSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.StatSConnsCfg] = statSConns
+	}
 	if acS.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*acS.StringIndexedFields))
 		for i, item := range *acS.StringIndexedFields {
@@ -178,11 +228,14 @@ func (acS *AccountSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 // Clone returns a deep copy of AccountSCfg
 func (acS AccountSCfg) Clone() (cln *AccountSCfg) {
 	cln = &AccountSCfg{
-		Enabled:        acS.Enabled,
-		IndexedSelects: acS.IndexedSelects,
-		NestedFields:   acS.NestedFields,
-		MaxIterations:  acS.MaxIterations,
-		MaxUsage:       acS.MaxUsage,
+		Enabled:         acS.Enabled,
+		IndexedSelects:  acS.IndexedSelects,
+		NestedFields:    acS.NestedFields,
+		MaxIterations:   acS.MaxIterations,
+		MaxUsage:        acS.MaxUsage,
+		SweepInterval:   acS.SweepInterval,
+		ExpiredBalances: acS.ExpiredBalances,
+		RefundIDsTTL:    acS.RefundIDsTTL,
 	}
 	if acS.AttributeSConns != nil {
 		cln.AttributeSConns = make([]string, len(acS.AttributeSConns))
@@ -202,6 +255,12 @@ func (acS AccountSCfg) Clone() (cln *AccountSCfg) {
 			cln.ThresholdSConns[i] = con
 		}
 	}
+	if acS.StatSConns != nil {
+		cln.StatSConns = make([]string, len(acS.StatSConns))
+		for i, con := range acS.StatSConns {
+			cln.StatSConns[i] = con
+		}
+	}
 	if acS.StringIndexedFields != nil {
 		idx := make([]string, len(*acS.StringIndexedFields))
 		for i, dx := range *acS.StringIndexedFields {
@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -31,6 +32,7 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		Attributes_conns:      &[]string{utils.MetaInternal},
 		Rates_conns:           &[]string{utils.MetaInternal},
 		Thresholds_conns:      &[]string{utils.MetaInternal},
+		Stats_conns:           &[]string{utils.MetaInternal},
 		Indexed_selects:       utils.BoolPointer(false),
 		String_indexed_fields: &[]string{"*req.index1"},
 		Prefix_indexed_fields: &[]string{"*req.index1"},
@@ -38,6 +40,9 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		Nested_fields:         utils.BoolPointer(true),
 		Max_iterations:        utils.IntPointer(1000),
 		Max_usage:             utils.StringPointer("200h"),
+		Sweep_interval:        utils.StringPointer("1h"),
+		Expired_balances:      utils.StringPointer(utils.MetaReset),
+		Refund_ids_ttl:        utils.StringPointer("48h"),
 	}
 	usage, err := utils.NewDecimalFromUsage("200h")
 	if err != nil {
@@ -48,6 +53,7 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
 		RateSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)},
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
+		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
 		IndexedSelects:      false,
 		StringIndexedFields: &[]string{"*req.index1"},
 		PrefixIndexedFields: &[]string{"*req.index1"},
@@ -55,6 +61,9 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		NestedFields:        true,
 		MaxIterations:       1000,
 		MaxUsage:            usage,
+		SweepInterval:       time.Hour,
+		ExpiredBalances:     utils.MetaReset,
+		RefundIDsTTL:        48 * time.Hour,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.accountSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -73,6 +82,19 @@ func TestAccountsCfLoadConfigError(t *testing.T) {
 	if err := actsCfg.loadFromJSONCfg(accountsJson); err == nil || err.Error() != expected {
 		t.Errorf("Expected %+v, received %+v", expected, err)
 	}
+	accountsJson = &AccountSJsonCfg{
+		Sweep_interval: utils.StringPointer("1ss"),
+	}
+	expected = "time: unknown unit \"ss\" in duration \"1ss\""
+	if err := actsCfg.loadFromJSONCfg(accountsJson); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	accountsJson = &AccountSJsonCfg{
+		Refund_ids_ttl: utils.StringPointer("1ss"),
+	}
+	if err := actsCfg.loadFromJSONCfg(accountsJson); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
 }
 
 func TestAccountSCfgAsMapInterface(t *testing.T) {
@@ -83,12 +105,15 @@ func TestAccountSCfgAsMapInterface(t *testing.T) {
 	"attributes_conns": ["*internal:*attributes"],
 	"rates_conns": ["*internal:*rates"],
 	"thresholds_conns": ["*internal:*thresholds"],					
+	"stats_conns": ["*internal:*stats"],
 	"string_indexed_fields": ["*req.index1"],			
 	"prefix_indexed_fields": ["*req.index1"],			
 	"suffix_indexed_fields": ["*req.index1"],			
 	"nested_fields": true,			
     "max_iterations": 100,
     "max_usage": "72h",
+	"sweep_interval": "30m",
+	"refund_ids_ttl": "",
 },	
 }`
 
@@ -98,11 +123,15 @@ func TestAccountSCfgAsMapInterface(t *testing.T) {
 		utils.AttributeSConnsCfg:     []string{utils.MetaInternal},
 		utils.RateSConnsCfg:          []string{utils.MetaInternal},
 		utils.ThresholdSConnsCfg:     []string{utils.MetaInternal},
+		utils.StatSConnsCfg:          []string{utils.MetaInternal},
 		utils.StringIndexedFieldsCfg: []string{"*req.index1"},
 		utils.PrefixIndexedFieldsCfg: []string{"*req.index1"},
 		utils.SuffixIndexedFieldsCfg: []string{"*req.index1"},
 		utils.NestedFieldsCfg:        true,
 		utils.MaxIterations:          100,
+		utils.SweepIntervalCfg:       "30m0s",
+		utils.ExpiredBalancesCfg:     utils.MetaRemove,
+		utils.RefundIDsTTLCfg:        "",
 	}
 	usage, err := utils.NewDecimalFromUsage("72h")
 	if err != nil {
@@ -128,12 +157,16 @@ func TestAccountSCfgClone(t *testing.T) {
 		AttributeSConns:     []string{"*req.index1"},
 		RateSConns:          []string{"*req.index1"},
 		ThresholdSConns:     []string{"*req.index1"},
+		StatSConns:          []string{"*req.index1"},
 		StringIndexedFields: &[]string{"*req.index1"},
 		PrefixIndexedFields: &[]string{"*req.index1", "*req.index2"},
 		SuffixIndexedFields: &[]string{"*req.index1"},
 		NestedFields:        true,
 		MaxIterations:       1000,
 		MaxUsage:            usage,
+		SweepInterval:       time.Hour,
+		ExpiredBalances:     utils.MetaRemove,
+		RefundIDsTTL:        24 * time.Hour,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -148,6 +181,9 @@ func TestAccountSCfgClone(t *testing.T) {
 	if (rcv.ThresholdSConns)[0] = ""; (ban.ThresholdSConns)[0] != "*req.index1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if (rcv.StatSConns)[0] = ""; (ban.StatSConns)[0] != "*req.index1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 	if (*rcv.StringIndexedFields)[0] = ""; (*ban.StringIndexedFields)[0] != "*req.index1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
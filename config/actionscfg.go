@@ -33,6 +33,8 @@ type ActionSCfg struct {
 	PrefixIndexedFields *[]string
 	SuffixIndexedFields *[]string
 	NestedFields        bool
+	ExecutionsHistory   int
+	MaxMissedRuns       int
 }
 
 func (acS *ActionSCfg) loadFromJSONCfg(jsnCfg *ActionSJsonCfg) (err error) {
@@ -116,15 +118,23 @@ func (acS *ActionSCfg) loadFromJSONCfg(jsnCfg *ActionSJsonCfg) (err error) {
 	if jsnCfg.Nested_fields != nil {
 		acS.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Executions_history != nil {
+		acS.ExecutionsHistory = *jsnCfg.Executions_history
+	}
+	if jsnCfg.Max_missed_runs != nil {
+		acS.MaxMissedRuns = *jsnCfg.Max_missed_runs
+	}
 	return
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (acS *ActionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:        acS.Enabled,
-		utils.IndexedSelectsCfg: acS.IndexedSelects,
-		utils.NestedFieldsCfg:   acS.NestedFields,
+		utils.EnabledCfg:           acS.Enabled,
+		utils.IndexedSelectsCfg:    acS.IndexedSelects,
+		utils.NestedFieldsCfg:      acS.NestedFields,
+		utils.ExecutionsHistoryCfg: acS.ExecutionsHistory,
+		utils.MaxMissedRunsCfg:     acS.MaxMissedRuns,
 	}
 	if acS.CDRsConns != nil {
 		CDRsConns := make([]string, len(acS.CDRsConns))
@@ -200,9 +210,11 @@ func (acS *ActionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 // Clone returns a deep copy of ActionSCfg
 func (acS ActionSCfg) Clone() (cln *ActionSCfg) {
 	cln = &ActionSCfg{
-		Enabled:        acS.Enabled,
-		IndexedSelects: acS.IndexedSelects,
-		NestedFields:   acS.NestedFields,
+		Enabled:           acS.Enabled,
+		IndexedSelects:    acS.IndexedSelects,
+		NestedFields:      acS.NestedFields,
+		ExecutionsHistory: acS.ExecutionsHistory,
+		MaxMissedRuns:     acS.MaxMissedRuns,
 	}
 	if acS.CDRsConns != nil {
 		cln.CDRsConns = make([]string, len(acS.CDRsConns))
@@ -38,6 +38,8 @@ func TestActionSCfgLoadFromJSONCfg(t *testing.T) {
 		Prefix_indexed_fields: &[]string{"*req.index1", "*req.index2"},
 		Suffix_indexed_fields: &[]string{"*req.index1"},
 		Nested_fields:         utils.BoolPointer(true),
+		Executions_history:    utils.IntPointer(5),
+		Max_missed_runs:       utils.IntPointer(-1),
 	}
 	expected := &ActionSCfg{
 		Enabled:             true,
@@ -51,6 +53,8 @@ func TestActionSCfgLoadFromJSONCfg(t *testing.T) {
 		PrefixIndexedFields: &[]string{"*req.index1", "*req.index2"},
 		SuffixIndexedFields: &[]string{"*req.index1"},
 		NestedFields:        true,
+		ExecutionsHistory:   5,
+		MaxMissedRuns:       -1,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.actionSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -74,6 +78,8 @@ func TestActionSCfgAsMapInterface(t *testing.T) {
 	"prefix_indexed_fields": ["*req.index1","*req.index2"],		
     "suffix_indexed_fields": ["*req.index1"],
 	"nested_fields": true,						
+	"executions_history": 5,
+	"max_missed_runs": 3,
 	},		
 }`
 
@@ -89,6 +95,8 @@ func TestActionSCfgAsMapInterface(t *testing.T) {
 		utils.PrefixIndexedFieldsCfg: []string{"*req.index1", "*req.index2"},
 		utils.SuffixIndexedFieldsCfg: []string{"*req.index1"},
 		utils.NestedFieldsCfg:        true,
+		utils.ExecutionsHistoryCfg:   5,
+		utils.MaxMissedRunsCfg:       3,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -110,6 +118,8 @@ func TestActionSCfgClone(t *testing.T) {
 		PrefixIndexedFields: &[]string{"*req.index1", "*req.index2"},
 		SuffixIndexedFields: &[]string{"*req.index1"},
 		NestedFields:        true,
+		ExecutionsHistory:   5,
+		MaxMissedRuns:       3,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
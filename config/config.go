@@ -168,6 +168,7 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.sessionSCfg = new(SessionSCfg)
 	cfg.sessionSCfg.STIRCfg = new(STIRcfg)
 	cfg.sessionSCfg.DefaultUsage = make(map[string]time.Duration)
+	cfg.sessionSCfg.QuotaThreshold = make(map[string]float64)
 	cfg.fsAgentCfg = new(FsAgentCfg)
 	cfg.kamAgentCfg = new(KamAgentCfg)
 	cfg.asteriskAgentCfg = new(AsteriskAgentCfg)
@@ -197,6 +198,7 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.rateSCfg = new(RateSCfg)
 	cfg.actionSCfg = new(ActionSCfg)
 	cfg.sipAgentCfg = new(SIPAgentCfg)
+	cfg.smppAgentCfg = new(SMPPAgentCfg)
 	cfg.configSCfg = new(ConfigSCfg)
 	cfg.apiBanCfg = new(APIBanCfg)
 	cfg.coreSCfg = new(CoreSCfg)
@@ -333,6 +335,7 @@ type CGRConfig struct {
 	rateSCfg         *RateSCfg         // RateS config
 	actionSCfg       *ActionSCfg       // ActionS config
 	sipAgentCfg      *SIPAgentCfg      // SIPAgent config
+	smppAgentCfg     *SMPPAgentCfg     // SMPPAgent config
 	configSCfg       *ConfigSCfg       // ConfigS config
 	apiBanCfg        *APIBanCfg        // APIBan config
 	coreSCfg         *CoreSCfg         // CoreS config
@@ -349,11 +352,11 @@ var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
-	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaFileJSON, utils.MetaNone})
+	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaFileJSON, utils.MetaNatsjsonMap, utils.MetaNone})
 
 var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaFileCSV, utils.MetaNone, utils.MetaFileFWV,
 	utils.MetaHTTPPost, utils.MetaHTTPjsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
-	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL})
+	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaNatsjsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL})
 
 // LazySanityCheck used after check config sanity to display warnings related to the config
 func (cfg *CGRConfig) LazySanityCheck() {
@@ -406,7 +409,7 @@ func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadMailerCfg, cfg.loadSureTaxCfg, cfg.loadDispatcherSCfg,
 		cfg.loadLoaderCgrCfg, cfg.loadMigratorCgrCfg, cfg.loadTLSCgrCfg,
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
-		cfg.loadRateSCfg, cfg.loadSIPAgentCfg, cfg.loadDispatcherHCfg,
+		cfg.loadRateSCfg, cfg.loadSIPAgentCfg, cfg.loadSMPPAgentCfg, cfg.loadDispatcherHCfg,
 		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadCoreSCfg, cfg.loadActionSCfg,
 		cfg.loadAccountSCfg} {
 		if err = loadFunc(jsnCfg); err != nil {
@@ -818,6 +821,15 @@ func (cfg *CGRConfig) loadSIPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.sipAgentCfg.loadFromJSONCfg(jsnSIPAgentCfg, cfg.generalCfg.RSRSep)
 }
 
+// loadSMPPAgentCfg loads the smpp_agent section of the configuration
+func (cfg *CGRConfig) loadSMPPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnSMPPAgentCfg *SMPPAgentJsonCfg
+	if jsnSMPPAgentCfg, err = jsnCfg.SMPPAgentJsonCfg(); err != nil {
+		return
+	}
+	return cfg.smppAgentCfg.loadFromJSONCfg(jsnSMPPAgentCfg, cfg.generalCfg.RSRSep)
+}
+
 // loadTemplateSCfg loads the Template section of the configuration
 func (cfg *CGRConfig) loadTemplateSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnTemplateCfg map[string][]*FcTemplateJsonCfg
@@ -1145,6 +1157,13 @@ func (cfg *CGRConfig) SIPAgentCfg() *SIPAgentCfg {
 	return cfg.sipAgentCfg
 }
 
+// SMPPAgentCfg reads the SMPPAgent configuration
+func (cfg *CGRConfig) SMPPAgentCfg() *SMPPAgentCfg {
+	cfg.lks[SMPPAgentJson].Lock()
+	defer cfg.lks[SMPPAgentJson].Unlock()
+	return cfg.smppAgentCfg
+}
+
 // RPCConns reads the RPCConns configuration
 func (cfg *CGRConfig) RPCConns() RPCConns {
 	cfg.lks[RPCConnsJsonName].RLock()
@@ -1272,6 +1291,7 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		RPCConnsJsonName:   cfg.loadRPCConns,
 		RateSJson:          cfg.loadRateSCfg,
 		SIPAgentJson:       cfg.loadSIPAgentCfg,
+		SMPPAgentJson:      cfg.loadSMPPAgentCfg,
 		TemplatesJson:      cfg.loadTemplateSCfg,
 		ConfigSJson:        cfg.loadConfigSCfg,
 		APIBanCfgJson:      cfg.loadAPIBanCgrCfg,
@@ -1527,6 +1547,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[EEsJson] <- struct{}{}
 		case SIPAgentJson:
 			cfg.rldChans[SIPAgentJson] <- struct{}{}
+		case SMPPAgentJson:
+			cfg.rldChans[SMPPAgentJson] <- struct{}{}
 		case RateSJson:
 			cfg.rldChans[RateSJson] <- struct{}{}
 		case DispatcherHJson:
@@ -1583,6 +1605,7 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		EEsJson:            cfg.eesCfg.AsMapInterface(separator),
 		RateSJson:          cfg.rateSCfg.AsMapInterface(),
 		SIPAgentJson:       cfg.sipAgentCfg.AsMapInterface(separator),
+		SMPPAgentJson:      cfg.smppAgentCfg.AsMapInterface(separator),
 		TemplatesJson:      cfg.templates.AsMapInterface(separator),
 		ConfigSJson:        cfg.configSCfg.AsMapInterface(),
 		CoreSCfgJson:       cfg.coreSCfg.AsMapInterface(),
@@ -1734,6 +1757,8 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithOpts, reply *map[string]inter
 		mp = cfg.RPCConns().AsMapInterface()
 	case SIPAgentJson:
 		mp = cfg.SIPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case SMPPAgentJson:
+		mp = cfg.SMPPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case TemplatesJson:
 		mp = cfg.TemplatesCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ConfigSJson:
@@ -1902,6 +1927,8 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithOpts, reply *string) (e
 		mp = cfg.ERsCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case SIPAgentJson:
 		mp = cfg.SIPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case SMPPAgentJson:
+		mp = cfg.SMPPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ConfigSJson:
 		mp = cfg.ConfigSCfg().AsMapInterface()
 	case APIBanCfgJson:
@@ -2016,6 +2043,7 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		eesCfg:           cfg.eesCfg.Clone(),
 		rateSCfg:         cfg.rateSCfg.Clone(),
 		sipAgentCfg:      cfg.sipAgentCfg.Clone(),
+		smppAgentCfg:     cfg.smppAgentCfg.Clone(),
 		configSCfg:       cfg.configSCfg.Clone(),
 		apiBanCfg:        cfg.apiBanCfg.Clone(),
 		coreSCfg:         cfg.coreSCfg.Clone(),
@@ -256,6 +256,11 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},	// control dispatcher profile caching
 		"*dispatcher_hosts": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control dispatcher hosts caching
 		"*rate_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// control rate profile caching
+		"*currency_conversions": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},						// control currency conversions caching
+		"*rate_volume_counters": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},						// control rate volume counters caching
+		"*action_schedules": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control action schedules caching
+		"*statqueue_histories": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},						// control statqueue window histories caching
+		"*stored_sessions": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control stored sessions caching
 		"*action_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control action profile caching
 		"*account_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control account profile caching
 		"*resource_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 				// control resource filter indexes caching
@@ -274,6 +279,7 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_loads": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control dispatcher load( in case of *ratio ConnParams is present)
 		"*dispatchers": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 								// control dispatcher interface
 		"*diameter_messages": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},						// diameter messages caching
+		"*radius_packets": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},							// radius packets caching
 		"*rpc_responses": {"limit": 0, "ttl": "2s", "static_ttl": false, "replicate": false},							// RPC responses caching
 		"*closed_sessions": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},						// closed sessions cached for CDRs
 		"*event_charges": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},							// events proccessed by ChargerS
@@ -446,12 +452,14 @@ const CGRATES_CFG_JSON = `
 	"replication_conns": [],				// replicate sessions towards these session services
 	"debit_interval": "0s",					// interval to perform debits on.
 	"store_session_costs": false,			// enable storing of the session costs within CDRs
+	"store_sessions": false,				// checkpoint the active sessions into DataDB and recover them on start
 	"default_usage":{						// the usage if the event is missing the usage field
 			"*any": "3h",
 			"*voice": "3h",
 			"*data": "1048576",
 			"*sms": "1",
 	},
+	"quota_threshold": {},					// ratio of the granted usage consumed when the client should request more units, per ToR (ie: {"*data": 0.8})
 	"session_ttl": "0s",					// time after a session with no updates is terminated, not defined by default
 	//"session_ttl_max_delay": "",			// activates session_ttl randomization and limits the maximum possible delay
 	//"session_ttl_last_used": "",			// tweak LastUsed for sessions timing-out, not defined by default
@@ -542,7 +550,10 @@ const CGRATES_CFG_JSON = `
 	"client_dictionaries": {									// per client path towards directory holding additional dictionaries to load (extra to RFC)
 		"*default": "/usr/share/cgrates/radius/dict/",			// key represents the client IP or catch-all <*default|$client_ip>
 	},
+	"client_da_addresses": {},									// dynamic authorization server address for clients, defaults to $client_ip:3799 <$client_ip: $host:$port>
 	"sessions_conns": ["*internal"],
+	"dmr_template": "",											// enable Disconnect-Request being sent to client on DisconnectSession
+	"coa_template": "",											// enable CoA-Request being sent to client on AlterSession
 	"request_processors": [										// request processors to be applied to Radius messages
 	],
 },
@@ -616,6 +627,7 @@ const CGRATES_CFG_JSON = `
 "thresholds": {								// ThresholdS
 	"enabled": false,						// starts ThresholdS service: <true|false>.
 	"store_interval": "",					// dump cache regularly to dataDB, 0 - dump at start/shutdown: <""|$dur>
+	"ees_conns": [],						// connections to EEs for threshold state transitions: <""|*internal|$rpc_conns_id>
 	"indexed_selects": true,				// enable profile matching exclusively on indexes
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
@@ -696,6 +708,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~*req.8"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.9"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.10"},
+					{"tag": "RateWindow", "path": "RateWindow", "type": "*variable", "value": "~*req.11"},
 				],
 			},
 			{
@@ -715,6 +728,11 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~*req.10"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.11"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.12"},
+					{"tag": "GroupBy", "path": "GroupBy", "type": "*variable", "value": "~*req.13"},
+					{"tag": "GroupTTL", "path": "GroupTTL", "type": "*variable", "value": "~*req.14"},
+					{"tag": "WindowType", "path": "WindowType", "type": "*variable", "value": "~*req.15"},
+					{"tag": "WindowLength", "path": "WindowLength", "type": "*variable", "value": "~*req.16"},
+					{"tag": "WindowHistory", "path": "WindowHistory", "type": "*variable", "value": "~*req.17"},
 				],
 			},
 			{
@@ -732,6 +750,8 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.8"},
 					{"tag": "ActionIDs", "path": "ActionIDs", "type": "*variable", "value": "~*req.9"},
 					{"tag": "Async", "path": "Async", "type": "*variable", "value": "~*req.10"},
+					{"tag": "RecoveryFilterIDs", "path": "RecoveryFilterIDs", "type": "*variable", "value": "~*req.11"},
+					{"tag": "RecoveryActionIDs", "path": "RecoveryActionIDs", "type": "*variable", "value": "~*req.12"},
 				],
 			},
 			{
@@ -754,6 +774,8 @@ const CGRATES_CFG_JSON = `
 					{"tag": "RouteBlocker", "path": "RouteBlocker", "type": "*variable", "value": "~*req.13"},
 					{"tag": "RouteParameters", "path": "RouteParameters", "type": "*variable", "value": "~*req.14"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.15"},
+					{"tag": "BreakerFailures", "path": "BreakerFailures", "type": "*variable", "value": "~*req.16"},
+					{"tag": "BreakerCoolDown", "path": "BreakerCoolDown", "type": "*variable", "value": "~*req.17"},
 				],
 			},
 			{
@@ -821,6 +843,9 @@ const CGRATES_CFG_JSON = `
                     {"tag": "RateRecurrentFee", "path": "RateRecurrentFee", "type": "*variable", "value": "~*req.15"},
 					{"tag": "RateUnit", "path": "RateUnit", "type": "*variable", "value": "~*req.16"},
 					{"tag": "RateIncrement", "path": "RateIncrement", "type": "*variable", "value": "~*req.17"},
+					{"tag": "Currency", "path": "Currency", "type": "*variable", "value": "~*req.18"},
+					{"tag": "VolumeCounter", "path": "VolumeCounter", "type": "*variable", "value": "~*req.19"},
+					{"tag": "VolumeReset", "path": "VolumeReset", "type": "*variable", "value": "~*req.20"},
 				],
 			},
 			{
@@ -843,6 +868,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "ActionOpts", "path": "ActionOpts", "type": "*variable", "value": "~*req.13"},
 					{"tag": "ActionPath", "path": "ActionPath", "type": "*variable", "value": "~*req.14"},
 					{"tag": "ActionValue", "path": "ActionValue", "type": "*variable", "value": "~*req.15"},
+					{"tag": "MissedRuns", "path": "MissedRuns", "type": "*variable", "value": "~*req.16"},
 				],
 			},
 			{
@@ -866,6 +892,17 @@ const CGRATES_CFG_JSON = `
 					{"tag": "BalanceUnitFactors", "path": "BalanceUnitFactors", "type": "*variable", "value": "~*req.14"},
 					{"tag": "BalanceUnits", "path": "BalanceUnits", "type": "*variable", "value": "~*req.15"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.16"},
+					{"tag": "BalanceActivationInterval", "path": "BalanceActivationInterval", "type": "*variable", "value": "~*req.17"},
+				],
+			},
+			{
+				"type": "*currency_conversions",					// data source type
+				"file_name": "CurrencyConversions.csv",			// file name in the tp_in_dir
+				"fields": [
+					{"tag": "Tenant", "path": "Tenant", "type": "*variable", "value": "~*req.0", "mandatory": true},
+					{"tag": "FromCurrency", "path": "FromCurrency", "type": "*variable", "value": "~*req.1", "mandatory": true},
+					{"tag": "ToCurrency", "path": "ToCurrency", "type": "*variable", "value": "~*req.2", "mandatory": true},
+					{"tag": "ExchangeRate", "path": "ExchangeRate", "type": "*variable", "value": "~*req.3", "mandatory": true},
 				],
 			},
 		],
@@ -1002,6 +1039,7 @@ const CGRATES_CFG_JSON = `
 	"rate_suffix_indexed_fields": [],		// query indexes based on these fields for faster processing
 	"rate_nested_fields": false,			// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
     "verbosity": 1000,                      // number of increment iterations allowed
+	"cdrs_conns": [],						// connections to CDRs for cost simulations over stored CDRs <""|*internal|$rpc_conns_id>
 },
 
 
@@ -1017,6 +1055,18 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"smpp_agent": {						// SMPP Agent, SMSC-facing server for SMS charging and routing
+	"enabled": false,					// enables the SMPP agent: <true|false>
+	"listen": "127.0.0.1:2775",			// address where to listen for SMPP binds <x.y.z.y:1234>
+	"system_id": "CGRateS",				// system_id sent back in the bind responses
+	"bind_credentials": {},				// password per system_id allowed to bind, empty to accept any bind
+	"sessions_conns": ["*internal"],
+	"timezone": "",						// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
+	"request_processors": [				// request processors to be applied to submit_sm/deliver_sm PDUs
+	],
+},
+
+
 "templates": {
 	"*err": [
 			{"tag": "SessionId", "path": "*rep.Session-Id", "type": "*variable",
@@ -1132,6 +1182,8 @@ const CGRATES_CFG_JSON = `
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
+	"executions_history": 10,				// number of past executions kept for each scheduled ActionProfile
+	"max_missed_runs": 10,					// maximum number of missed runs executed for the *run_all policy, the oldest are skipped <-1 for unlimited>
 },
 
 
@@ -1141,12 +1193,16 @@ const CGRATES_CFG_JSON = `
 	"attributes_conns": [],					// connections to AttributeS for account/balance updates, empty to disable attributes functionality: <""|*internal|$rpc_conns_id>
 	"rates_conns": [],						// connections to RatesS for account/balance updates, empty to disable rates functionality: <""|*internal|$rpc_conns_id>
 	"thresholds_conns": [],					// connections to ThresholdS for account/balance updates, empty to disable thresholds functionality: <""|*internal|$rpc_conns_id>
+	"stats_conns": [],						// connections to StatS for account/balance updates, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
     "max_iterations": 1000,                 // maximum number of iterations
     "max_usage": "72h",                     // maximum time of usage
+	"sweep_interval": "",					// interval to check for expired balances, empty to disable: <""|$dur>
+	"expired_balances": "*remove",			// action on the expired balances found by the sweeper: <*remove|*reset>
+	"refund_ids_ttl": "24h",				// how long the refunded CGRIDs are kept on the accounts to reject duplicated refunds, empty to keep them forever: <""|$dur>
 },
 
 
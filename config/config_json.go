@@ -63,6 +63,7 @@ const (
 	ActionSJson        = "actions"
 	RPCConnsJsonName   = "rpc_conns"
 	SIPAgentJson       = "sip_agent"
+	SMPPAgentJson      = "smpp_agent"
 	TemplatesJson      = "templates"
 	ConfigSJson        = "configs"
 	APIBanCfgJson      = "apiban"
@@ -75,7 +76,7 @@ var (
 		CACHE_JSN, FilterSjsn, RALS_JSN, CDRS_JSN, ERsJson, SessionSJson, AsteriskAgentJSN, FreeSWITCHAgentJSN,
 		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
 		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
-		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson, DispatcherHJson, TemplatesJson, ConfigSJson, APIBanCfgJson, CoreSCfgJson,
+		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson, SMPPAgentJson, DispatcherHJson, TemplatesJson, ConfigSJson, APIBanCfgJson, CoreSCfgJson,
 		ActionSJson, AccountSCfgJson}
 )
 
@@ -539,6 +540,18 @@ func (self CgrJsonCfg) SIPAgentJsonCfg() (*SIPAgentJsonCfg, error) {
 	return sipAgnt, nil
 }
 
+func (self CgrJsonCfg) SMPPAgentJsonCfg() (*SMPPAgentJsonCfg, error) {
+	rawCfg, hasKey := self[SMPPAgentJson]
+	if !hasKey {
+		return nil, nil
+	}
+	smppAgnt := new(SMPPAgentJsonCfg)
+	if err := json.Unmarshal(*rawCfg, smppAgnt); err != nil {
+		return nil, err
+	}
+	return smppAgnt, nil
+}
+
 func (self CgrJsonCfg) TemplateSJsonCfg() (map[string][]*FcTemplateJsonCfg, error) {
 	rawCfg, hasKey := self[TemplatesJson]
 	if !hasKey {
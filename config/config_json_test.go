@@ -154,6 +154,21 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheRateProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheCurrencyConversions: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheRateVolumeCounters: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheActionSchedules: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheStatQueueHistories: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheStoredSessions: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheActionProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -211,6 +226,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheDiameterMessages: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheRadiusPackets: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheRPCResponses: {Limit: utils.IntPointer(0),
 				Ttl: utils.StringPointer("2s"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -736,6 +754,7 @@ func TestSmgJsonCfg(t *testing.T) {
 		Replication_conns:     &[]string{},
 		Debit_interval:        utils.StringPointer("0s"),
 		Store_session_costs:   utils.BoolPointer(false),
+		Store_sessions:        utils.BoolPointer(false),
 		Session_ttl:           utils.StringPointer("0s"),
 		Session_indexes:       &[]string{},
 		Client_protocol:       utils.Float64Pointer(1.0),
@@ -748,6 +767,7 @@ func TestSmgJsonCfg(t *testing.T) {
 			utils.MetaData:  "1048576",
 			utils.MetaSMS:   "1",
 		},
+		Quota_threshold: &map[string]float64{},
 		Stir: &STIRJsonCfg{
 			Allowed_attest:      &[]string{utils.MetaAny},
 			Payload_maxduration: utils.StringPointer("-1"),
@@ -890,8 +910,11 @@ func TestRadiusAgentJsonCfg(t *testing.T) {
 		Client_dictionaries: utils.MapStringStringPointer(map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		}),
-		Sessions_conns:     &[]string{utils.MetaInternal},
-		Request_processors: &[]*ReqProcessorJsnCfg{},
+		Client_da_addresses: utils.MapStringStringPointer(map[string]string{}),
+		Sessions_conns:      &[]string{utils.MetaInternal},
+		Dmr_template:        utils.StringPointer(""),
+		Coa_template:        utils.StringPointer(""),
+		Request_processors:  &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1050,6 +1073,7 @@ func TestDfThresholdSJsonCfg(t *testing.T) {
 		Enabled:               utils.BoolPointer(false),
 		Indexed_selects:       utils.BoolPointer(true),
 		Store_interval:        utils.StringPointer(""),
+		Ees_conns:             &[]string{},
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
 		Suffix_indexed_fields: &[]string{},
@@ -1239,6 +1263,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.10")},
+						{Tag: utils.StringPointer("RateWindow"),
+							Path:  utils.StringPointer("RateWindow"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.11")},
 					},
 				},
 				{
@@ -1300,6 +1328,26 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.12")},
+						{Tag: utils.StringPointer("GroupBy"),
+							Path:  utils.StringPointer("GroupBy"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.13")},
+						{Tag: utils.StringPointer("GroupTTL"),
+							Path:  utils.StringPointer("GroupTTL"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.14")},
+						{Tag: utils.StringPointer("WindowType"),
+							Path:  utils.StringPointer("WindowType"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.15")},
+						{Tag: utils.StringPointer("WindowLength"),
+							Path:  utils.StringPointer("WindowLength"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.16")},
+						{Tag: utils.StringPointer("WindowHistory"),
+							Path:  utils.StringPointer("WindowHistory"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.17")},
 					},
 				},
 				{
@@ -1352,6 +1400,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Async"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.10")},
+						{Tag: utils.StringPointer("RecoveryFilterIDs"),
+							Path:  utils.StringPointer("RecoveryFilterIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.11")},
+						{Tag: utils.StringPointer("RecoveryActionIDs"),
+							Path:  utils.StringPointer("RecoveryActionIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.12")},
 					},
 				},
 				{
@@ -1424,6 +1480,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Weight"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.15")},
+						{Tag: utils.StringPointer("BreakerFailures"),
+							Path:  utils.StringPointer("BreakerFailures"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.16")},
+						{Tag: utils.StringPointer("BreakerCoolDown"),
+							Path:  utils.StringPointer("BreakerCoolDown"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.17")},
 					},
 				},
 				{
@@ -1628,6 +1692,18 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("RateIncrement"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.17")},
+						{Tag: utils.StringPointer("Currency"),
+							Path:  utils.StringPointer("Currency"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.18")},
+						{Tag: utils.StringPointer("VolumeCounter"),
+							Path:  utils.StringPointer("VolumeCounter"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.19")},
+						{Tag: utils.StringPointer("VolumeReset"),
+							Path:  utils.StringPointer("VolumeReset"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.20")},
 					},
 				},
 				{
@@ -1700,6 +1776,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ActionValue"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.15")},
+						{Tag: utils.StringPointer("MissedRuns"),
+							Path:  utils.StringPointer("MissedRuns"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.16")},
 					},
 				},
 				{
@@ -1776,6 +1856,36 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.16")},
+						{Tag: utils.StringPointer("BalanceActivationInterval"),
+							Path:  utils.StringPointer("BalanceActivationInterval"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.17")},
+					},
+				},
+				{
+					Type:      utils.StringPointer(utils.MetaCurrencyConversions),
+					File_name: utils.StringPointer(utils.CurrencyConversionsCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer(utils.Tenant),
+							Path:      utils.StringPointer(utils.Tenant),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.FromCurrency),
+							Path:      utils.StringPointer(utils.FromCurrency),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.1"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.ToCurrency),
+							Path:      utils.StringPointer(utils.ToCurrency),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.2"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.ExchangeRate),
+							Path:      utils.StringPointer(utils.ExchangeRate),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.3"),
+							Mandatory: utils.BoolPointer(true)},
 					},
 				},
 			},
@@ -2148,6 +2258,7 @@ func TestDfRateSJsonCfg(t *testing.T) {
 		Rate_suffix_indexed_fields: &[]string{},
 		Rate_nested_fields:         utils.BoolPointer(false),
 		Verbosity:                  utils.IntPointer(1000),
+		Cdrs_conns:                 &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -2433,6 +2544,8 @@ func TestDfActionSJsonCfg(t *testing.T) {
 		Prefix_indexed_fields: &[]string{},
 		Suffix_indexed_fields: &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
+		Executions_history:    utils.IntPointer(10),
+		Max_missed_runs:       utils.IntPointer(10),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -429,6 +429,34 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		}
 	}
 
+	//SMPP Agent
+	if cfg.smppAgentCfg.Enabled {
+		if len(cfg.smppAgentCfg.SessionSConns) == 0 {
+			return fmt.Errorf("<%s> no %s connections defined",
+				utils.SMPPAgent, utils.SessionS)
+		}
+		for _, connID := range cfg.smppAgentCfg.SessionSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.SMPPAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SMPPAgent, connID)
+			}
+		}
+		for _, req := range cfg.smppAgentCfg.RequestProcessors {
+			for _, field := range req.RequestFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+			}
+			for _, field := range req.ReplyFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+			}
+		}
+	}
+
 	if cfg.attributeSCfg.Enabled {
 		if cfg.attributeSCfg.ProcessRuns < 1 {
 			return fmt.Errorf("<%s> process_runs needs to be bigger than 0", utils.AttributeS)
@@ -466,6 +494,17 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// ThresholdS checks
+	if cfg.thresholdSCfg.Enabled {
+		for _, connID := range cfg.thresholdSCfg.EEsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.eesCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.EEs, utils.ThresholdS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.ThresholdS, connID)
+			}
+		}
+	}
 	// RouteS checks
 	if cfg.routeSCfg.Enabled {
 		for _, connID := range cfg.routeSCfg.AttributeSConns {
@@ -751,6 +790,39 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// AccountS sanity check
+	if cfg.accountSCfg.Enabled {
+		if !utils.SliceHasMember([]string{utils.MetaRemove, utils.MetaReset}, cfg.accountSCfg.ExpiredBalances) {
+			return fmt.Errorf("<%s> unsupported expired_balances action: <%s>", utils.AccountS, cfg.accountSCfg.ExpiredBalances)
+		}
+		for _, connID := range cfg.accountSCfg.ThresholdSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.thresholdSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ThresholdS, utils.AccountS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.AccountS, connID)
+			}
+		}
+		for _, connID := range cfg.accountSCfg.StatSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.statsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.StatS, utils.AccountS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.AccountS, connID)
+			}
+		}
+	}
+	// RateS sanity check
+	if cfg.rateSCfg.Enabled {
+		for _, connID := range cfg.rateSCfg.CDRsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.cdrsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.CDRs, utils.RateS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RateS, connID)
+			}
+		}
+	}
 	if cfg.analyzerSCfg.Enabled {
 		if _, err := os.Stat(cfg.analyzerSCfg.DBPath); err != nil && os.IsNotExist(err) {
 			return fmt.Errorf("<%s> nonexistent DB folder: %q", utils.AnalyzerS, cfg.analyzerSCfg.DBPath)
@@ -694,6 +694,55 @@ func TestConfigSanitySipAgent(t *testing.T) {
 	}
 }
 
+func TestConfigSanitySMPPAgent(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+
+	cfg.smppAgentCfg = &SMPPAgentCfg{
+		Enabled: true,
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:       "cgrates",
+				Timezone: "Local",
+				RequestFields: []*FCTemplate{
+					{Tag: "SessionId", Path: utils.EmptyString, Type: "*variable",
+						Value: NewRSRParsersMustCompile("~*req.Session-Id", utils.InfieldSep), Mandatory: true},
+				},
+				ReplyFields: []*FCTemplate{
+					{Tag: "SessionId", Path: utils.EmptyString, Type: "*variable",
+						Value: NewRSRParsersMustCompile("~*req.Session-Id", utils.InfieldSep), Mandatory: true},
+				},
+			},
+		},
+	}
+
+	expected := "<SMPPAgent> no SessionS connections defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
+	expected = "<SessionS> not enabled but requested by <SMPPAgent> component"
+	cfg.smppAgentCfg.SessionSConns = []string{utils.MetaInternal}
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	expected = "<SMPPAgent> connection with id: <test> not defined"
+	cfg.smppAgentCfg.SessionSConns = []string{"test"}
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
+	cfg.rpcConns["test"] = nil
+	expected = "<SMPPAgent> MANDATORY_IE_MISSING: [Path] for cgrates at SessionId"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.smppAgentCfg.RequestProcessors[0].RequestFields[0].Type = utils.MetaNone
+	expected = "<SMPPAgent> MANDATORY_IE_MISSING: [Path] for cgrates at SessionId"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
 func TestConfigSanityAttributesCfg(t *testing.T) {
 	cfg := NewDefaultCGRConfig()
 
@@ -759,6 +808,23 @@ func TestConfigSanityStatS(t *testing.T) {
 	}
 }
 
+func TestConfigSanityThresholdS(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.thresholdSCfg = &ThresholdSCfg{
+		Enabled:  true,
+		EEsConns: []string{utils.MetaInternal},
+	}
+	expected := "<EEs> not enabled but requested by <ThresholdS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.thresholdSCfg.EEsConns = []string{"test"}
+	expected = "<ThresholdS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
 func TestConfigSanityRouteS(t *testing.T) {
 	cfg = NewDefaultCGRConfig()
 	cfg.routeSCfg.Enabled = true
@@ -1320,6 +1386,61 @@ func TestConfigSanityFilterS(t *testing.T) {
 	}
 }
 
+func TestConfigSanityAccountS(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.accountSCfg.Enabled = true
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+	cfg.accountSCfg.ExpiredBalances = "*unsupported"
+	expected := "<AccountS> unsupported expired_balances action: <*unsupported>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.accountSCfg.ExpiredBalances = utils.MetaRemove
+
+	cfg.accountSCfg.ThresholdSConns = []string{utils.MetaInternal}
+	expected = "<ThresholdS> not enabled but requested by <AccountS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.accountSCfg.ThresholdSConns = []string{"test"}
+	expected = "<AccountS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.accountSCfg.ThresholdSConns = []string{}
+
+	cfg.accountSCfg.StatSConns = []string{utils.MetaInternal}
+	expected = "<Stats> not enabled but requested by <AccountS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.accountSCfg.StatSConns = []string{"test"}
+	expected = "<AccountS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
+func TestConfigSanityRateS(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.rateSCfg.Enabled = true
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+	cfg.rateSCfg.CDRsConns = []string{utils.MetaInternal}
+	expected := "<CDRs> not enabled but requested by <RateS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.rateSCfg.CDRsConns = []string{"test"}
+	expected = "<RateS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
 func TestCheckConfigSanity(t *testing.T) {
 	// Rater checks
 	cfg := NewDefaultCGRConfig()
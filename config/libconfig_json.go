@@ -224,6 +224,7 @@ type SessionSJsonCfg struct {
 	Attributes_conns       *[]string
 	Debit_interval         *string
 	Store_session_costs    *bool
+	Store_sessions         *bool
 	Session_ttl            *string
 	Session_ttl_max_delay  *string
 	Session_ttl_last_used  *string
@@ -238,6 +239,7 @@ type SessionSJsonCfg struct {
 	Scheduler_conns        *[]string
 	Stir                   *STIRJsonCfg
 	Default_usage          *map[string]string
+	Quota_threshold        *map[string]float64
 }
 
 // FreeSWITCHAgent config section
@@ -354,7 +356,10 @@ type RadiusAgentJsonCfg struct {
 	Listen_acct         *string
 	Client_secrets      *map[string]string
 	Client_dictionaries *map[string]string
+	Client_da_addresses *map[string]string
 	Sessions_conns      *[]string
+	Dmr_template        *string
+	Coa_template        *string
 	Timezone            *string
 	Request_processors  *[]*ReqProcessorJsnCfg
 }
@@ -444,6 +449,7 @@ type ThresholdSJsonCfg struct {
 	Enabled               *bool
 	Indexed_selects       *bool
 	Store_interval        *string
+	Ees_conns             *[]string
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
 	Suffix_indexed_fields *[]string
@@ -638,6 +644,7 @@ type RateSJsonCfg struct {
 	Rate_suffix_indexed_fields *[]string
 	Rate_nested_fields         *bool // applies when indexed fields is not defined
 	Verbosity                  *int
+	Cdrs_conns                 *[]string
 }
 
 // SIPAgentJsonCfg
@@ -651,6 +658,17 @@ type SIPAgentJsonCfg struct {
 	Request_processors   *[]*ReqProcessorJsnCfg
 }
 
+// SMPPAgentJsonCfg
+type SMPPAgentJsonCfg struct {
+	Enabled            *bool
+	Listen             *string
+	System_id          *string
+	Bind_credentials   *map[string]string
+	Sessions_conns     *[]string
+	Timezone           *string
+	Request_processors *[]*ReqProcessorJsnCfg
+}
+
 type ConfigSCfgJson struct {
 	Enabled  *bool
 	Url      *string
@@ -682,6 +700,8 @@ type ActionSJsonCfg struct {
 	Prefix_indexed_fields *[]string
 	Suffix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
+	Executions_history    *int
+	Max_missed_runs       *int
 }
 
 // Account service config section
@@ -691,10 +711,14 @@ type AccountSJsonCfg struct {
 	Attributes_conns      *[]string
 	Rates_conns           *[]string
 	Thresholds_conns      *[]string
+	Stats_conns           *[]string
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
 	Suffix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Max_iterations        *int
 	Max_usage             *string
+	Sweep_interval        *string
+	Expired_balances      *string
+	Refund_ids_ttl        *string
 }
@@ -20,6 +20,7 @@ package config
 
 import (
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 // RadiusAgentCfg the config section that describes the Radius Agent
@@ -30,7 +31,10 @@ type RadiusAgentCfg struct {
 	ListenAcct         string
 	ClientSecrets      map[string]string
 	ClientDictionaries map[string]string
+	ClientDAAddresses  map[string]string // address of the dynamic authorization server per client
 	SessionSConns      []string
+	DMRTemplate        string
+	CoATemplate        string
 	RequestProcessors  []*RequestProcessor
 }
 
@@ -66,16 +70,31 @@ func (ra *RadiusAgentCfg) loadFromJSONCfg(jsnCfg *RadiusAgentJsonCfg, separator
 			ra.ClientDictionaries[k] = v
 		}
 	}
+	if jsnCfg.Client_da_addresses != nil {
+		if ra.ClientDAAddresses == nil {
+			ra.ClientDAAddresses = make(map[string]string)
+		}
+		for k, v := range *jsnCfg.Client_da_addresses {
+			ra.ClientDAAddresses[k] = v
+		}
+	}
 	if jsnCfg.Sessions_conns != nil {
 		ra.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
 		for idx, attrConn := range *jsnCfg.Sessions_conns {
 			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
 			ra.SessionSConns[idx] = attrConn
-			if attrConn == utils.MetaInternal {
-				ra.SessionSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+			if attrConn == utils.MetaInternal ||
+				attrConn == rpcclient.BiRPCInternal {
+				ra.SessionSConns[idx] = utils.ConcatenatedKey(attrConn, utils.MetaSessionS)
 			}
 		}
 	}
+	if jsnCfg.Dmr_template != nil {
+		ra.DMRTemplate = *jsnCfg.Dmr_template
+	}
+	if jsnCfg.Coa_template != nil {
+		ra.CoATemplate = *jsnCfg.Coa_template
+	}
 	if jsnCfg.Request_processors != nil {
 		for _, reqProcJsn := range *jsnCfg.Request_processors {
 			rp := new(RequestProcessor)
@@ -101,10 +120,12 @@ func (ra *RadiusAgentCfg) loadFromJSONCfg(jsnCfg *RadiusAgentJsonCfg, separator
 // AsMapInterface returns the config as a map[string]interface{}
 func (ra *RadiusAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:    ra.Enabled,
-		utils.ListenNetCfg:  ra.ListenNet,
-		utils.ListenAuthCfg: ra.ListenAuth,
-		utils.ListenAcctCfg: ra.ListenAcct,
+		utils.EnabledCfg:     ra.Enabled,
+		utils.ListenNetCfg:   ra.ListenNet,
+		utils.ListenAuthCfg:  ra.ListenAuth,
+		utils.ListenAcctCfg:  ra.ListenAcct,
+		utils.DMRTemplateCfg: ra.DMRTemplate,
+		utils.CoATemplateCfg: ra.CoATemplate,
 	}
 
 	requestProcessors := make([]map[string]interface{}, len(ra.RequestProcessors))
@@ -119,6 +140,8 @@ func (ra *RadiusAgentCfg) AsMapInterface(separator string) (initialMP map[string
 			sessionSConns[i] = item
 			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
 				sessionSConns[i] = utils.MetaInternal
+			} else if item == utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS) {
+				sessionSConns[i] = rpcclient.BiRPCInternal
 			}
 		}
 		initialMP[utils.SessionSConnsCfg] = sessionSConns
@@ -133,6 +156,11 @@ func (ra *RadiusAgentCfg) AsMapInterface(separator string) (initialMP map[string
 		clientDictionaries[k] = v
 	}
 	initialMP[utils.ClientDictionariesCfg] = clientDictionaries
+	clientDAAddresses := make(map[string]string)
+	for k, v := range ra.ClientDAAddresses {
+		clientDAAddresses[k] = v
+	}
+	initialMP[utils.ClientDAAddressesCfg] = clientDAAddresses
 	return
 }
 
@@ -145,6 +173,9 @@ func (ra RadiusAgentCfg) Clone() (cln *RadiusAgentCfg) {
 		ListenAcct:         ra.ListenAcct,
 		ClientSecrets:      make(map[string]string),
 		ClientDictionaries: make(map[string]string),
+		ClientDAAddresses:  make(map[string]string),
+		DMRTemplate:        ra.DMRTemplate,
+		CoATemplate:        ra.CoATemplate,
 	}
 	if ra.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(ra.SessionSConns))
@@ -158,6 +189,9 @@ func (ra RadiusAgentCfg) Clone() (cln *RadiusAgentCfg) {
 	for k, v := range ra.ClientDictionaries {
 		cln.ClientDictionaries[k] = v
 	}
+	for k, v := range ra.ClientDAAddresses {
+		cln.ClientDAAddresses[k] = v
+	}
 	if ra.RequestProcessors != nil {
 		cln.RequestProcessors = make([]*RequestProcessor, len(ra.RequestProcessors))
 		for i, req := range ra.RequestProcessors {
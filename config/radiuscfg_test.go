@@ -34,7 +34,10 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		Listen_acct:         utils.StringPointer("127.0.0.1:1813"),
 		Client_secrets:      &map[string]string{utils.MetaDefault: "CGRateS.org"},
 		Client_dictionaries: &map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
+		Client_da_addresses: &map[string]string{"192.168.56.203": "192.168.56.203:3799"},
 		Sessions_conns:      &[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		Dmr_template:        utils.StringPointer("*dmr"),
+		Coa_template:        utils.StringPointer("*coa"),
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:             utils.StringPointer("OutboundAUTHDryRun"),
@@ -63,7 +66,10 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
+		ClientDAAddresses:  map[string]string{"192.168.56.203": "192.168.56.203:3799"},
 		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		DMRTemplate:        "*dmr",
+		CoATemplate:        "*coa",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -145,6 +151,24 @@ func TestRadiusAgentCfgloadFromJsonCfgCase3(t *testing.T) {
 	}
 }
 
+func TestRadiusAgentCfgloadFromJsonCfgSessionSConns(t *testing.T) {
+	cfgJSON := &RadiusAgentJsonCfg{
+		Sessions_conns: &[]string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
+	}
+	expected := []string{utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS),
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"}
+	cfg := NewDefaultCGRConfig()
+	if err = cfg.radiusAgentCfg.loadFromJSONCfg(cfgJSON, cfg.generalCfg.RSRSep); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, cfg.radiusAgentCfg.SessionSConns) {
+		t.Errorf("Expected %+v \n, received %+v", expected, cfg.radiusAgentCfg.SessionSConns)
+	}
+	expMp := []string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"}
+	if rcv := cfg.radiusAgentCfg.AsMapInterface(cfg.generalCfg.RSRSep)[utils.SessionSConnsCfg]; !reflect.DeepEqual(expMp, rcv) {
+		t.Errorf("Expected %+v \n, received %+v", expMp, rcv)
+	}
+}
+
 func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 	cfgJSONStr := `{
 	"radius_agent": {
@@ -155,6 +179,10 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 	     "client_dictionaries": {									
 	    	"*default": "/usr/share/cgrates/",			
 	     },
+	     "client_da_addresses": {
+	    	"192.168.56.203": "192.168.56.203:3799",
+	     },
+	     "dmr_template": "*dmr",
 	     "sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
          "request_processors": [
 			{
@@ -181,6 +209,11 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/",
 		},
+		utils.ClientDAAddressesCfg: map[string]string{
+			"192.168.56.203": "192.168.56.203:3799",
+		},
+		utils.DMRTemplateCfg:   "*dmr",
+		utils.CoATemplateCfg:   "",
 		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
@@ -218,6 +251,9 @@ func TestRadiusAgentCfgAsMapInterface1(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		},
+		utils.ClientDAAddressesCfg: map[string]string{},
+		utils.DMRTemplateCfg:       "",
+		utils.CoATemplateCfg:       "",
 		utils.SessionSConnsCfg:     []string{"*internal"},
 		utils.RequestProcessorsCfg: []map[string]interface{}{},
 	}
@@ -236,7 +272,10 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
+		ClientDAAddresses:  map[string]string{"192.168.56.203": "192.168.56.203:3799"},
 		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		DMRTemplate:        "*dmr",
+		CoATemplate:        "*coa",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -274,4 +313,7 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 	if rcv.ClientDictionaries[utils.MetaDefault] = ""; ban.ClientDictionaries[utils.MetaDefault] != "/usr/share/cgrates/radius/dict/" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.ClientDAAddresses["192.168.56.203"] = ""; ban.ClientDAAddresses["192.168.56.203"] != "192.168.56.203:3799" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
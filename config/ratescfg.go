@@ -36,6 +36,7 @@ type RateSCfg struct {
 	RateSuffixIndexedFields *[]string
 	RateNestedFields        bool
 	Verbosity               int
+	CDRsConns               []string
 }
 
 func (rCfg *RateSCfg) loadFromJSONCfg(jsnCfg *RateSJsonCfg) (err error) {
@@ -103,6 +104,16 @@ func (rCfg *RateSCfg) loadFromJSONCfg(jsnCfg *RateSJsonCfg) (err error) {
 	if jsnCfg.Verbosity != nil {
 		rCfg.Verbosity = *jsnCfg.Verbosity
 	}
+	if jsnCfg.Cdrs_conns != nil {
+		rCfg.CDRsConns = make([]string, len(*jsnCfg.Cdrs_conns))
+		for idx, conn := range *jsnCfg.Cdrs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			rCfg.CDRsConns[idx] = conn
+			if conn == utils.MetaInternal {
+				rCfg.CDRsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)
+			}
+		}
+	}
 	return
 }
 
@@ -158,6 +169,16 @@ func (rCfg *RateSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.RateSuffixIndexedFieldsCfg] = rateSufixIndexedFields
 	}
+	if rCfg.CDRsConns != nil {
+		cdrsConns := make([]string, len(rCfg.CDRsConns))
+		for i, item := range rCfg.CDRsConns {
+			cdrsConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs) {
+				cdrsConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.CDRsConnsCfg] = cdrsConns
+	}
 	return
 }
 
@@ -214,5 +235,11 @@ func (rCfg RateSCfg) Clone() (cln *RateSCfg) {
 		}
 		cln.RateSuffixIndexedFields = &idx
 	}
+	if rCfg.CDRsConns != nil {
+		cln.CDRsConns = make([]string, len(rCfg.CDRsConns))
+		for i, con := range rCfg.CDRsConns {
+			cln.CDRsConns[i] = con
+		}
+	}
 	return
 }
@@ -39,6 +39,7 @@ func TestRateSConfigloadFromJsonCfg(t *testing.T) {
 		Rate_suffix_indexed_fields: &[]string{"*req.index1"},
 		Rate_nested_fields:         utils.BoolPointer(true),
 		Verbosity:                  utils.IntPointer(20),
+		Cdrs_conns:                 &[]string{utils.MetaInternal, "*conn1"},
 	}
 	expected := &RateSCfg{
 		Enabled:                 true,
@@ -53,6 +54,7 @@ func TestRateSConfigloadFromJsonCfg(t *testing.T) {
 		RateSuffixIndexedFields: &[]string{"*req.index1"},
 		RateNestedFields:        true,
 		Verbosity:               20,
+		CDRsConns:               []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
 	}
 	jsonCfg := NewDefaultCGRConfig()
 	if err = jsonCfg.rateSCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -77,6 +79,7 @@ func TestRatesCfgAsMapInterface(t *testing.T) {
 		utils.RateSuffixIndexedFieldsCfg: []string{},
 		utils.RateNestedFieldsCfg:        false,
 		utils.Verbosity:                  1000,
+		utils.CDRsConnsCfg:               []string{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -99,6 +102,7 @@ func TestRatesCfgAsMapInterface1(t *testing.T) {
 	         "rate_prefix_indexed_fields": ["*req.index1", "*req.index2"],		
          	"rate_suffix_indexed_fields": ["*req.index1", "*req.index2", "*req.index3"],		
 	        "rate_nested_fields": true,			
+	        "cdrs_conns": ["*internal", "*conn1"],
      },
 }`
 	eMap := map[string]interface{}{
@@ -114,6 +118,7 @@ func TestRatesCfgAsMapInterface1(t *testing.T) {
 		utils.RateSuffixIndexedFieldsCfg: []string{"*req.index1", "*req.index2", "*req.index3"},
 		utils.RateNestedFieldsCfg:        true,
 		utils.Verbosity:                  1000,
+		utils.CDRsConnsCfg:               []string{utils.MetaInternal, "*conn1"},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -136,6 +141,7 @@ func TestRateSCfgClone(t *testing.T) {
 		RateSuffixIndexedFields: &[]string{"*req.index1"},
 		RateNestedFields:        true,
 		Verbosity:               20,
+		CDRsConns:               []string{"*conn1"},
 	}
 	rcv := sa.Clone()
 	if !reflect.DeepEqual(sa, rcv) {
@@ -166,4 +172,7 @@ func TestRateSCfgClone(t *testing.T) {
 	if (*sa.RateSuffixIndexedFields)[0] != "*req.index1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.CDRsConns[0] = ""; sa.CDRsConns[0] != "*conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
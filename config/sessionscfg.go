@@ -101,6 +101,7 @@ type SessionSCfg struct {
 	ReplicationConns    []string
 	DebitInterval       time.Duration
 	StoreSCosts         bool
+	StoreSessions       bool
 	SessionTTL          time.Duration
 	SessionTTLMaxDelay  *time.Duration
 	SessionTTLLastUsed  *time.Duration
@@ -115,6 +116,7 @@ type SessionSCfg struct {
 	SchedulerConns      []string
 	STIRCfg             *STIRcfg
 	DefaultUsage        map[string]time.Duration
+	QuotaThreshold      map[string]float64
 }
 
 func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
@@ -227,6 +229,9 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 	if jsnCfg.Store_session_costs != nil {
 		scfg.StoreSCosts = *jsnCfg.Store_session_costs
 	}
+	if jsnCfg.Store_sessions != nil {
+		scfg.StoreSessions = *jsnCfg.Store_sessions
+	}
 	if jsnCfg.Session_ttl != nil {
 		if scfg.SessionTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Session_ttl); err != nil {
 			return err
@@ -289,6 +294,11 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Quota_threshold != nil {
+		for k, v := range *jsnCfg.Quota_threshold {
+			scfg.QuotaThreshold[k] = v
+		}
+	}
 	if jsnCfg.Scheduler_conns != nil {
 		scfg.SchedulerConns = make([]string, len(*jsnCfg.Scheduler_conns))
 		for idx, connID := range *jsnCfg.Scheduler_conns {
@@ -309,6 +319,14 @@ func (scfg *SessionSCfg) GetDefaultUsage(tor string) time.Duration {
 	return scfg.DefaultUsage[tor]
 }
 
+// GetQuotaThreshold returns the quota threshold for the ToR, falling back on *any
+func (scfg *SessionSCfg) GetQuotaThreshold(tor string) float64 {
+	if qt, has := scfg.QuotaThreshold[tor]; has {
+		return qt
+	}
+	return scfg.QuotaThreshold[utils.MetaAny]
+}
+
 // AsMapInterface returns the config as a map[string]interface{}
 func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	maxComputed := make(map[string]string)
@@ -319,12 +337,17 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 			maxComputed[key] = strconv.Itoa(int(item))
 		}
 	}
+	quotaThld := make(map[string]float64)
+	for key, item := range scfg.QuotaThreshold {
+		quotaThld[key] = item
+	}
 	initialMP = map[string]interface{}{
 		utils.EnabledCfg:             scfg.Enabled,
 		utils.ListenBijsonCfg:        scfg.ListenBijson,
 		utils.ListenBigobCfg:         scfg.ListenBigob,
 		utils.ReplicationConnsCfg:    scfg.ReplicationConns,
 		utils.StoreSCostsCfg:         scfg.StoreSCosts,
+		utils.StoreSessionsCfg:       scfg.StoreSessions,
 		utils.SessionIndexesCfg:      scfg.SessionIndexes.AsSlice(),
 		utils.ClientProtocolCfg:      scfg.ClientProtocol,
 		utils.TerminateAttemptsCfg:   scfg.TerminateAttempts,
@@ -335,6 +358,7 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.DebitIntervalCfg:       "0",
 		utils.SessionTTLCfg:          "0",
 		utils.DefaultUsageCfg:        maxComputed,
+		utils.QuotaThresholdCfg:      quotaThld,
 	}
 	if scfg.DebitInterval != 0 {
 		initialMP[utils.DebitIntervalCfg] = scfg.DebitInterval.String()
@@ -460,6 +484,7 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 		ListenBijson:        scfg.ListenBijson,
 		DebitInterval:       scfg.DebitInterval,
 		StoreSCosts:         scfg.StoreSCosts,
+		StoreSessions:       scfg.StoreSessions,
 		SessionTTL:          scfg.SessionTTL,
 		ClientProtocol:      scfg.ClientProtocol,
 		ChannelSyncInterval: scfg.ChannelSyncInterval,
@@ -470,10 +495,14 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 		AlterableFields: scfg.AlterableFields.Clone(),
 		STIRCfg:         scfg.STIRCfg.Clone(),
 		DefaultUsage:    make(map[string]time.Duration),
+		QuotaThreshold:  make(map[string]float64),
 	}
 	for k, v := range scfg.DefaultUsage {
 		cln.DefaultUsage[k] = v
 	}
+	for k, v := range scfg.QuotaThreshold {
+		cln.QuotaThreshold[k] = v
+	}
 	if scfg.SessionTTLMaxDelay != nil {
 		cln.SessionTTLMaxDelay = utils.DurationPointer(*scfg.SessionTTLMaxDelay)
 	}
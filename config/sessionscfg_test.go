@@ -128,6 +128,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 			utils.MetaData:  1048576,
 			utils.MetaSMS:   1,
 		},
+		QuotaThreshold: map[string]float64{},
 	}
 	jsonCfg := NewDefaultCGRConfig()
 	if err = jsonCfg.sessionSCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -270,6 +271,7 @@ func TestSessionSCfgloadFromJsonCfgCase10(t *testing.T) {
 			utils.MetaData:  1048576,
 			utils.MetaSMS:   1,
 		},
+		QuotaThreshold: map[string]float64{},
 	}
 	jsonCfg := NewDefaultCGRConfig()
 	if err = jsonCfg.sessionSCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -322,6 +324,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 		utils.ReplicationConnsCfg:    []string{},
 		utils.DebitIntervalCfg:       "0",
 		utils.StoreSCostsCfg:         false,
+		utils.StoreSessionsCfg:       false,
 		utils.SessionTTLCfg:          "0",
 		utils.SessionTTLMaxDelayCfg:  "3h0m0s",
 		utils.SessionTTLLastUsedCfg:  "0s",
@@ -347,6 +350,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 			utils.MetaData:  "1048576",
 			utils.MetaSMS:   "1",
 		},
+		utils.QuotaThresholdCfg: map[string]float64{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -400,6 +404,7 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 		utils.ReplicationConnsCfg:    []string{utils.MetaLocalHost},
 		utils.DebitIntervalCfg:       "8s",
 		utils.StoreSCostsCfg:         true,
+		utils.StoreSessionsCfg:       false,
 		utils.MinDurLowBalanceCfg:    "1s",
 		utils.SessionTTLCfg:          "1s",
 		utils.SessionIndexesCfg:      []string{},
@@ -421,6 +426,7 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 			utils.MetaData:  "1048576",
 			utils.MetaSMS:   "1",
 		},
+		utils.QuotaThresholdCfg: map[string]float64{},
 	}
 	cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr)
 	if err != nil {
@@ -878,6 +884,7 @@ func TestSessionSCfgClone(t *testing.T) {
 			utils.MetaData:  1048576,
 			utils.MetaSMS:   1,
 		},
+		QuotaThreshold: map[string]float64{utils.MetaData: 0.8},
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -0,0 +1,150 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SMPPAgentCfg the config for the SMPPAgent
+type SMPPAgentCfg struct {
+	Enabled           bool
+	Listen            string
+	SystemID          string            // system_id sent back in the bind responses
+	BindCredentials   map[string]string // password per system_id, empty to accept any bind
+	SessionSConns     []string
+	Timezone          string
+	RequestProcessors []*RequestProcessor
+}
+
+func (sa *SMPPAgentCfg) loadFromJSONCfg(jsnCfg *SMPPAgentJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Enabled != nil {
+		sa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Listen != nil {
+		sa.Listen = *jsnCfg.Listen
+	}
+	if jsnCfg.System_id != nil {
+		sa.SystemID = *jsnCfg.System_id
+	}
+	if jsnCfg.Bind_credentials != nil {
+		sa.BindCredentials = make(map[string]string)
+		for systemID, passwd := range *jsnCfg.Bind_credentials {
+			sa.BindCredentials[systemID] = passwd
+		}
+	}
+	if jsnCfg.Timezone != nil {
+		sa.Timezone = *jsnCfg.Timezone
+	}
+	if jsnCfg.Sessions_conns != nil {
+		sa.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
+		for idx, connID := range *jsnCfg.Sessions_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			sa.SessionSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				sa.SessionSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+			}
+		}
+	}
+	if jsnCfg.Request_processors != nil {
+		for _, reqProcJsn := range *jsnCfg.Request_processors {
+			rp := new(RequestProcessor)
+			var haveID bool
+			for _, rpSet := range sa.RequestProcessors {
+				if reqProcJsn.ID != nil && rpSet.ID == *reqProcJsn.ID {
+					rp = rpSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = rp.loadFromJSONCfg(reqProcJsn, sep); err != nil {
+				return
+			}
+			if !haveID {
+				sa.RequestProcessors = append(sa.RequestProcessors, rp)
+			}
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (sa *SMPPAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg:  sa.Enabled,
+		utils.ListenCfg:   sa.Listen,
+		utils.SystemIDCfg: sa.SystemID,
+		utils.TimezoneCfg: sa.Timezone,
+	}
+	bindCredentials := make(map[string]interface{})
+	for systemID, passwd := range sa.BindCredentials {
+		bindCredentials[systemID] = passwd
+	}
+	initialMP[utils.BindCredentialsCfg] = bindCredentials
+
+	requestProcessors := make([]map[string]interface{}, len(sa.RequestProcessors))
+	for i, item := range sa.RequestProcessors {
+		requestProcessors[i] = item.AsMapInterface(separator)
+	}
+	initialMP[utils.RequestProcessorsCfg] = requestProcessors
+
+	if sa.SessionSConns != nil {
+		sessionSConns := make([]string, len(sa.SessionSConns))
+		for i, item := range sa.SessionSConns {
+			sessionSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+				sessionSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.SessionSConnsCfg] = sessionSConns
+	}
+	return
+}
+
+// Clone returns a deep copy of SMPPAgentCfg
+func (sa SMPPAgentCfg) Clone() (cln *SMPPAgentCfg) {
+	cln = &SMPPAgentCfg{
+		Enabled:  sa.Enabled,
+		Listen:   sa.Listen,
+		SystemID: sa.SystemID,
+		Timezone: sa.Timezone,
+	}
+	if sa.BindCredentials != nil {
+		cln.BindCredentials = make(map[string]string)
+		for systemID, passwd := range sa.BindCredentials {
+			cln.BindCredentials[systemID] = passwd
+		}
+	}
+	if sa.SessionSConns != nil {
+		cln.SessionSConns = make([]string, len(sa.SessionSConns))
+		for i, c := range sa.SessionSConns {
+			cln.SessionSConns[i] = c
+		}
+	}
+	if sa.RequestProcessors != nil {
+		cln.RequestProcessors = make([]*RequestProcessor, len(sa.RequestProcessors))
+		for i, rp := range sa.RequestProcessors {
+			cln.RequestProcessors[i] = rp.Clone()
+		}
+	}
+	return
+}
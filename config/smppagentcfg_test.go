@@ -0,0 +1,180 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSMPPAgentCfgloadFromJsonCfg(t *testing.T) {
+	cfgJSON := &SMPPAgentJsonCfg{
+		Enabled:          utils.BoolPointer(true),
+		Listen:           utils.StringPointer("127.0.0.1:2776"),
+		System_id:        utils.StringPointer("SMSC1"),
+		Bind_credentials: &map[string]string{"esme1": "secret"},
+		Sessions_conns:   &[]string{utils.MetaInternal, "*conn1"},
+		Timezone:         utils.StringPointer("UTC"),
+		Request_processors: &[]*ReqProcessorJsnCfg{
+			{
+				ID:             utils.StringPointer("SMSCharging"),
+				Filters:        &[]string{"*string:~*vars.CommandID:submit_sm"},
+				Flags:          &[]string{utils.MetaMessage, utils.MetaAccounts},
+				Request_fields: &[]*FcTemplateJsonCfg{},
+				Reply_fields: &[]*FcTemplateJsonCfg{
+					{
+						Tag:   utils.StringPointer("CommandStatus"),
+						Path:  utils.StringPointer("*rep.command_status"),
+						Type:  utils.StringPointer(utils.MetaConstant),
+						Value: utils.StringPointer("0x45"),
+					},
+				},
+			},
+		},
+	}
+	expected := &SMPPAgentCfg{
+		Enabled:         true,
+		Listen:          "127.0.0.1:2776",
+		SystemID:        "SMSC1",
+		BindCredentials: map[string]string{"esme1": "secret"},
+		SessionSConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone:        "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "SMSCharging",
+				Filters:       []string{"*string:~*vars.CommandID:submit_sm"},
+				Flags:         utils.FlagsWithParamsFromSlice([]string{utils.MetaMessage, utils.MetaAccounts}),
+				RequestFields: []*FCTemplate{},
+				ReplyFields: []*FCTemplate{
+					{
+						Tag:    "CommandStatus",
+						Path:   "*rep.command_status",
+						Type:   utils.MetaConstant,
+						Value:  NewRSRParsersMustCompile("0x45", utils.InfieldSep),
+						Layout: "2006-01-02T15:04:05Z07:00",
+					},
+				},
+			},
+		},
+	}
+	for _, r := range expected.RequestProcessors[0].ReplyFields {
+		r.ComputePath()
+	}
+	jsnCfg := NewDefaultCGRConfig()
+	if err := jsnCfg.smppAgentCfg.loadFromJSONCfg(cfgJSON, jsnCfg.generalCfg.RSRSep); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, jsnCfg.smppAgentCfg) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsnCfg.smppAgentCfg))
+	}
+
+	cfgJSON = &SMPPAgentJsonCfg{
+		Request_processors: &[]*ReqProcessorJsnCfg{{
+			Tenant: utils.StringPointer("a{*"),
+		}},
+	}
+	expErr := "invalid converter terminator in rule: <a{*>"
+	if err := jsnCfg.smppAgentCfg.loadFromJSONCfg(cfgJSON, jsnCfg.generalCfg.RSRSep); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestSMPPAgentCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+	"smpp_agent": {
+		"enabled": true,
+		"listen": "127.0.0.1:2776",
+		"bind_credentials": {"esme1": "secret"},
+		"sessions_conns": ["*internal", "*conn1"],
+		"request_processors": [
+			{
+				"id": "SMSCharging",
+				"filters": ["*string:~*vars.CommandID:submit_sm"],
+				"tenant": "cgrates.org",
+				"flags": ["*message"],
+				"timezone": "",
+				"request_fields": [],
+				"reply_fields": [
+					{"tag": "CommandStatus", "path": "*rep.command_status", "type": "*constant", "value": "0x45"},
+				],
+			},
+		],
+	},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:         true,
+		utils.ListenCfg:          "127.0.0.1:2776",
+		utils.SystemIDCfg:        "CGRateS",
+		utils.BindCredentialsCfg: map[string]interface{}{"esme1": "secret"},
+		utils.SessionSConnsCfg:   []string{utils.MetaInternal, "*conn1"},
+		utils.TimezoneCfg:        "",
+		utils.RequestProcessorsCfg: []map[string]interface{}{
+			{
+				utils.IDCfg:            "SMSCharging",
+				utils.FiltersCfg:       []string{"*string:~*vars.CommandID:submit_sm"},
+				utils.TenantCfg:        "cgrates.org",
+				utils.FlagsCfg:         []string{"*message"},
+				utils.TimezoneCfg:      "",
+				utils.RequestFieldsCfg: []map[string]interface{}{},
+				utils.ReplyFieldsCfg: []map[string]interface{}{
+					{utils.TagCfg: "CommandStatus", utils.PathCfg: "*rep.command_status", utils.TypeCfg: "*constant", utils.ValueCfg: "0x45"},
+				},
+			},
+		},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.smppAgentCfg.AsMapInterface(cgrCfg.generalCfg.RSRSep); !reflect.DeepEqual(rcv, eMap) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestSMPPAgentCfgClone(t *testing.T) {
+	sa := &SMPPAgentCfg{
+		Enabled:         true,
+		Listen:          "127.0.0.1:2775",
+		SystemID:        "CGRateS",
+		BindCredentials: map[string]string{"esme1": "secret"},
+		SessionSConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		Timezone:        "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "SMSCharging",
+				Filters:       []string{"*string:~*vars.CommandID:submit_sm"},
+				Flags:         utils.FlagsWithParams{utils.MetaMessage: {}},
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
+	}
+	rcv := sa.Clone()
+	if !reflect.DeepEqual(sa, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(sa), utils.ToJSON(rcv))
+	}
+	if rcv.RequestProcessors[0].ID = ""; sa.RequestProcessors[0].ID != "SMSCharging" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.BindCredentials["esme1"] = ""; sa.BindCredentials["esme1"] != "secret" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.SessionSConns[0] = ""; sa.SessionSConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
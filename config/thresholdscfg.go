@@ -29,6 +29,7 @@ type ThresholdSCfg struct {
 	Enabled             bool
 	IndexedSelects      bool
 	StoreInterval       time.Duration // Dump regularly from cache into dataDB
+	EEsConns            []string      // connections towards EEs for state transition events
 	StringIndexedFields *[]string
 	PrefixIndexedFields *[]string
 	SuffixIndexedFields *[]string
@@ -50,6 +51,16 @@ func (t *ThresholdSCfg) loadFromJSONCfg(jsnCfg *ThresholdSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Ees_conns != nil {
+		t.EEsConns = make([]string, len(*jsnCfg.Ees_conns))
+		for idx, connID := range *jsnCfg.Ees_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			t.EEsConns[idx] = connID
+			if connID == utils.MetaInternal {
+				t.EEsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)
+			}
+		}
+	}
 	if jsnCfg.String_indexed_fields != nil {
 		sif := make([]string, len(*jsnCfg.String_indexed_fields))
 		for i, fID := range *jsnCfg.String_indexed_fields {
@@ -110,6 +121,16 @@ func (t *ThresholdSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.SuffixIndexedFieldsCfg] = suffixIndexedFields
 	}
+	if t.EEsConns != nil {
+		eesConns := make([]string, len(t.EEsConns))
+		for i, item := range t.EEsConns {
+			eesConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs) {
+				eesConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.EEsConnsCfg] = eesConns
+	}
 	return
 }
 
@@ -121,6 +142,12 @@ func (t ThresholdSCfg) Clone() (cln *ThresholdSCfg) {
 		StoreInterval:  t.StoreInterval,
 		NestedFields:   t.NestedFields,
 	}
+	if t.EEsConns != nil {
+		cln.EEsConns = make([]string, len(t.EEsConns))
+		for i, con := range t.EEsConns {
+			cln.EEsConns[i] = con
+		}
+	}
 
 	if t.StringIndexedFields != nil {
 		idx := make([]string, len(*t.StringIndexedFields))
@@ -29,6 +29,7 @@ func TestThresholdSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Enabled:               utils.BoolPointer(true),
 		Indexed_selects:       utils.BoolPointer(true),
 		Store_interval:        utils.StringPointer("2"),
+		Ees_conns:             &[]string{utils.MetaInternal, "*conn1"},
 		String_indexed_fields: &[]string{"*req.prefix"},
 		Prefix_indexed_fields: &[]string{"*req.index1"},
 		Suffix_indexed_fields: &[]string{"*req.index1"},
@@ -38,6 +39,7 @@ func TestThresholdSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Enabled:             true,
 		IndexedSelects:      true,
 		StoreInterval:       2,
+		EEsConns:            []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
 		StringIndexedFields: &[]string{"*req.prefix"},
 		PrefixIndexedFields: &[]string{"*req.index1"},
 		SuffixIndexedFields: &[]string{"*req.index1"},
@@ -69,6 +71,7 @@ func TestThresholdSCfgAsMapInterfaceCase1(t *testing.T) {
 	eMap := map[string]interface{}{
 		utils.EnabledCfg:             false,
 		utils.StoreIntervalCfg:       "",
+		utils.EEsConnsCfg:            []string{},
 		utils.IndexedSelectsCfg:      true,
 		utils.PrefixIndexedFieldsCfg: []string{},
 		utils.SuffixIndexedFieldsCfg: []string{},
@@ -86,6 +89,7 @@ func TestThresholdSCfgAsMapInterfaceCase2(t *testing.T) {
 		"thresholds": {								
 			"enabled": true,						
 			"store_interval": "96h",					
+			"ees_conns": ["*internal", "*conn1"],
 			"indexed_selects": false,	
             "string_indexed_fields": ["*req.string"],
 			"prefix_indexed_fields": ["*req.prefix","*req.indexed","*req.fields"],	
@@ -96,6 +100,7 @@ func TestThresholdSCfgAsMapInterfaceCase2(t *testing.T) {
 	eMap := map[string]interface{}{
 		utils.EnabledCfg:             true,
 		utils.StoreIntervalCfg:       "96h0m0s",
+		utils.EEsConnsCfg:            []string{utils.MetaInternal, "*conn1"},
 		utils.IndexedSelectsCfg:      false,
 		utils.StringIndexedFieldsCfg: []string{"*req.string"},
 		utils.PrefixIndexedFieldsCfg: []string{"*req.prefix", "*req.indexed", "*req.fields"},
@@ -113,6 +118,7 @@ func TestThresholdSCfgClone(t *testing.T) {
 		Enabled:             true,
 		IndexedSelects:      true,
 		StoreInterval:       2,
+		EEsConns:            []string{"*conn1"},
 		StringIndexedFields: &[]string{"*req.index1"},
 		PrefixIndexedFields: &[]string{"*req.index1"},
 		SuffixIndexedFields: &[]string{"*req.index1"},
@@ -122,6 +128,9 @@ func TestThresholdSCfgClone(t *testing.T) {
 	if !reflect.DeepEqual(ban, rcv) {
 		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(ban), utils.ToJSON(rcv))
 	}
+	if rcv.EEsConns[0] = ""; ban.EEsConns[0] != "*conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 	if (*rcv.StringIndexedFields)[0] = ""; (*ban.StringIndexedFields)[0] != "*req.index1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
@@ -261,6 +261,7 @@ CREATE TABLE tp_resources (
   `stored` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `rate_window` varchar(32) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -288,6 +289,11 @@ CREATE TABLE tp_stats (
   `blocker` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `group_by` varchar(64) NOT NULL,
+  `group_ttl` varchar(32) NOT NULL,
+  `window_type` varchar(16) NOT NULL,
+  `window_length` varchar(32) NOT NULL,
+  `window_history` int(11) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -313,6 +319,8 @@ CREATE TABLE tp_thresholds (
   `weight` decimal(8,2) NOT NULL,
   `action_ids` varchar(64) NOT NULL,
   `async` BOOLEAN NOT NULL,
+  `recovery_filter_ids` varchar(64) NOT NULL,
+  `recovery_action_ids` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -365,6 +373,8 @@ CREATE TABLE tp_routes (
   `route_blocker` BOOLEAN NOT NULL,
   `route_parameters` varchar(64) NOT NULL,
   `weight` decimal(8,2) NOT NULL,
+  `breaker_failures` int(11) NOT NULL,
+  `breaker_cool_down` varchar(32) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -496,6 +506,9 @@ CREATE TABLE tp_rate_profiles (
   `rate_recurrent_fee` decimal(8,4) NOT NULL,
   `rate_unit` varchar(64) NOT NULL,
   `rate_increment` varchar(64) NOT NULL,
+  `currency` varchar(3) NOT NULL,
+  `volume_counter` varchar(16) NOT NULL,
+  `volume_reset` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -528,6 +541,7 @@ CREATE TABLE tp_action_profiles (
   `action_opts` varchar(256) NOT NULL,
   `action_path` varchar(64) NOT NULL,
   `action_value` varchar(64) NOT NULL,
+  `missed_runs` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -557,6 +571,7 @@ CREATE TABLE tp_account_profiles (
   `balance_attribute_ids` varchar(64) NOT NULL,
   `balance_rate_profile_ids` varchar(64) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `balance_activation_interval` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -257,6 +257,7 @@ CREATE TABLE tp_resources (
   "stored" BOOLEAN NOT NULL,
   "weight" NUMERIC(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "rate_window" varchar(32) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_resources_idx ON tp_resources (tpid);
@@ -284,6 +285,11 @@ CREATE TABLE tp_stats (
   "blocker" BOOLEAN NOT NULL,
   "weight" decimal(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "group_by" varchar(64) NOT NULL,
+  "group_ttl" varchar(32) NOT NULL,
+  "window_type" varchar(16) NOT NULL,
+  "window_length" varchar(32) NOT NULL,
+  "window_history" INTEGER NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_stats_idx ON tp_stats (tpid);
@@ -308,6 +314,8 @@ CREATE TABLE tp_thresholds (
   "weight" decimal(8,2) NOT NULL,
   "action_ids" varchar(64) NOT NULL,
   "async" BOOLEAN NOT NULL,
+  "recovery_filter_ids" varchar(64) NOT NULL,
+  "recovery_action_ids" varchar(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_thresholds_idx ON tp_thresholds (tpid);
@@ -356,6 +364,8 @@ CREATE TABLE tp_routes (
   "route_blocker" BOOLEAN NOT NULL,
   "route_parameters" varchar(64) NOT NULL,
   "weight" decimal(8,2) NOT NULL,
+  "breaker_failures" INTEGER NOT NULL,
+  "breaker_cool_down" varchar(32) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_routes_idx ON tp_routes (tpid);
@@ -481,6 +491,9 @@ CREATE INDEX tp_routes_unique ON tp_routes  ("tpid",  "tenant", "id",
   "rate_recurrent_fee" decimal(8,4) NOT NULL,
   "rate_unit" VARCHAR(64) NOT NULL,
   "rate_increment" VARCHAR(64) NOT NULL,
+  "currency" VARCHAR(3) NOT NULL,
+  "volume_counter" VARCHAR(16) NOT NULL,
+  "volume_reset" VARCHAR(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
   );
   CREATE INDEX tp_rate_profiles_ids ON tp_rate_profiles (tpid);
@@ -512,6 +525,7 @@ CREATE TABLE tp_action_profiles (
   "action_opts" varchar(256) NOT NULL,
   "action_path" varchar(64) NOT NULL,
   "action_value" varchar(64) NOT NULL,
+  "missed_runs" varchar(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
   );
   CREATE INDEX tp_action_profiles_ids ON tp_action_profiles (tpid);
@@ -540,6 +554,7 @@ CREATE TABLE tp_account_profiles (
   "balance_attribute_ids" varchar(64) NOT NULL,
   "balance_rate_profile_ids" varchar(64) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "balance_activation_interval" varchar(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
  CREATE INDEX tp_account_profiles_ids ON tp_account_profiles (tpid);
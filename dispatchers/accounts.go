@@ -33,3 +33,45 @@ func (dS *DispatcherService) AccountSv1Ping(args *utils.CGREvent, rpl *string) (
 	}
 	return dS.Dispatch(args, utils.AccountS, utils.AccountSv1Ping, args, rpl)
 }
+
+func (dS *DispatcherService) AccountSv1RefundCharges(args *utils.ArgsAccountsRefundCharges, rpl *string) (err error) {
+	if args.CGREvent == nil {
+		args.CGREvent = new(utils.CGREvent)
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1RefundCharges, args.CGREvent.Tenant,
+			utils.IfaceAsString(args.CGREvent.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.AccountS, utils.AccountSv1RefundCharges, args, rpl)
+}
+
+func (dS *DispatcherService) AccountSv1MaxCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	if args.CGREvent == nil {
+		args.CGREvent = new(utils.CGREvent)
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1MaxCost, args.CGREvent.Tenant,
+			utils.IfaceAsString(args.CGREvent.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.AccountS, utils.AccountSv1MaxCost, args, eEc)
+}
+
+func (dS *DispatcherService) AccountSv1DebitCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	if args.CGREvent == nil {
+		args.CGREvent = new(utils.CGREvent)
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1DebitCost, args.CGREvent.Tenant,
+			utils.IfaceAsString(args.CGREvent.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.AccountS, utils.AccountSv1DebitCost, args, eEc)
+}
@@ -189,6 +189,7 @@ func testDspChcPrecacheStatus(t *testing.T) {
 		utils.CacheDispatcherProfiles:           utils.MetaReady,
 		utils.CacheDispatcherHosts:              utils.MetaReady,
 		utils.CacheDiameterMessages:             utils.MetaReady,
+		utils.CacheRadiusPackets:                utils.MetaReady,
 		utils.CacheAttributeFilterIndexes:       utils.MetaReady,
 		utils.CacheResourceFilterIndexes:        utils.MetaReady,
 		utils.CacheStatFilterIndexes:            utils.MetaReady,
@@ -199,6 +200,11 @@ func testDspChcPrecacheStatus(t *testing.T) {
 		utils.CacheRateProfilesFilterIndexes:    utils.MetaReady,
 		utils.CacheRateFilterIndexes:            utils.MetaReady,
 		utils.CacheRateProfiles:                 utils.MetaReady,
+		utils.CacheCurrencyConversions:          utils.MetaReady,
+		utils.CacheRateVolumeCounters:           utils.MetaReady,
+		utils.CacheActionSchedules:              utils.MetaReady,
+		utils.CacheStatQueueHistories:           utils.MetaReady,
+		utils.CacheStoredSessions:               utils.MetaReady,
 		utils.CacheLoadIDs:                      utils.MetaReady,
 		utils.CacheCDRIDs:                       utils.MetaReady,
 		utils.CacheClosedSessions:               utils.MetaReady,
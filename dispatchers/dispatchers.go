@@ -194,7 +194,7 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
 	if errCh := engine.Cache.Set(utils.CacheDispatchers, tntID, d, nil, true, utils.EmptyString); errCh != nil {
 		return utils.NewErrDispatcherS(errCh)
 	}
-	return d.Dispatch(utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsys, serviceMethod, args, reply)
+	return d.Dispatch(ev, subsys, serviceMethod, args, reply)
 }
 
 func (dS *DispatcherService) V1GetProfileForEvent(ev *utils.CGREvent,
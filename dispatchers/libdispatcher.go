@@ -21,6 +21,7 @@ package dispatchers
 import (
 	"encoding/gob"
 	"fmt"
+	"hash/fnv"
 	"sort"
 	"sync"
 
@@ -47,7 +48,7 @@ type Dispatcher interface {
 	// HostIDs returns the ordered list of host IDs
 	HostIDs() (hostIDs engine.DispatcherHostIDs)
 	// Dispatch is used to send the method over the connections given
-	Dispatch(routeID string, subsystem,
+	Dispatch(ev *utils.CGREvent, subsystem,
 		serviceMethod string, args interface{}, reply interface{}) (err error)
 }
 
@@ -95,6 +96,20 @@ func newDispatcher(dm *engine.DataManager, pfl *engine.DispatcherProfile) (d Dis
 			hosts:    hosts,
 			strategy: strDsp,
 		}
+	case utils.MetaHash:
+		var hashFlds config.RSRParsers
+		var failover bool
+		if hashFlds, failover, err = hashParams(pfl.StrategyParams); err != nil {
+			return
+		}
+		d = &HashDispatcher{
+			dm:       dm,
+			tnt:      pfl.Tenant,
+			hosts:    hosts,
+			hashFlds: hashFlds,
+			failover: failover,
+			strategy: new(singleResultstrategyDispatcher),
+		}
 	case rpcclient.PoolBroadcast,
 		rpcclient.PoolBroadcastSync,
 		rpcclient.PoolBroadcastAsync:
@@ -137,9 +152,9 @@ func (wd *WeightDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (wd *WeightDispatcher) Dispatch(routeID string, subsystem,
+func (wd *WeightDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
-	return wd.strategy.dispatch(wd.dm, routeID, subsystem, wd.tnt, wd.HostIDs(),
+	return wd.strategy.dispatch(wd.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, wd.tnt, wd.HostIDs(),
 		serviceMethod, args, reply)
 }
 
@@ -171,9 +186,9 @@ func (d *RandomDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (d *RandomDispatcher) Dispatch(routeID string, subsystem,
+func (d *RandomDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
-	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
+	return d.strategy.dispatch(d.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
 }
 
@@ -209,12 +224,103 @@ func (d *RoundRobinDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (d *RoundRobinDispatcher) Dispatch(routeID string, subsystem,
+func (d *RoundRobinDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
-	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
+	return d.strategy.dispatch(d.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
 }
 
+// HashDispatcher selects the connection based on the hash of some event fields
+// so the events with the same values always reach the same host
+type HashDispatcher struct {
+	sync.RWMutex
+	dm       *engine.DataManager
+	tnt      string
+	hosts    engine.DispatcherHostProfiles
+	hashFlds config.RSRParsers
+	failover bool // try the next hosts on network errors
+	strategy strategyDispatcher
+}
+
+// SetProfile used to implement Dispatcher interface
+func (d *HashDispatcher) SetProfile(pfl *engine.DispatcherProfile) {
+	d.Lock()
+	d.hosts = pfl.Hosts.Clone()
+	if hashFlds, failover, err := hashParams(pfl.StrategyParams); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> keeping the old hash parameters for profile: %s, error: %s",
+			utils.DispatcherS, pfl.TenantID(), err.Error()))
+	} else {
+		d.hashFlds = hashFlds
+		d.failover = failover
+	}
+	d.Unlock()
+	return
+}
+
+// HostIDs used to implement Dispatcher interface
+func (d *HashDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
+	d.RLock()
+	hostIDs = d.hosts.HostIDs()
+	d.RUnlock()
+	return
+}
+
+// hostIDsForKey orders the hosts using rendezvous hashing on the key
+// so adding or removing a host only moves the keys owned by it
+func (d *HashDispatcher) hostIDsForKey(key string) (hostIDs engine.DispatcherHostIDs) {
+	hostIDs = d.HostIDs()
+	scores := make(map[string]uint64, len(hostIDs))
+	for _, hostID := range hostIDs {
+		h := fnv.New64a()
+		h.Write([]byte(key + utils.ConcatenatedKeySep + hostID))
+		scores[hostID] = h.Sum64()
+	}
+	sort.SliceStable(hostIDs, func(i, j int) bool { return scores[hostIDs[i]] > scores[hostIDs[j]] })
+	return
+}
+
+// Dispatch used to implement Dispatcher interface
+func (d *HashDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
+	serviceMethod string, args interface{}, reply interface{}) (err error) {
+	d.RLock()
+	hashFlds := d.hashFlds
+	failover := d.failover
+	d.RUnlock()
+	var key string
+	if key, err = hashFlds.ParseDataProvider(utils.MapStorage{
+		utils.MetaReq:  ev.Event,
+		utils.MetaOpts: ev.Opts,
+	}); err != nil {
+		if err != utils.ErrNotFound {
+			return utils.NewErrDispatcherS(err)
+		}
+		// without the hash fields the owner of the key is unknown (ie: GetResource, Ping)
+		return utils.NewErrMandatoryIeMissing(hashFlds.GetRule(utils.InfieldSep))
+	}
+	hostIDs := d.hostIDsForKey(utils.ConcatenatedKey(ev.Tenant, key))
+	if !failover && len(hostIDs) > 1 {
+		hostIDs = hostIDs[:1] // only the owner of the key can answer
+	}
+	return d.strategy.dispatch(d.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, d.tnt,
+		hostIDs, serviceMethod, args, reply)
+}
+
+// hashParams returns the fields and the failover used by the *hash strategy
+func hashParams(params map[string]interface{}) (hashFlds config.RSRParsers, failover bool, err error) {
+	flds := utils.IfaceAsString(params[utils.MetaHashFields])
+	if flds == utils.EmptyString {
+		err = fmt.Errorf("missing <%s> strategy parameter", utils.MetaHashFields)
+		return
+	}
+	if hashFlds, err = config.NewRSRParsers(flds, utils.InfieldSep); err != nil {
+		return
+	}
+	if fo, has := params[utils.MetaHashFailover]; has {
+		failover, err = utils.IfaceAsBool(fo)
+	}
+	return
+}
+
 type singleResultstrategyDispatcher struct{}
 
 func (*singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeID string, subsystem, tnt string,
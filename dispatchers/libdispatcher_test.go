@@ -235,3 +235,110 @@ func TestLoadMetricsGetHosts2(t *testing.T) {
 		lm.incrementLoad(exp[0], utils.EmptyString)
 	}
 }
+
+func TestHashDispatcherHostIDsForKey(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HASH",
+		Strategy: utils.MetaHash,
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_1"},
+			{ID: "DSP_2"},
+			{ID: "DSP_3"},
+		},
+	}
+	if _, err := newDispatcher(nil, pfl); err == nil {
+		t.Errorf("expected error for missing %s", utils.MetaHashFields)
+	}
+	pfl.StrategyParams = map[string]interface{}{utils.MetaHashFields: "~*req.Account"}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd := d.(*HashDispatcher)
+	owners := make(map[string]string)
+	for _, key := range []string{"1001", "1002", "1003", "1004", "1005", "1006"} {
+		hostIDs := hd.hostIDsForKey(key)
+		if len(hostIDs) != 3 {
+			t.Fatalf("unexpected hosts: %v", hostIDs)
+		}
+		if rply := hd.hostIDsForKey(key); !reflect.DeepEqual(hostIDs, rply) {
+			t.Errorf("expected stable hosts for key %s: %v, received: %v", key, hostIDs, rply)
+		}
+		owners[key] = hostIDs[0]
+	}
+	// removing a host only moves the keys it owned
+	pfl.Hosts = pfl.Hosts[:2]
+	hd.SetProfile(pfl)
+	for key, owner := range owners {
+		if owner == "DSP_3" {
+			continue
+		}
+		if rply := hd.hostIDsForKey(key)[0]; rply != owner {
+			t.Errorf("expected key %s to stay on %s, received: %s", key, owner, rply)
+		}
+	}
+}
+
+type hostIDsStrategyDispatcher struct {
+	hostIDs []string
+}
+
+func (s *hostIDsStrategyDispatcher) dispatch(_ *engine.DataManager, _ string, _, _ string,
+	hostIDs []string, _ string, _ interface{}, _ interface{}) (err error) {
+	s.hostIDs = hostIDs
+	return
+}
+
+func TestHashDispatcherDispatch(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:         "cgrates.org",
+		ID:             "DSP_HASH",
+		Strategy:       utils.MetaHash,
+		StrategyParams: map[string]interface{}{utils.MetaHashFields: "~*req.Account"},
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_1"},
+			{ID: "DSP_2"},
+			{ID: "DSP_3"},
+		},
+	}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd := d.(*HashDispatcher)
+	strDsp := new(hostIDsStrategyDispatcher)
+	hd.strategy = strDsp
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "EV1",
+		Event:  map[string]interface{}{utils.AccountField: "1001"},
+	}
+	// no failover by default
+	if err = hd.Dispatch(ev, utils.MetaResources, utils.ResourceSv1AuthorizeResources, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	owner := hd.hostIDsForKey("cgrates.org:1001")
+	if exp := []string(owner[:1]); !reflect.DeepEqual(exp, strDsp.hostIDs) {
+		t.Errorf("Expected: %v, received: %v", exp, strDsp.hostIDs)
+	}
+	// the events without the hash fields are not dispatched
+	expErr := utils.NewErrMandatoryIeMissing("~*req.Account").Error()
+	if err = hd.Dispatch(&utils.CGREvent{Tenant: "cgrates.org", ID: "RES1"}, utils.MetaResources,
+		utils.ResourceSv1GetResource, nil, nil); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	// failover on the rest of the hosts
+	pfl.StrategyParams[utils.MetaHashFailover] = true
+	hd.SetProfile(pfl)
+	if err = hd.Dispatch(ev, utils.MetaResources, utils.ResourceSv1AuthorizeResources, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string(owner), strDsp.hostIDs) {
+		t.Errorf("Expected: %v, received: %v", owner, strDsp.hostIDs)
+	}
+	pfl.StrategyParams[utils.MetaHashFailover] = "notABool"
+	if _, err = newDispatcher(nil, pfl); err == nil {
+		t.Errorf("expected error for invalid %s", utils.MetaHashFailover)
+	}
+}
@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -50,3 +52,20 @@ func (dS *DispatcherService) RateSv1CostForEvent(args *utils.ArgsCostForEvent, r
 	}
 	return dS.Dispatch(args.CGREvent, utils.RateS, utils.RateSv1CostForEvent, args, rpCost)
 }
+
+func (dS *DispatcherService) RateSv1CostForEvents(args *engine.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) (err error) {
+	if args == nil {
+		args = new(engine.ArgsCostForEvents)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1CostForEvents, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1CostForEvents, args, rpCosts)
+}
@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -63,3 +65,20 @@ func (dS *DispatcherService) RouteSv1GetRouteProfilesForEvent(args *utils.CGREve
 	}
 	return dS.Dispatch(args, utils.MetaRoutes, utils.RouteSv1GetRouteProfilesForEvent, args, reply)
 }
+
+func (dS *DispatcherService) RouteSv1ReportRouteOutcome(args *engine.ArgsRouteOutcome,
+	reply *string) (err error) {
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RouteSv1ReportRouteOutcome,
+			args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		ID:     args.RouteID,
+		Opts:   args.Opts,
+	}, utils.MetaRoutes, utils.RouteSv1ReportRouteOutcome, args, reply)
+}
@@ -99,6 +99,22 @@ func (dS *DispatcherService) StatSv1GetQueueFloatMetrics(args *utils.TenantIDWit
 	}, utils.MetaStats, utils.StatSv1GetQueueFloatMetrics, args, reply)
 }
 
+func (dS *DispatcherService) StatSv1GetQueueHistory(args *utils.TenantIDWithOpts,
+	reply *engine.StatQueueHistory) (err error) {
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.StatSv1GetQueueHistory,
+			args.TenantID.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		ID:     args.ID,
+		Opts:   args.Opts,
+	}, utils.MetaStats, utils.StatSv1GetQueueHistory, args, reply)
+}
+
 func (dS *DispatcherService) StatSv1GetQueueIDs(args *utils.TenantWithOpts,
 	reply *[]string) (err error) {
 	tnt := dS.cfg.GeneralCfg().DefaultTenant
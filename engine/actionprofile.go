@@ -34,6 +34,7 @@ type ActionProfile struct {
 	ActivationInterval *utils.ActivationInterval
 	Weight             float64
 	Schedule           string
+	MissedRuns         string // <""|*skip|*run_once|*run_all> what to do with the runs missed while ActionS was down
 	Targets            map[string]utils.StringSet
 
 	Actions []*APAction
@@ -63,6 +64,18 @@ type APAction struct {
 	Value     config.RSRParsers      // Value to execute on path
 }
 
+// ActionExecutionInfo describes an action in progress
+type ActionExecutionInfo struct {
+	ID              string // execution ID, used to cancel the action
+	Tenant          string
+	ActionProfileID string
+	ActionID        string
+	Type            string
+	Target          string    // target key: <*none|*accounts:1001>
+	StartTime       time.Time // time the action started executing
+	Deadline        time.Time // time the action will be cancelled based on its TTL, zero if no TTL
+}
+
 // ActionProfileWithOpts is used in API calls
 type ActionProfileWithOpts struct {
 	*ActionProfile
@@ -0,0 +1,102 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ActionSchedule is the persisted schedule state of an ActionProfile
+// used by ActionS to detect the runs missed while it was down
+type ActionSchedule struct {
+	Tenant     string
+	ID         string               // ActionProfile ID
+	LastRuns   map[string]time.Time // last run handled, indexed on target key
+	Executions []*ActionExecution   // past executions, the most recent last
+}
+
+// ActionExecution is one run of a scheduled ActionProfile
+type ActionExecution struct {
+	Target   string    // target key: <*none|*accounts:1001>
+	RunTime  time.Time // time the run was scheduled for
+	ExecTime time.Time // time the actions were executed, zero if the run was skipped
+	CatchUp  bool      // the run was missed while ActionS was down
+	Error    string
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (aSched *ActionSchedule) TenantID() string {
+	return utils.ConcatenatedKey(aSched.Tenant, aSched.ID)
+}
+
+// Clone returns a copy of the ActionSchedule
+// the executions are shared since they are not modified once recorded
+func (aSched *ActionSchedule) Clone() (cln *ActionSchedule) {
+	cln = &ActionSchedule{
+		Tenant: aSched.Tenant,
+		ID:     aSched.ID,
+	}
+	if aSched.LastRuns != nil {
+		cln.LastRuns = make(map[string]time.Time, len(aSched.LastRuns))
+		for trgKey, lastRun := range aSched.LastRuns {
+			cln.LastRuns[trgKey] = lastRun
+		}
+	}
+	if aSched.Executions != nil {
+		cln.Executions = make([]*ActionExecution, len(aSched.Executions))
+		copy(cln.Executions, aSched.Executions)
+	}
+	return
+}
+
+// SetLastRun marks the runs of the target up to runTime as handled
+func (aSched *ActionSchedule) SetLastRun(trgKey string, runTime time.Time) {
+	if aSched.LastRuns == nil {
+		aSched.LastRuns = make(map[string]time.Time)
+	}
+	if lastRun, has := aSched.LastRuns[trgKey]; !has || runTime.After(lastRun) {
+		aSched.LastRuns[trgKey] = runTime
+	}
+}
+
+// AddExecution records a run for the target, keeping at most maxHistory executions
+// maxHistory lower than 0 means unlimited history
+func (aSched *ActionSchedule) AddExecution(aExec *ActionExecution, maxHistory int) {
+	aSched.SetLastRun(aExec.Target, aExec.RunTime)
+	if maxHistory == 0 {
+		return
+	}
+	aSched.Executions = append(aSched.Executions, aExec)
+	if maxHistory > 0 && len(aSched.Executions) > maxHistory {
+		aSched.Executions = aSched.Executions[len(aSched.Executions)-maxHistory:]
+	}
+}
+
+// ActionScheduleInfo is returned by the API listing the past and upcoming executions
+type ActionScheduleInfo struct {
+	Tenant     string
+	ID         string
+	Schedule   string
+	MissedRuns string
+	LastRuns   map[string]time.Time
+	Upcoming   []time.Time
+	Executions []*ActionExecution
+}
@@ -327,6 +327,7 @@ func (cdrS *CDRServer) rateCDRWithErr(cdr *CDRWithOpts) (ratedCDRs []*CDR) {
 }
 
 // refundEventCost will refund the EventCost using RefundIncrements
+// ToDo: refund with AccountSv1.RefundCharges on rerate once the CDRs are debited out of AccountS
 func (cdrS *CDRServer) refundEventCost(ec *EventCost, reqType, tor string) (rfnd bool, err error) {
 	if len(cdrS.cgrCfg.CdrsCfg().RaterConns) == 0 {
 		return false, utils.NewErrNotConnected(utils.RALService)
@@ -0,0 +1,68 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+)
+
+// CurrencyConversions is the conversion table of a tenant
+// used by RateS to convert the costs between currencies
+type CurrencyConversions struct {
+	Tenant        string
+	ExchangeRates map[string]*utils.Decimal // indexed on FromCurrency:ToCurrency
+}
+
+// SetExchangeRate will add or overwrite the rate used to convert fromCurrency into toCurrency
+func (cC *CurrencyConversions) SetExchangeRate(fromCurrency, toCurrency string, rate *utils.Decimal) {
+	if cC.ExchangeRates == nil {
+		cC.ExchangeRates = make(map[string]*utils.Decimal)
+	}
+	cC.ExchangeRates[utils.ConcatenatedKey(fromCurrency, toCurrency)] = rate
+}
+
+// RemoveExchangeRate will remove the rate used to convert fromCurrency into toCurrency
+func (cC *CurrencyConversions) RemoveExchangeRate(fromCurrency, toCurrency string) {
+	delete(cC.ExchangeRates, utils.ConcatenatedKey(fromCurrency, toCurrency))
+}
+
+// ExchangeRate returns the rate used to convert an amount from fromCurrency into toCurrency
+// the inverse of the opposite conversion is used if the direct one is not defined
+func (cC *CurrencyConversions) ExchangeRate(fromCurrency, toCurrency string) (rate *decimal.Big, err error) {
+	if fromCurrency == toCurrency {
+		return decimal.New(1, 0), nil
+	}
+	if xRate, has := cC.ExchangeRates[utils.ConcatenatedKey(fromCurrency, toCurrency)]; has {
+		return xRate.Big, nil
+	}
+	if xRate, has := cC.ExchangeRates[utils.ConcatenatedKey(toCurrency, fromCurrency)]; has &&
+		xRate.Big.Sign() != 0 {
+		return utils.DivideBig(decimal.New(1, 0), xRate.Big), nil
+	}
+	return nil, fmt.Errorf("missing exchange rate from <%s> to <%s>", fromCurrency, toCurrency)
+}
+
+// CurrencyConversionsWithOpts is used in replicator and APIs
+type CurrencyConversionsWithOpts struct {
+	*CurrencyConversions
+	Opts map[string]interface{}
+}
@@ -0,0 +1,88 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCurrencyConversionsExchangeRate(t *testing.T) {
+	cC := &CurrencyConversions{Tenant: "cgrates.org"}
+	cC.SetExchangeRate("USD", "EUR", utils.NewDecimal(8, 1))
+	cC.SetExchangeRate("GBP", "EUR", utils.NewDecimal(0, 0))
+
+	if rate, err := cC.ExchangeRate("EUR", "EUR"); err != nil {
+		t.Error(err)
+	} else if rcv, _ := rate.Float64(); rcv != 1 {
+		t.Errorf("Expected 1, received %v", rcv)
+	}
+	if rate, err := cC.ExchangeRate("USD", "EUR"); err != nil {
+		t.Error(err)
+	} else if rcv, _ := rate.Float64(); rcv != 0.8 {
+		t.Errorf("Expected 0.8, received %v", rcv)
+	}
+	if rate, err := cC.ExchangeRate("EUR", "USD"); err != nil {
+		t.Error(err)
+	} else if rcv, _ := rate.Float64(); rcv != 1.25 {
+		t.Errorf("Expected 1.25, received %v", rcv)
+	}
+	expErr := "missing exchange rate from <EUR> to <GBP>"
+	if _, err := cC.ExchangeRate("EUR", "GBP"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+
+	cC.RemoveExchangeRate("USD", "EUR")
+	expErr = "missing exchange rate from <USD> to <EUR>"
+	if _, err := cC.ExchangeRate("USD", "EUR"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestDMGetCurrencyConversionsCache(t *testing.T) {
+	defer func() {
+		Cache.Remove(utils.CacheCurrencyConversions, "cgrates.org", true, utils.NonTransactional)
+		Cache.Remove(utils.CacheCurrencyConversions, "itsyscom.com", true, utils.NonTransactional)
+	}()
+	dm := NewDataManager(&DataDBMock{}, config.CgrConfig().CacheCfg(), nil)
+	cC := &CurrencyConversions{Tenant: "cgrates.org"}
+	cC.SetExchangeRate("USD", "EUR", utils.NewDecimal(8, 1))
+	if err := Cache.Set(utils.CacheCurrencyConversions, "cgrates.org", cC, nil,
+		true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetCurrencyConversions("cgrates.org", true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcv != cC {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(cC), utils.ToJSON(rcv))
+	}
+	if _, err := dm.GetCurrencyConversions("cgrates.org", false, false, utils.NonTransactional); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+
+	if err := Cache.Set(utils.CacheCurrencyConversions, "itsyscom.com", nil, nil,
+		true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetCurrencyConversions("itsyscom.com", true, true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
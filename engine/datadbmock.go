@@ -418,6 +418,18 @@ func (dbM *DataDBMock) RemoveAccountProfileDrv(string, string) error {
 	return utils.ErrNotImplemented
 }
 
+func (dbM *DataDBMock) GetCurrencyConversionsDrv(string) (*CurrencyConversions, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetCurrencyConversionsDrv(*CurrencyConversions) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveCurrencyConversionsDrv(string) error {
+	return utils.ErrNotImplemented
+}
+
 func (dbM *DataDBMock) SetVersions(vrs Versions, overwrite bool) (err error) {
 	return utils.ErrNotImplemented
 }
@@ -425,3 +437,51 @@ func (dbM *DataDBMock) SetVersions(vrs Versions, overwrite bool) (err error) {
 func (dbM *DataDBMock) RemoveRatingProfileDrv(string) error {
 	return utils.ErrNotImplemented
 }
+
+func (dbM *DataDBMock) GetRateVolumeCounterDrv(string, string) (*RateVolumeCounter, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetRateVolumeCounterDrv(*RateVolumeCounter) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveRateVolumeCounterDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetActionScheduleDrv(string, string) (*ActionSchedule, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetActionScheduleDrv(*ActionSchedule) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveActionScheduleDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetStatQueueHistoryDrv(string, string) (*StatQueueHistory, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetStatQueueHistoryDrv(*StatQueueHistory) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveStatQueueHistoryDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetStoredSessionDrv(string, string) (*StoredSession, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetStoredSessionDrv(*StoredSession) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveStoredSessionDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
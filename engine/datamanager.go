@@ -230,6 +230,8 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 		case utils.ActionProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetActionProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.CurrencyConversionsPrefix:
+			_, err = dm.GetCurrencyConversions(dataID, false, true, utils.NonTransactional)
 		case utils.AttributeFilterIndexes:
 			var tntCtx, idxKey string
 			if tntCtx, idxKey, err = splitFilterIndex(dataID); err != nil {
@@ -1275,6 +1277,9 @@ func (dm *DataManager) GetStatQueueProfile(tenant, id string, cacheRead, cacheWr
 			return nil, err
 		}
 	}
+	if err = sqp.Compile(); err != nil {
+		return nil, err
+	}
 	if cacheWrite {
 		if errCh := Cache.Set(utils.CacheStatQueueProfiles, tntID, sqp, nil,
 			cacheCommit(transactionID), transactionID); errCh != nil {
@@ -3918,3 +3923,178 @@ func (dm *DataManager) RemoveAccountProfile(tenant, id string,
 	}
 	return
 }
+
+// GetCurrencyConversions returns the conversion table of the tenant
+func (dm *DataManager) GetCurrencyConversions(tenant string, cacheRead, cacheWrite bool,
+	transactionID string) (cC *CurrencyConversions, err error) {
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheCurrencyConversions, tenant); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*CurrencyConversions), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if cC, err = dm.dataDB.GetCurrencyConversionsDrv(tenant); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheCurrencyConversions, tenant, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheCurrencyConversions, tenant, cC, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+// SetCurrencyConversions stores the conversion table of the tenant
+func (dm *DataManager) SetCurrencyConversions(cC *CurrencyConversions) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().SetCurrencyConversionsDrv(cC)
+}
+
+// RemoveCurrencyConversions removes the conversion table of the tenant
+func (dm *DataManager) RemoveCurrencyConversions(tenant string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	var oldCC *CurrencyConversions
+	if oldCC, err = dm.GetCurrencyConversions(tenant, true, false, utils.NonTransactional); err != nil {
+		return
+	}
+	return dm.DataDB().RemoveCurrencyConversionsDrv(oldCC.Tenant)
+}
+
+// GetRateVolumeCounter returns the volume accumulated on a RateProfile
+func (dm *DataManager) GetRateVolumeCounter(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetRateVolumeCounterDrv(tenant, id)
+}
+
+// SetRateVolumeCounter stores the volume accumulated on a RateProfile
+func (dm *DataManager) SetRateVolumeCounter(rvc *RateVolumeCounter) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().SetRateVolumeCounterDrv(rvc)
+}
+
+// RemoveRateVolumeCounter removes the volume accumulated on a RateProfile
+func (dm *DataManager) RemoveRateVolumeCounter(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	var oldRvc *RateVolumeCounter
+	if oldRvc, err = dm.GetRateVolumeCounter(tenant, id); err != nil {
+		return
+	}
+	return dm.DataDB().RemoveRateVolumeCounterDrv(oldRvc.Tenant, oldRvc.ID)
+}
+
+// GetActionSchedule returns the schedule state of an ActionProfile
+func (dm *DataManager) GetActionSchedule(tenant, id string) (aSched *ActionSchedule, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetActionScheduleDrv(tenant, id)
+}
+
+// SetActionSchedule stores the schedule state of an ActionProfile
+func (dm *DataManager) SetActionSchedule(aSched *ActionSchedule) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().SetActionScheduleDrv(aSched)
+}
+
+// RemoveActionSchedule removes the schedule state of an ActionProfile
+func (dm *DataManager) RemoveActionSchedule(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	var oldASched *ActionSchedule
+	if oldASched, err = dm.GetActionSchedule(tenant, id); err != nil {
+		return
+	}
+	return dm.DataDB().RemoveActionScheduleDrv(oldASched.Tenant, oldASched.ID)
+}
+
+// GetStatQueueHistory returns the window snapshots of a StatQueue
+func (dm *DataManager) GetStatQueueHistory(tenant, id string) (sqh *StatQueueHistory, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetStatQueueHistoryDrv(tenant, id)
+}
+
+// SetStatQueueHistory stores the window snapshots of a StatQueue
+func (dm *DataManager) SetStatQueueHistory(sqh *StatQueueHistory) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().SetStatQueueHistoryDrv(sqh)
+}
+
+// RemoveStatQueueHistory removes the window snapshots of a StatQueue
+func (dm *DataManager) RemoveStatQueueHistory(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	var oldSqh *StatQueueHistory
+	if oldSqh, err = dm.GetStatQueueHistory(tenant, id); err != nil {
+		return
+	}
+	return dm.DataDB().RemoveStatQueueHistoryDrv(oldSqh.Tenant, oldSqh.ID)
+}
+
+// GetStoredSession returns the checkpoint of an active session
+func (dm *DataManager) GetStoredSession(tenant, id string) (ss *StoredSession, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetStoredSessionDrv(tenant, id)
+}
+
+// SetStoredSession stores the checkpoint of an active session
+func (dm *DataManager) SetStoredSession(ss *StoredSession) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().SetStoredSessionDrv(ss)
+}
+
+// RemoveStoredSession removes the checkpoint of a session
+func (dm *DataManager) RemoveStoredSession(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().RemoveStoredSessionDrv(tenant, id)
+}
@@ -188,6 +188,10 @@ func (expEv *ExportEvents) ReplayFailedPosts(attempts int) (failedEvents *Export
 	case utils.MetaS3jsonMap:
 		pstr = NewS3Poster(expEv.Path, attempts, expEv.Opts)
 		keyFunc = utils.UUIDSha1Prefix
+	case utils.MetaNatsjsonMap:
+		if pstr, err = NewNatsPoster(expEv.Path, attempts, expEv.Opts); err != nil {
+			return expEv, err
+		}
 	}
 	for _, ev := range expEv.Events {
 		if err = pstr.Post(ev.([]byte), keyFunc()); err != nil {
@@ -224,6 +224,7 @@ func NewRouteSortDispatcher(lcrS *RouteService) (rsd RouteSortDispatcher, err er
 	rsd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	rsd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	rsd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	rsd[utils.MetaLCQOS] = NewLeastCostQOSSorter(lcrS)
 	return
 }
 
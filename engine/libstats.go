@@ -41,7 +41,14 @@ type StatQueueProfile struct {
 	Stored             bool
 	Blocker            bool // blocker flag to stop processing on filters matched
 	Weight             float64
-	ThresholdIDs       []string // list of thresholds to be checked after changes
+	ThresholdIDs       []string      // list of thresholds to be checked after changes
+	GroupBy            string        // spawn one child queue for each distinct value of this field
+	GroupTTL           time.Duration // evict the child queues idle for longer than this
+	WindowType         string        // *tumbling or *sliding, snapshot the metrics at window boundaries
+	WindowLength       time.Duration // length of the windows, aligned to the UTC calendar
+	WindowHistory      int           // number of past window snapshots kept in DataDB
+
+	groupBy config.RSRParsers // compiled GroupBy
 }
 
 // StatQueueProfileWithOpts is used in replicatorV1 for dispatcher
@@ -60,6 +67,33 @@ func (sqp *StatQueueProfile) TenantID() string {
 	return utils.ConcatenatedKey(sqp.Tenant, sqp.ID)
 }
 
+// Compile will compile the GroupBy of the StatQueueProfile
+func (sqp *StatQueueProfile) Compile() (err error) {
+	if sqp.GroupBy == utils.EmptyString {
+		return
+	}
+	sqp.groupBy, err = config.NewRSRParsers(sqp.GroupBy, utils.InfieldSep)
+	return
+}
+
+// groupQueueID returns the ID of the child queue matching the event
+func (sqp *StatQueueProfile) groupQueueID(evNm utils.DataProvider) (sqID string, err error) {
+	grpBy := sqp.groupBy
+	if grpBy == nil { // profile not compiled
+		if grpBy, err = config.NewRSRParsers(sqp.GroupBy, utils.InfieldSep); err != nil {
+			return
+		}
+	}
+	var grpVal string
+	if grpVal, err = grpBy.ParseDataProvider(evNm); err != nil {
+		return
+	}
+	if grpVal == utils.EmptyString {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	return utils.ConcatenatedKey(sqp.ID, grpVal), nil
+}
+
 type MetricWithFilters struct {
 	FilterIDs []string
 	MetricID  string
@@ -72,8 +106,9 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 		ID:     sq.ID,
 		Compressed: sq.Compress(int64(config.CgrConfig().StatSCfg().StoreUncompressedLimit),
 			config.CgrConfig().GeneralCfg().RoundingDecimals),
-		SQItems:   make([]SQItem, len(sq.SQItems)),
-		SQMetrics: make(map[string][]byte, len(sq.SQMetrics)),
+		SQItems:     make([]SQItem, len(sq.SQItems)),
+		SQMetrics:   make(map[string][]byte, len(sq.SQMetrics)),
+		WindowStart: sq.WindowStart,
 	}
 	for i, sqItm := range sq.SQItems {
 		sSQ.SQItems[i] = sqItm
@@ -90,11 +125,12 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 
 // StoredStatQueue differs from StatQueue due to serialization of SQMetrics
 type StoredStatQueue struct {
-	Tenant     string
-	ID         string
-	SQItems    []SQItem
-	SQMetrics  map[string][]byte
-	Compressed bool
+	Tenant      string
+	ID          string
+	SQItems     []SQItem
+	SQMetrics   map[string][]byte
+	Compressed  bool
+	WindowStart *time.Time
 }
 
 type StoredStatQueueWithOpts struct {
@@ -113,10 +149,11 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 		return
 	}
 	sq = &StatQueue{
-		Tenant:    ssq.Tenant,
-		ID:        ssq.ID,
-		SQItems:   make([]SQItem, len(ssq.SQItems)),
-		SQMetrics: make(map[string]StatMetric, len(ssq.SQMetrics)),
+		Tenant:      ssq.Tenant,
+		ID:          ssq.ID,
+		SQItems:     make([]SQItem, len(ssq.SQItems)),
+		SQMetrics:   make(map[string]StatMetric, len(ssq.SQMetrics)),
+		WindowStart: ssq.WindowStart,
 	}
 	for i, sqItm := range ssq.SQItems {
 		sq.SQItems[i] = sqItm
@@ -159,14 +196,15 @@ func NewStatQueue(tnt, id string, metrics []*MetricWithFilters, minItems int) (s
 
 // StatQueue represents an individual stats instance
 type StatQueue struct {
-	lk        sync.RWMutex // protect the elements from within
-	Tenant    string
-	ID        string
-	SQItems   []SQItem
-	SQMetrics map[string]StatMetric
-	sqPrfl    *StatQueueProfile
-	dirty     *bool          // needs save
-	ttl       *time.Duration // timeToLeave, picked on each init
+	lk          sync.RWMutex // protect the elements from within
+	Tenant      string
+	ID          string
+	SQItems     []SQItem
+	SQMetrics   map[string]StatMetric
+	WindowStart *time.Time // start of the current window for windowed queues
+	sqPrfl      *StatQueueProfile
+	dirty       *bool          // needs save
+	ttl         *time.Duration // timeToLeave, picked on each init
 }
 
 // RLock only to implement sync.RWMutex methods
@@ -277,10 +315,31 @@ func (sq *StatQueue) addStatEvent(tnt, evID string, filterS *FilterS, evNm utils
 	return
 }
 
+// reset removes all the items from the queue and reinitializes the metrics
+func (sq *StatQueue) reset() (err error) {
+	sq.SQItems = make([]SQItem, 0)
+	metrics := sq.SQMetrics
+	sq.SQMetrics = make(map[string]StatMetric)
+	for id, m := range metrics {
+		var metric StatMetric
+		if metric, err = NewStatMetric(id,
+			m.GetMinItems(), m.GetFilterIDs()); err != nil {
+			return
+		}
+		sq.SQMetrics[id] = metric
+	}
+	return
+}
+
 func (sq *StatQueue) Compress(maxQL int64, roundDec int) bool {
 	if int64(len(sq.SQItems)) < maxQL || maxQL == 0 {
 		return false
 	}
+	for _, m := range sq.SQMetrics {
+		if _, isPrcnt := m.(*StatPercentile); isPrcnt { // needs all the values, cannot be compressed
+			return false
+		}
+	}
 	var newSQItems []SQItem
 	sqMap := make(map[string]*time.Time)
 	idMap := make(utils.StringSet)
@@ -346,3 +405,30 @@ type StatQueues []*StatQueue
 func (sis StatQueues) Sort() {
 	sort.Slice(sis, func(i, j int) bool { return sis[i].sqPrfl.Weight > sis[j].sqPrfl.Weight })
 }
+
+// StatQueueWindow is the snapshot of the metrics at the end of a window
+type StatQueueWindow struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Metrics   map[string]float64
+}
+
+// StatQueueHistory keeps the snapshots of the past windows of a StatQueue
+type StatQueueHistory struct {
+	Tenant  string
+	ID      string
+	Windows []*StatQueueWindow // ordered from the oldest to the newest
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (sqh *StatQueueHistory) TenantID() string {
+	return utils.ConcatenatedKey(sqh.Tenant, sqh.ID)
+}
+
+// AddWindow appends the window snapshot keeping only the last maxWindows
+func (sqh *StatQueueHistory) AddWindow(sqw *StatQueueWindow, maxWindows int) {
+	sqh.Windows = append(sqh.Windows, sqw)
+	if len(sqh.Windows) > maxWindows {
+		sqh.Windows = sqh.Windows[len(sqh.Windows)-maxWindows:]
+	}
+}
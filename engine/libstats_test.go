@@ -19,6 +19,7 @@ package engine
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -315,6 +316,37 @@ func TestStatCompress(t *testing.T) {
 	}
 }
 
+func TestStatCompressPercentile(t *testing.T) {
+	asr, _ := NewASR(0, utils.EmptyString, nil)
+	prcnt, _ := NewStatPercentile(0, "95#~*req.Cost", nil)
+	var sqItems []SQItem
+	for i := 0; i < 4; i++ {
+		evID := "cgrates.org:TestStatCompressPercentile_" + strconv.Itoa(i)
+		ev := utils.MapStorage{utils.MetaReq: map[string]interface{}{
+			utils.AnswerTime: time.Now(), "Cost": i}}
+		if err := asr.AddEvent(evID, ev); err != nil {
+			t.Fatal(err)
+		}
+		if err := prcnt.AddEvent(evID, ev); err != nil {
+			t.Fatal(err)
+		}
+		sqItems = append(sqItems, SQItem{EventID: evID})
+	}
+	sq := &StatQueue{
+		SQItems: sqItems,
+		SQMetrics: map[string]StatMetric{
+			utils.MetaASR:                asr,
+			utils.MetaPercentile + "#95": prcnt,
+		},
+	}
+	if sq.Compress(int64(2), config.CgrConfig().GeneralCfg().RoundingDecimals) {
+		t.Errorf("StatQueue compressed: %s", utils.ToJSON(sq))
+	}
+	if !reflect.DeepEqual(sq.SQItems, sqItems) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(sqItems), utils.ToJSON(sq.SQItems))
+	}
+}
+
 func TestStatCompress2(t *testing.T) {
 	asr := &StatASR{
 		Answered: 2,
@@ -693,3 +725,25 @@ func TestStatRemoveExpiredQueue(t *testing.T) {
 		t.Errorf("Expecting: 2, received: %+v", len(sq.SQItems))
 	}
 }
+
+func TestStatQueueProfileCompile(t *testing.T) {
+	sqp := &StatQueueProfile{Tenant: "cgrates.org", ID: "SQ1"}
+	if err := sqp.Compile(); err != nil {
+		t.Error(err)
+	} else if sqp.groupBy != nil {
+		t.Errorf("Expected no GroupBy compiled, received: %+v", sqp.groupBy)
+	}
+	sqp.GroupBy = "~*req.Account"
+	if err := sqp.Compile(); err != nil {
+		t.Error(err)
+	} else if sqID, err := sqp.groupQueueID(utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{utils.AccountField: "1001"}}); err != nil {
+		t.Error(err)
+	} else if sqID != "SQ1:1001" {
+		t.Errorf("Expected SQ1:1001, received: %s", sqID)
+	}
+	sqp.GroupBy = "~*req.Account{*"
+	if err := sqp.Compile(); err == nil {
+		t.Error("Expected error compiling an invalid GroupBy")
+	}
+}
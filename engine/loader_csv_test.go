@@ -975,6 +975,7 @@ func TestLoadResourceProfiles(t *testing.T) {
 			Stored:            true,
 			Weight:            10,
 			Limit:             "2",
+			RateWindow:        "1s",
 		},
 	}
 	resKey := utils.TenantID{Tenant: "cgrates.org", ID: "ResGroup21"}
@@ -983,6 +984,12 @@ func TestLoadResourceProfiles(t *testing.T) {
 	} else if !reflect.DeepEqual(eResProfiles[resKey], csvr.resProfiles[resKey]) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eResProfiles[resKey]), utils.ToJSON(csvr.resProfiles[resKey]))
 	}
+	resKey = utils.TenantID{Tenant: "cgrates.org", ID: "ResGroup22"}
+	if rcv := csvr.resProfiles[resKey]; rcv == nil {
+		t.Errorf("Failed to load ResourceProfile: %s", resKey.TenantID())
+	} else if rcv.RateWindow != eResProfiles[resKey].RateWindow {
+		t.Errorf("Expecting: %q, received: %q", eResProfiles[resKey].RateWindow, rcv.RateWindow)
+	}
 }
 
 func TestLoadStatQueueProfiles(t *testing.T) {
@@ -1008,11 +1015,14 @@ func TestLoadStatQueueProfiles(t *testing.T) {
 					MetricID: "*average#Value",
 				},
 			},
-			ThresholdIDs: []string{"Th1", "Th2"},
-			Blocker:      true,
-			Stored:       true,
-			Weight:       20,
-			MinItems:     2,
+			ThresholdIDs:  []string{"Th1", "Th2"},
+			Blocker:       true,
+			Stored:        true,
+			Weight:        20,
+			MinItems:      2,
+			WindowType:    utils.MetaTumbling,
+			WindowLength:  "1h",
+			WindowHistory: 24,
 		},
 		{Tenant: "cgrates.org", ID: "TestStats2"}: {
 			Tenant:    "cgrates.org",
@@ -1051,6 +1061,8 @@ func TestLoadStatQueueProfiles(t *testing.T) {
 			Stored:       true,
 			Weight:       20,
 			MinItems:     2,
+			GroupBy:      "~*req.Destination{*prefix:4}",
+			GroupTTL:     "1h",
 		},
 	}
 	stKeys := []utils.TenantID{
@@ -1079,6 +1091,18 @@ func TestLoadStatQueueProfiles(t *testing.T) {
 			t.Errorf("Expecting: %s, \n received: %s",
 				utils.ToJSON(eStats[stKey].Metrics),
 				utils.ToJSON(csvr.sqProfiles[stKey].Metrics))
+		} else if eStats[stKey].GroupBy != csvr.sqProfiles[stKey].GroupBy ||
+			eStats[stKey].GroupTTL != csvr.sqProfiles[stKey].GroupTTL {
+			t.Errorf("Expecting: %q, %q, received: %q, %q",
+				eStats[stKey].GroupBy, eStats[stKey].GroupTTL,
+				csvr.sqProfiles[stKey].GroupBy, csvr.sqProfiles[stKey].GroupTTL)
+		} else if eStats[stKey].WindowType != csvr.sqProfiles[stKey].WindowType ||
+			eStats[stKey].WindowLength != csvr.sqProfiles[stKey].WindowLength ||
+			eStats[stKey].WindowHistory != csvr.sqProfiles[stKey].WindowHistory {
+			t.Errorf("Expecting: %q, %q, %d, received: %q, %q, %d",
+				eStats[stKey].WindowType, eStats[stKey].WindowLength, eStats[stKey].WindowHistory,
+				csvr.sqProfiles[stKey].WindowType, csvr.sqProfiles[stKey].WindowLength,
+				csvr.sqProfiles[stKey].WindowHistory)
 		}
 	}
 }
@@ -1093,13 +1117,15 @@ func TestLoadThresholdProfiles(t *testing.T) {
 			ActivationInterval: &utils.TPActivationInterval{
 				ActivationTime: "2014-07-29T15:00:00Z",
 			},
-			MaxHits:   12,
-			MinHits:   10,
-			MinSleep:  "1s",
-			Blocker:   true,
-			Weight:    10,
-			ActionIDs: []string{"THRESH1"},
-			Async:     true,
+			MaxHits:           12,
+			MinHits:           10,
+			MinSleep:          "1s",
+			Blocker:           true,
+			Weight:            10,
+			ActionIDs:         []string{"THRESH1"},
+			Async:             true,
+			RecoveryFilterIDs: []string{"*gte:~*req.ASR:60"},
+			RecoveryActionIDs: []string{"THRESH_RECOVERED"},
 		},
 	}
 	eThresholdReverse := map[utils.TenantID]*utils.TPThresholdProfile{
@@ -1111,13 +1137,15 @@ func TestLoadThresholdProfiles(t *testing.T) {
 			ActivationInterval: &utils.TPActivationInterval{
 				ActivationTime: "2014-07-29T15:00:00Z",
 			},
-			MaxHits:   12,
-			MinHits:   10,
-			MinSleep:  "1s",
-			Blocker:   true,
-			Weight:    10,
-			ActionIDs: []string{"THRESH1"},
-			Async:     true,
+			MaxHits:           12,
+			MinHits:           10,
+			MinSleep:          "1s",
+			Blocker:           true,
+			Weight:            10,
+			ActionIDs:         []string{"THRESH1"},
+			Async:             true,
+			RecoveryFilterIDs: []string{"*gte:~*req.ASR:60"},
+			RecoveryActionIDs: []string{"THRESH_RECOVERED"},
 		},
 	}
 	thkey := utils.TenantID{Tenant: "cgrates.org", ID: "Threshold1"}
@@ -1259,7 +1287,9 @@ func TestLoadRouteProfiles(t *testing.T) {
 				RouteParameters: utils.EmptyString,
 			},
 		},
-		Weight: 20,
+		Weight:          20,
+		BreakerFailures: 3,
+		BreakerCoolDown: "1m",
 	}
 	sort.Slice(eSppProfile.Routes, func(i, j int) bool {
 		return strings.Compare(eSppProfile.Routes[i].ID+strings.Join(eSppProfile.Routes[i].FilterIDs, utils.ConcatenatedKeySep),
@@ -1411,6 +1441,9 @@ func TestLoadRateProfiles(t *testing.T) {
 		MinCost:         0.1,
 		MaxCost:         0.6,
 		MaxCostStrategy: "*free",
+		Currency:        "EUR",
+		VolumeCounter:   utils.MetaAccount,
+		VolumeReset:     "0 0 1 * *",
 		Rates: map[string]*utils.TPRate{
 			"RT_WEEK": {
 				ID:              "RT_WEEK",
@@ -1638,6 +1671,10 @@ func TestLoadAccountProfiles(t *testing.T) {
 				Weights: ";10",
 				Type:    utils.MetaVoice,
 				Units:   3600000000000,
+				ActivationInterval: &utils.TPActivationInterval{
+					ActivationTime: "2014-07-29T15:00:00Z",
+					ExpiryTime:     "2014-08-28T15:00:00Z",
+				},
 			},
 		},
 		ThresholdIDs: []string{utils.MetaNone},
@@ -1047,7 +1047,7 @@ type ResourceMdls []*ResourceMdl
 func (tps ResourceMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.UsageTTL, utils.Limit, utils.AllocationMessage, utils.Blocker, utils.Stored,
-		utils.Weight, utils.ThresholdIDs}
+		utils.Weight, utils.ThresholdIDs, utils.RateWindow}
 }
 
 func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
@@ -1078,6 +1078,9 @@ func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
 		if tp.AllocationMessage != utils.EmptyString {
 			rl.AllocationMessage = tp.AllocationMessage
 		}
+		if tp.RateWindow != utils.EmptyString {
+			rl.RateWindow = tp.RateWindow
+		}
 		rl.Blocker = tp.Blocker
 		rl.Stored = tp.Stored
 		if len(tp.ActivationInterval) != 0 {
@@ -1131,6 +1134,7 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			Weight:            rl.Weight,
 			Limit:             rl.Limit,
 			AllocationMessage: rl.AllocationMessage,
+			RateWindow:        rl.RateWindow,
 		}
 		if rl.ActivationInterval != nil {
 			if rl.ActivationInterval.ActivationTime != utils.EmptyString {
@@ -1161,6 +1165,7 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			mdl.Weight = rl.Weight
 			mdl.Limit = rl.Limit
 			mdl.AllocationMessage = rl.AllocationMessage
+			mdl.RateWindow = rl.RateWindow
 			if rl.ActivationInterval != nil {
 				if rl.ActivationInterval.ActivationTime != utils.EmptyString {
 					mdl.ActivationInterval = rl.ActivationInterval.ActivationTime
@@ -1198,6 +1203,11 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 			return nil, err
 		}
 	}
+	if tpRL.RateWindow != utils.EmptyString {
+		if rp.RateWindow, err = utils.ParseDurationWithNanosecs(tpRL.RateWindow); err != nil {
+			return nil, err
+		}
+	}
 	for i, fltr := range tpRL.FilterIDs {
 		rp.FilterIDs[i] = fltr
 	}
@@ -1233,6 +1243,9 @@ func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 	if rp.UsageTTL != time.Duration(0) {
 		tpRL.UsageTTL = rp.UsageTTL.String()
 	}
+	if rp.RateWindow != time.Duration(0) {
+		tpRL.RateWindow = rp.RateWindow.String()
+	}
 	for i, fli := range rp.FilterIDs {
 		tpRL.FilterIDs[i] = fli
 	}
@@ -1257,7 +1270,8 @@ type StatMdls []*StatMdl
 func (tps StatMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs,
+		utils.GroupBy, utils.GroupTTL, utils.WindowType, utils.WindowLength, utils.WindowHistory}
 }
 
 func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
@@ -1270,15 +1284,20 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		st, found := mst[key.TenantID()]
 		if !found {
 			st = &utils.TPStatProfile{
-				Tenant:      model.Tenant,
-				TPid:        model.Tpid,
-				ID:          model.ID,
-				Blocker:     model.Blocker,
-				Stored:      model.Stored,
-				Weight:      model.Weight,
-				MinItems:    model.MinItems,
-				TTL:         model.TTL,
-				QueueLength: model.QueueLength,
+				Tenant:        model.Tenant,
+				TPid:          model.Tpid,
+				ID:            model.ID,
+				Blocker:       model.Blocker,
+				Stored:        model.Stored,
+				Weight:        model.Weight,
+				MinItems:      model.MinItems,
+				TTL:           model.TTL,
+				QueueLength:   model.QueueLength,
+				GroupBy:       model.GroupBy,
+				GroupTTL:      model.GroupTTL,
+				WindowType:    model.WindowType,
+				WindowLength:  model.WindowLength,
+				WindowHistory: model.WindowHistory,
 			}
 		}
 		if model.Blocker {
@@ -1299,6 +1318,21 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		if model.QueueLength != 0 {
 			st.QueueLength = model.QueueLength
 		}
+		if model.GroupBy != utils.EmptyString {
+			st.GroupBy = model.GroupBy
+		}
+		if model.GroupTTL != utils.EmptyString {
+			st.GroupTTL = model.GroupTTL
+		}
+		if model.WindowType != utils.EmptyString {
+			st.WindowType = model.WindowType
+		}
+		if model.WindowLength != utils.EmptyString {
+			st.WindowLength = model.WindowLength
+		}
+		if model.WindowHistory != 0 {
+			st.WindowHistory = model.WindowHistory
+		}
 		if model.ThresholdIDs != utils.EmptyString {
 			if _, has := thresholdMap[key.TenantID()]; !has {
 				thresholdMap[key.TenantID()] = make(utils.StringSet)
@@ -1391,6 +1425,11 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls StatMdls) {
 					}
 					mdl.ThresholdIDs += val
 				}
+				mdl.GroupBy = st.GroupBy
+				mdl.GroupTTL = st.GroupTTL
+				mdl.WindowType = st.WindowType
+				mdl.WindowLength = st.WindowLength
+				mdl.WindowHistory = st.WindowHistory
 			}
 			for i, val := range metric.FilterIDs {
 				if i != 0 {
@@ -1407,22 +1446,35 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls StatMdls) {
 
 func APItoStats(tpST *utils.TPStatProfile, timezone string) (st *StatQueueProfile, err error) {
 	st = &StatQueueProfile{
-		Tenant:       tpST.Tenant,
-		ID:           tpST.ID,
-		FilterIDs:    make([]string, len(tpST.FilterIDs)),
-		QueueLength:  tpST.QueueLength,
-		MinItems:     tpST.MinItems,
-		Metrics:      make([]*MetricWithFilters, len(tpST.Metrics)),
-		Stored:       tpST.Stored,
-		Blocker:      tpST.Blocker,
-		Weight:       tpST.Weight,
-		ThresholdIDs: make([]string, len(tpST.ThresholdIDs)),
+		Tenant:        tpST.Tenant,
+		ID:            tpST.ID,
+		FilterIDs:     make([]string, len(tpST.FilterIDs)),
+		QueueLength:   tpST.QueueLength,
+		MinItems:      tpST.MinItems,
+		Metrics:       make([]*MetricWithFilters, len(tpST.Metrics)),
+		Stored:        tpST.Stored,
+		Blocker:       tpST.Blocker,
+		Weight:        tpST.Weight,
+		ThresholdIDs:  make([]string, len(tpST.ThresholdIDs)),
+		GroupBy:       tpST.GroupBy,
+		WindowType:    tpST.WindowType,
+		WindowHistory: tpST.WindowHistory,
 	}
 	if tpST.TTL != utils.EmptyString {
 		if st.TTL, err = utils.ParseDurationWithNanosecs(tpST.TTL); err != nil {
 			return nil, err
 		}
 	}
+	if tpST.GroupTTL != utils.EmptyString {
+		if st.GroupTTL, err = utils.ParseDurationWithNanosecs(tpST.GroupTTL); err != nil {
+			return nil, err
+		}
+	}
+	if tpST.WindowLength != utils.EmptyString {
+		if st.WindowLength, err = utils.ParseDurationWithNanosecs(tpST.WindowLength); err != nil {
+			return nil, err
+		}
+	}
 	for i, metric := range tpST.Metrics {
 		st.Metrics[i] = &MetricWithFilters{
 			MetricID:  metric.MetricID,
@@ -1456,6 +1508,9 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 		Weight:             st.Weight,
 		MinItems:           st.MinItems,
 		ThresholdIDs:       make([]string, len(st.ThresholdIDs)),
+		GroupBy:            st.GroupBy,
+		WindowType:         st.WindowType,
+		WindowHistory:      st.WindowHistory,
 	}
 	for i, metric := range st.Metrics {
 		tpST.Metrics[i] = &utils.MetricWithFilters{
@@ -1472,6 +1527,12 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 	if st.TTL != time.Duration(0) {
 		tpST.TTL = st.TTL.String()
 	}
+	if st.GroupTTL != time.Duration(0) {
+		tpST.GroupTTL = st.GroupTTL.String()
+	}
+	if st.WindowLength != time.Duration(0) {
+		tpST.WindowLength = st.WindowLength.String()
+	}
 	for i, fli := range st.FilterIDs {
 		tpST.FilterIDs[i] = fli
 	}
@@ -1496,13 +1557,16 @@ type ThresholdMdls []*ThresholdMdl
 func (tps ThresholdMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async,
+		utils.RecoveryFilterIDs, utils.RecoveryActionIDs}
 }
 
 func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 	mst := make(map[string]*utils.TPThresholdProfile)
 	filterMap := make(map[string]utils.StringSet)
 	actionMap := make(map[string]utils.StringSet)
+	recFilterMap := make(map[string]utils.StringSet)
+	recActionMap := make(map[string]utils.StringSet)
 	for _, tp := range tps {
 		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
 		th, found := mst[tenID]
@@ -1543,6 +1607,18 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 			}
 			filterMap[tenID].AddSlice(strings.Split(tp.FilterIDs, utils.InfieldSep))
 		}
+		if tp.RecoveryFilterIDs != utils.EmptyString {
+			if _, has := recFilterMap[tenID]; !has {
+				recFilterMap[tenID] = make(utils.StringSet)
+			}
+			recFilterMap[tenID].AddSlice(strings.Split(tp.RecoveryFilterIDs, utils.InfieldSep))
+		}
+		if tp.RecoveryActionIDs != utils.EmptyString {
+			if _, has := recActionMap[tenID]; !has {
+				recActionMap[tenID] = make(utils.StringSet)
+			}
+			recActionMap[tenID].AddSlice(strings.Split(tp.RecoveryActionIDs, utils.InfieldSep))
+		}
 
 		mst[tenID] = th
 	}
@@ -1552,6 +1628,12 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 		result[i] = th
 		result[i].FilterIDs = filterMap[tntID].AsSlice()
 		result[i].ActionIDs = actionMap[tntID].AsSlice()
+		if recFltrs, has := recFilterMap[tntID]; has {
+			result[i].RecoveryFilterIDs = recFltrs.AsSlice()
+		}
+		if recActs, has := recActionMap[tntID]; has {
+			result[i].RecoveryActionIDs = recActs.AsSlice()
+		}
 		i++
 	}
 	return
@@ -1579,6 +1661,8 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls ThresholdMdls) {
 				mdl.MinHits = th.MinHits
 				mdl.MinSleep = th.MinSleep
 				mdl.Async = th.Async
+				mdl.RecoveryFilterIDs = strings.Join(th.RecoveryFilterIDs, utils.InfieldSep)
+				mdl.RecoveryActionIDs = strings.Join(th.RecoveryActionIDs, utils.InfieldSep)
 				if th.ActivationInterval != nil {
 					if th.ActivationInterval.ActivationTime != utils.EmptyString {
 						mdl.ActivationInterval = th.ActivationInterval.ActivationTime
@@ -1618,6 +1702,8 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls ThresholdMdls) {
 					mdl.MinHits = th.MinHits
 					mdl.MinSleep = th.MinSleep
 					mdl.Async = th.Async
+					mdl.RecoveryFilterIDs = strings.Join(th.RecoveryFilterIDs, utils.InfieldSep)
+					mdl.RecoveryActionIDs = strings.Join(th.RecoveryActionIDs, utils.InfieldSep)
 					if th.ActivationInterval != nil {
 						if th.ActivationInterval.ActivationTime != utils.EmptyString {
 							mdl.ActivationInterval = th.ActivationInterval.ActivationTime
@@ -1659,6 +1745,14 @@ func APItoThresholdProfile(tpTH *utils.TPThresholdProfile, timezone string) (th
 	for i, fli := range tpTH.FilterIDs {
 		th.FilterIDs[i] = fli
 	}
+	if len(tpTH.RecoveryFilterIDs) != 0 {
+		th.RecoveryFilterIDs = make([]string, len(tpTH.RecoveryFilterIDs))
+		copy(th.RecoveryFilterIDs, tpTH.RecoveryFilterIDs)
+	}
+	if len(tpTH.RecoveryActionIDs) != 0 {
+		th.RecoveryActionIDs = make([]string, len(tpTH.RecoveryActionIDs))
+		copy(th.RecoveryActionIDs, tpTH.RecoveryActionIDs)
+	}
 	if tpTH.ActivationInterval != nil {
 		if th.ActivationInterval, err = tpTH.ActivationInterval.AsActivationInterval(timezone); err != nil {
 			return nil, err
@@ -1689,6 +1783,14 @@ func ThresholdProfileToAPI(th *ThresholdProfile) (tpTH *utils.TPThresholdProfile
 	for i, fli := range th.ActionIDs {
 		tpTH.ActionIDs[i] = fli
 	}
+	if len(th.RecoveryFilterIDs) != 0 {
+		tpTH.RecoveryFilterIDs = make([]string, len(th.RecoveryFilterIDs))
+		copy(tpTH.RecoveryFilterIDs, th.RecoveryFilterIDs)
+	}
+	if len(th.RecoveryActionIDs) != 0 {
+		tpTH.RecoveryActionIDs = make([]string, len(th.RecoveryActionIDs))
+		copy(tpTH.RecoveryActionIDs, th.RecoveryActionIDs)
+	}
 
 	if th.ActivationInterval != nil {
 		if !th.ActivationInterval.ActivationTime.IsZero() {
@@ -1839,7 +1941,7 @@ func (tps RouteMdls) CSVHeader() (result []string) {
 		utils.Sorting, utils.SortingParameters, utils.RouteID, utils.RouteFilterIDs,
 		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteRateProfileIDs, utils.RouteResourceIDs,
 		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker,
-		utils.RouteParameters, utils.Weight,
+		utils.RouteParameters, utils.Weight, utils.BreakerFailures, utils.BreakerCoolDown,
 	}
 }
 
@@ -1911,6 +2013,12 @@ func (tps RouteMdls) AsTPRouteProfile() (result []*utils.TPRouteProfile) {
 		if tp.Weight != 0 {
 			th.Weight = tp.Weight
 		}
+		if tp.BreakerFailures != 0 {
+			th.BreakerFailures = tp.BreakerFailures
+		}
+		if tp.BreakerCoolDown != utils.EmptyString {
+			th.BreakerCoolDown = tp.BreakerCoolDown
+		}
 		if tp.ActivationInterval != utils.EmptyString {
 			th.ActivationInterval = new(utils.TPActivationInterval)
 			aiSplt := strings.Split(tp.ActivationInterval, utils.InfieldSep)
@@ -1956,6 +2064,8 @@ func APItoModelTPRoutes(st *utils.TPRouteProfile) (mdls RouteMdls) {
 		if i == 0 {
 			mdl.Sorting = st.Sorting
 			mdl.Weight = st.Weight
+			mdl.BreakerFailures = st.BreakerFailures
+			mdl.BreakerCoolDown = st.BreakerCoolDown
 			for i, val := range st.FilterIDs {
 				if i != 0 {
 					mdl.FilterIDs += utils.InfieldSep
@@ -2022,10 +2132,16 @@ func APItoRouteProfile(tpRp *utils.TPRouteProfile, timezone string) (rp *RoutePr
 		ID:                tpRp.ID,
 		Sorting:           tpRp.Sorting,
 		Weight:            tpRp.Weight,
+		BreakerFailures:   tpRp.BreakerFailures,
 		Routes:            make([]*Route, len(tpRp.Routes)),
 		SortingParameters: make([]string, len(tpRp.SortingParameters)),
 		FilterIDs:         make([]string, len(tpRp.FilterIDs)),
 	}
+	if tpRp.BreakerCoolDown != utils.EmptyString {
+		if rp.BreakerCoolDown, err = utils.ParseDurationWithNanosecs(tpRp.BreakerCoolDown); err != nil {
+			return nil, err
+		}
+	}
 	for i, stp := range tpRp.SortingParameters {
 		rp.SortingParameters[i] = stp
 	}
@@ -2063,6 +2179,10 @@ func RouteProfileToAPI(rp *RouteProfile) (tpRp *utils.TPRouteProfile) {
 		SortingParameters:  make([]string, len(rp.SortingParameters)),
 		Routes:             make([]*utils.TPRoute, len(rp.Routes)),
 		Weight:             rp.Weight,
+		BreakerFailures:    rp.BreakerFailures,
+	}
+	if rp.BreakerCoolDown != time.Duration(0) {
+		tpRp.BreakerCoolDown = rp.BreakerCoolDown.String()
 	}
 
 	for i, route := range rp.Routes {
@@ -2900,6 +3020,7 @@ func (tps RateProfileMdls) CSVHeader() (result []string) {
 		utils.MaxCost, utils.MaxCostStrategy, utils.RateID,
 		utils.RateFilterIDs, utils.RateActivationStart, utils.RateWeight, utils.RateBlocker,
 		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement,
+		utils.Currency, utils.VolumeCounter, utils.VolumeReset,
 	}
 }
 
@@ -2972,6 +3093,15 @@ func (tps RateProfileMdls) AsTPRateProfile() (result []*utils.TPRateProfile) {
 		if tp.MaxCostStrategy != utils.EmptyString {
 			rPrf.MaxCostStrategy = tp.MaxCostStrategy
 		}
+		if tp.Currency != utils.EmptyString {
+			rPrf.Currency = tp.Currency
+		}
+		if tp.VolumeCounter != utils.EmptyString {
+			rPrf.VolumeCounter = tp.VolumeCounter
+		}
+		if tp.VolumeReset != utils.EmptyString {
+			rPrf.VolumeReset = tp.VolumeReset
+		}
 		if tp.ActivationInterval != utils.EmptyString {
 			rPrf.ActivationInterval = new(utils.TPActivationInterval)
 			aiSplt := strings.Split(tp.ActivationInterval, utils.InfieldSep)
@@ -3033,6 +3163,9 @@ func APItoModelTPRateProfile(tPrf *utils.TPRateProfile) (mdls RateProfileMdls) {
 				mdl.MinCost = tPrf.MinCost
 				mdl.MaxCost = tPrf.MaxCost
 				mdl.MaxCostStrategy = tPrf.MaxCostStrategy
+				mdl.Currency = tPrf.Currency
+				mdl.VolumeCounter = tPrf.VolumeCounter
+				mdl.VolumeReset = tPrf.VolumeReset
 			}
 			mdl.RateID = rate.ID
 			if j == 0 {
@@ -3066,6 +3199,9 @@ func APItoRateProfile(tpRp *utils.TPRateProfile, timezone string) (rp *RateProfi
 		ID:              tpRp.ID,
 		FilterIDs:       make([]string, len(tpRp.FilterIDs)),
 		MaxCostStrategy: tpRp.MaxCostStrategy,
+		Currency:        tpRp.Currency,
+		VolumeCounter:   tpRp.VolumeCounter,
+		VolumeReset:     tpRp.VolumeReset,
 		Rates:           make(map[string]*Rate),
 		MinCost:         utils.NewDecimalFromFloat64(tpRp.MinCost),
 		MaxCost:         utils.NewDecimalFromFloat64(tpRp.MaxCost),
@@ -3126,6 +3262,9 @@ func RateProfileToAPI(rp *RateProfile) (tpRp *utils.TPRateProfile) {
 		ActivationInterval: new(utils.TPActivationInterval),
 		Weights:            rp.Weights.String(";", "&"),
 		MaxCostStrategy:    rp.MaxCostStrategy,
+		Currency:           rp.Currency,
+		VolumeCounter:      rp.VolumeCounter,
+		VolumeReset:        rp.VolumeReset,
 		Rates:              make(map[string]*utils.TPRate),
 	}
 	if rp.MinCost != nil {
@@ -3192,6 +3331,7 @@ func (apm ActionProfileMdls) CSVHeader() (result []string) {
 		utils.ActivationIntervalString, utils.Weight, utils.Schedule, utils.TargetType,
 		utils.TargetIDs, utils.ActionID, utils.ActionFilterIDs, utils.ActionBlocker, utils.ActionTTL,
 		utils.ActionType, utils.ActionOpts, utils.ActionPath, utils.ActionValue,
+		utils.MissedRuns,
 	}
 }
 
@@ -3231,6 +3371,9 @@ func (tps ActionProfileMdls) AsTPActionProfile() (result []*utils.TPActionProfil
 		if tp.Schedule != utils.EmptyString {
 			aPrf.Schedule = tp.Schedule
 		}
+		if tp.MissedRuns != utils.EmptyString {
+			aPrf.MissedRuns = tp.MissedRuns
+		}
 		if tp.TargetType != utils.EmptyString {
 			if _, has := targetIDsMap[tenID]; !has {
 				targetIDsMap[tenID] = make(map[string]utils.StringSet)
@@ -3299,6 +3442,7 @@ func APItoModelTPActionProfile(tPrf *utils.TPActionProfile) (mdls ActionProfileM
 			}
 			mdl.Weight = tPrf.Weight
 			mdl.Schedule = tPrf.Schedule
+			mdl.MissedRuns = tPrf.MissedRuns
 			for _, target := range tPrf.Targets {
 				mdl.TargetType = target.TargetType
 				mdl.TargetIDs = strings.Join(target.TargetIDs, utils.InfieldSep)
@@ -3325,13 +3469,14 @@ func APItoModelTPActionProfile(tPrf *utils.TPActionProfile) (mdls ActionProfileM
 
 func APItoActionProfile(tpAp *utils.TPActionProfile, timezone string) (ap *ActionProfile, err error) {
 	ap = &ActionProfile{
-		Tenant:    tpAp.Tenant,
-		ID:        tpAp.ID,
-		FilterIDs: make([]string, len(tpAp.FilterIDs)),
-		Weight:    tpAp.Weight,
-		Schedule:  tpAp.Schedule,
-		Targets:   make(map[string]utils.StringSet),
-		Actions:   make([]*APAction, len(tpAp.Actions)),
+		Tenant:     tpAp.Tenant,
+		ID:         tpAp.ID,
+		FilterIDs:  make([]string, len(tpAp.FilterIDs)),
+		Weight:     tpAp.Weight,
+		Schedule:   tpAp.Schedule,
+		MissedRuns: tpAp.MissedRuns,
+		Targets:    make(map[string]utils.StringSet),
+		Actions:    make([]*APAction, len(tpAp.Actions)),
 	}
 	for i, stp := range tpAp.FilterIDs {
 		ap.FilterIDs[i] = stp
@@ -3385,6 +3530,7 @@ func ActionProfileToAPI(ap *ActionProfile) (tpAp *utils.TPActionProfile) {
 		ActivationInterval: new(utils.TPActivationInterval),
 		Weight:             ap.Weight,
 		Schedule:           ap.Schedule,
+		MissedRuns:         ap.MissedRuns,
 		Targets:            make([]*utils.TPActionTarget, 0, len(ap.Targets)),
 		Actions:            make([]*utils.TPAPAction, len(ap.Actions)),
 	}
@@ -3430,6 +3576,7 @@ func (apm AccountProfileMdls) CSVHeader() (result []string) {
 		utils.ActivationIntervalString, utils.Weight, utils.BalanceID,
 		utils.BalanceFilterIDs, utils.BalanceWeight, utils.BalanceBlocker,
 		utils.BalanceType, utils.BalanceOpts, utils.BalanceUnits, utils.ThresholdIDs,
+		utils.BalanceActivationInterval,
 	}
 }
 
@@ -3526,6 +3673,16 @@ func (tps AccountProfileMdls) AsTPAccountProfile() (result []*utils.TPAccountPro
 				}
 				aPrf.Balances[tp.BalanceID].UnitFactors = unitFactors
 			}
+			if tp.BalanceActivationInterval != utils.EmptyString {
+				aPrf.Balances[tp.BalanceID].ActivationInterval = new(utils.TPActivationInterval)
+				aiSplt := strings.Split(tp.BalanceActivationInterval, utils.InfieldSep)
+				if len(aiSplt) == 2 {
+					aPrf.Balances[tp.BalanceID].ActivationInterval.ActivationTime = aiSplt[0]
+					aPrf.Balances[tp.BalanceID].ActivationInterval.ExpiryTime = aiSplt[1]
+				} else if len(aiSplt) == 1 {
+					aPrf.Balances[tp.BalanceID].ActivationInterval.ActivationTime = aiSplt[0]
+				}
+			}
 
 		}
 		actPrfMap[tenID] = aPrf
@@ -3610,6 +3767,14 @@ func APItoModelTPAccountProfile(tPrf *utils.TPAccountProfile) (mdls AccountProfi
 			mdl.BalanceUnitFactors += unitFactor.AsString()
 		}
 		mdl.BalanceUnits = balance.Units
+		if balance.ActivationInterval != nil {
+			if balance.ActivationInterval.ActivationTime != utils.EmptyString {
+				mdl.BalanceActivationInterval = balance.ActivationInterval.ActivationTime
+			}
+			if balance.ActivationInterval.ExpiryTime != utils.EmptyString {
+				mdl.BalanceActivationInterval += utils.InfieldSep + balance.ActivationInterval.ExpiryTime
+			}
+		}
 		mdls = append(mdls, mdl)
 		i++
 	}
@@ -3654,6 +3819,11 @@ func APItoAccountProfile(tpAp *utils.TPAccountProfile, timezone string) (ap *uti
 			}
 			ap.Balances[id].Weights = weight
 		}
+		if bal.ActivationInterval != nil {
+			if ap.Balances[id].ActivationInterval, err = bal.ActivationInterval.AsActivationInterval(timezone); err != nil {
+				return nil, err
+			}
+		}
 		if bal.UnitFactors != nil {
 			ap.Balances[id].UnitFactors = make([]*utils.UnitFactor, len(bal.UnitFactors))
 			for j, unitFactor := range bal.UnitFactors {
@@ -3746,6 +3916,15 @@ func AccountProfileToAPI(ap *utils.AccountProfile) (tpAp *utils.TPAccountProfile
 		for k, fli := range bal.FilterIDs {
 			tpAp.Balances[i].FilterIDs[k] = fli
 		}
+		if bal.ActivationInterval != nil {
+			tpAp.Balances[i].ActivationInterval = new(utils.TPActivationInterval)
+			if !bal.ActivationInterval.ActivationTime.IsZero() {
+				tpAp.Balances[i].ActivationInterval.ActivationTime = bal.ActivationInterval.ActivationTime.Format(time.RFC3339)
+			}
+			if !bal.ActivationInterval.ExpiryTime.IsZero() {
+				tpAp.Balances[i].ActivationInterval.ExpiryTime = bal.ActivationInterval.ExpiryTime.Format(time.RFC3339)
+			}
+		}
 		//there should not be an invalid value of converting into float64
 		tpAp.Balances[i].Units, _ = bal.Units.Float64()
 		elems := make([]string, 0, len(bal.Opts))
@@ -2268,7 +2268,7 @@ func TestAPItoModelAccountActions(t *testing.T) {
 func TestCSVHeader(t *testing.T) {
 	var tps ResourceMdls
 	eOut := []string{
-		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs",
+		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs", "RateWindow",
 	}
 	if rcv := tps.CSVHeader(); !reflect.DeepEqual(eOut, rcv) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eOut), utils.ToJSON(rcv))
@@ -2536,6 +2536,357 @@ func TestThresholdProfileToAPI(t *testing.T) {
 	}
 }
 
+func TestResourceRateWindowModelConversions(t *testing.T) {
+	tpRes := &utils.TPResourceProfile{
+		TPid:       testTPID,
+		Tenant:     "cgrates.org",
+		ID:         "RES_CPS",
+		FilterIDs:  []string{"*string:~*req.Account:1001"},
+		Limit:      "10",
+		RateWindow: "1s",
+	}
+	mdls := APItoModelResource(tpRes)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].RateWindow != tpRes.RateWindow {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPResources(); len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if rcv[0].RateWindow != tpRes.RateWindow {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	rp, err := APItoResource(tpRes, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if rp.RateWindow != time.Second {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(rp))
+	}
+	if rcv := ResourceProfileToAPI(rp); rcv.RateWindow != tpRes.RateWindow {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+	tpRes.RateWindow = "notADuration"
+	if _, err = APItoResource(tpRes, "UTC"); err == nil {
+		t.Error("Expected error for invalid RateWindow")
+	}
+}
+
+func TestRouteBreakerModelConversions(t *testing.T) {
+	tpRp := &utils.TPRouteProfile{
+		TPid:              testTPID,
+		Tenant:            "cgrates.org",
+		ID:                "ROUTE_BREAKER",
+		FilterIDs:         []string{"*string:~*req.Account:1001"},
+		Sorting:           utils.MetaWeight,
+		SortingParameters: []string{},
+		Routes: []*utils.TPRoute{
+			{ID: "route1", Weight: 10},
+		},
+		BreakerFailures: 3,
+		BreakerCoolDown: "1m0s",
+	}
+	mdls := APItoModelTPRoutes(tpRp)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].BreakerFailures != tpRp.BreakerFailures ||
+		mdls[0].BreakerCoolDown != tpRp.BreakerCoolDown {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPRouteProfile(); len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if rcv[0].BreakerFailures != tpRp.BreakerFailures ||
+		rcv[0].BreakerCoolDown != tpRp.BreakerCoolDown {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	rp, err := APItoRouteProfile(tpRp, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if rp.BreakerFailures != 3 || rp.BreakerCoolDown != time.Minute {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(rp))
+	}
+	if rcv := RouteProfileToAPI(rp); rcv.BreakerFailures != tpRp.BreakerFailures ||
+		rcv.BreakerCoolDown != tpRp.BreakerCoolDown {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+	tpRp.BreakerCoolDown = "notADuration"
+	if _, err = APItoRouteProfile(tpRp, "UTC"); err == nil {
+		t.Error("Expected error for invalid BreakerCoolDown")
+	}
+}
+
+func TestStatGroupByModelConversions(t *testing.T) {
+	tpST := &utils.TPStatProfile{
+		TPid:     testTPID,
+		Tenant:   "cgrates.org",
+		ID:       "SQ_PREFIX",
+		Metrics:  []*utils.MetricWithFilters{{MetricID: utils.MetaASR}},
+		GroupBy:  "~*req.Destination{*prefix:4}",
+		GroupTTL: "1h0m0s",
+	}
+	mdls := APItoModelStats(tpST)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].GroupBy != tpST.GroupBy || mdls[0].GroupTTL != tpST.GroupTTL {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPStats(); len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if rcv[0].GroupBy != tpST.GroupBy || rcv[0].GroupTTL != tpST.GroupTTL {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	sqp, err := APItoStats(tpST, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if sqp.GroupBy != tpST.GroupBy || sqp.GroupTTL != time.Hour {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(sqp))
+	}
+	if rcv := StatQueueProfileToAPI(sqp); rcv.GroupBy != tpST.GroupBy || rcv.GroupTTL != tpST.GroupTTL {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+	tpST.GroupTTL = "notADuration"
+	if _, err = APItoStats(tpST, "UTC"); err == nil {
+		t.Error("Expected error for invalid GroupTTL")
+	}
+}
+
+func TestAccountBalanceActivationModelConversions(t *testing.T) {
+	tpAcc := &utils.TPAccountProfile{
+		TPid:   testTPID,
+		Tenant: "cgrates.org",
+		ID:     "ACC_BUNDLE",
+		Balances: map[string]*utils.TPAccountBalance{
+			"Bundle30d": {
+				ID:    "Bundle30d",
+				Type:  utils.MetaVoice,
+				Units: 3600,
+				ActivationInterval: &utils.TPActivationInterval{
+					ActivationTime: "2021-03-01T00:00:00Z",
+					ExpiryTime:     "2021-03-31T00:00:00Z",
+				},
+			},
+		},
+	}
+	mdls := APItoModelTPAccountProfile(tpAcc)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if exp := "2021-03-01T00:00:00Z;2021-03-31T00:00:00Z"; mdls[0].BalanceActivationInterval != exp {
+		t.Errorf("Expecting: %q, received: %q", exp, mdls[0].BalanceActivationInterval)
+	}
+	if rcv, err := mdls.AsTPAccountProfile(); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if !reflect.DeepEqual(tpAcc.Balances["Bundle30d"].ActivationInterval,
+		rcv[0].Balances["Bundle30d"].ActivationInterval) {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	acc, err := APItoAccountProfile(tpAcc, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expAI := &utils.ActivationInterval{
+		ActivationTime: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		ExpiryTime:     time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(expAI, acc.Balances["Bundle30d"].ActivationInterval) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(expAI),
+			utils.ToJSON(acc.Balances["Bundle30d"].ActivationInterval))
+	}
+	if rcv := AccountProfileToAPI(acc); !reflect.DeepEqual(tpAcc.Balances["Bundle30d"].ActivationInterval,
+		rcv.Balances["Bundle30d"].ActivationInterval) {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+	tpAcc.Balances["Bundle30d"].ActivationInterval.ExpiryTime = "notATime"
+	if _, err = APItoAccountProfile(tpAcc, "UTC"); err == nil {
+		t.Error("Expected error for invalid balance ActivationInterval")
+	}
+}
+
+func TestStatWindowModelConversions(t *testing.T) {
+	tpST := &utils.TPStatProfile{
+		TPid:          testTPID,
+		Tenant:        "cgrates.org",
+		ID:            "SQ_WINDOWS",
+		Metrics:       []*utils.MetricWithFilters{{MetricID: utils.MetaASR}},
+		WindowType:    utils.MetaTumbling,
+		WindowLength:  "1h0m0s",
+		WindowHistory: 24,
+	}
+	mdls := APItoModelStats(tpST)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].WindowType != tpST.WindowType || mdls[0].WindowLength != tpST.WindowLength ||
+		mdls[0].WindowHistory != tpST.WindowHistory {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPStats(); len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if rcv[0].WindowType != tpST.WindowType || rcv[0].WindowLength != tpST.WindowLength ||
+		rcv[0].WindowHistory != tpST.WindowHistory {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	sqp, err := APItoStats(tpST, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if sqp.WindowType != tpST.WindowType || sqp.WindowLength != time.Hour ||
+		sqp.WindowHistory != tpST.WindowHistory {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(sqp))
+	}
+	if rcv := StatQueueProfileToAPI(sqp); rcv.WindowType != tpST.WindowType ||
+		rcv.WindowLength != tpST.WindowLength || rcv.WindowHistory != tpST.WindowHistory {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+	tpST.WindowLength = "notADuration"
+	if _, err = APItoStats(tpST, "UTC"); err == nil {
+		t.Error("Expected error for invalid WindowLength")
+	}
+}
+
+func TestRateProfileCurrencyModelConversions(t *testing.T) {
+	tpRp := &utils.TPRateProfile{
+		TPid:     testTPID,
+		Tenant:   "cgrates.org",
+		ID:       "RP_EUR",
+		Currency: "EUR",
+		Rates: map[string]*utils.TPRate{
+			"RT_ALWAYS": {
+				ID:            "RT_ALWAYS",
+				IntervalRates: []*utils.TPIntervalRate{{IntervalStart: "0s", Unit: "1m", Increment: "1s"}},
+			},
+		},
+	}
+	mdls := APItoModelTPRateProfile(tpRp)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].Currency != tpRp.Currency {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPRateProfile(); len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if rcv[0].Currency != tpRp.Currency {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	rp, err := APItoRateProfile(tpRp, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if rp.Currency != tpRp.Currency {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(rp))
+	}
+	if rcv := RateProfileToAPI(rp); rcv.Currency != tpRp.Currency {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestActionProfileMissedRunsModelConversions(t *testing.T) {
+	tpAp := &utils.TPActionProfile{
+		TPid:       testTPID,
+		Tenant:     "cgrates.org",
+		ID:         "AP_MONTHLY",
+		Schedule:   "0 0 1 * *",
+		MissedRuns: utils.MetaRunOnce,
+		Actions: []*utils.TPAPAction{{
+			ID:    "TOPUP",
+			TTL:   "0s",
+			Type:  utils.MetaTopUp,
+			Path:  "~*balance.TestBalance.Value",
+			Value: "10",
+		}},
+	}
+	mdls := APItoModelTPActionProfile(tpAp)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].MissedRuns != tpAp.MissedRuns {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPActionProfile(); len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if rcv[0].MissedRuns != tpAp.MissedRuns {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	ap, err := APItoActionProfile(tpAp, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if ap.MissedRuns != tpAp.MissedRuns {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(ap))
+	}
+	if rcv := ActionProfileToAPI(ap); rcv.MissedRuns != tpAp.MissedRuns {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestRateProfileVolumeModelConversions(t *testing.T) {
+	tpRp := &utils.TPRateProfile{
+		TPid:          testTPID,
+		Tenant:        "cgrates.org",
+		ID:            "RP_VOLUME",
+		VolumeCounter: utils.MetaAccount,
+		VolumeReset:   "0 0 1 * *",
+		Rates: map[string]*utils.TPRate{
+			"RT_ALWAYS": {
+				ID:            "RT_ALWAYS",
+				IntervalRates: []*utils.TPIntervalRate{{IntervalStart: "0s", Unit: "1m", Increment: "1s"}},
+			},
+		},
+	}
+	mdls := APItoModelTPRateProfile(tpRp)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].VolumeCounter != tpRp.VolumeCounter || mdls[0].VolumeReset != tpRp.VolumeReset {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPRateProfile(); len(rcv) != 1 {
+		t.Errorf("Expecting 1 profile, received: %s", utils.ToJSON(rcv))
+	} else if rcv[0].VolumeCounter != tpRp.VolumeCounter || rcv[0].VolumeReset != tpRp.VolumeReset {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv[0]))
+	}
+	rp, err := APItoRateProfile(tpRp, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if rp.VolumeCounter != tpRp.VolumeCounter || rp.VolumeReset != tpRp.VolumeReset {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(rp))
+	}
+	if rcv := RateProfileToAPI(rp); rcv.VolumeCounter != tpRp.VolumeCounter || rcv.VolumeReset != tpRp.VolumeReset {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestThresholdRecoveryModelConversions(t *testing.T) {
+	tpTH := &utils.TPThresholdProfile{
+		TPid:              testTPID,
+		Tenant:            "cgrates.org",
+		ID:                "TH1",
+		FilterIDs:         []string{"*lt:~*req.ASR:40"},
+		MaxHits:           -1,
+		Weight:            20.0,
+		ActionIDs:         []string{"ACT_ALARM"},
+		RecoveryFilterIDs: []string{"*gte:~*req.ASR:60"},
+		RecoveryActionIDs: []string{"ACT_RECOVERED"},
+	}
+	mdls := APItoModelTPThreshold(tpTH)
+	if len(mdls) != 1 {
+		t.Fatalf("Expecting 1 model, received: %s", utils.ToJSON(mdls))
+	} else if mdls[0].RecoveryFilterIDs != "*gte:~*req.ASR:60" ||
+		mdls[0].RecoveryActionIDs != "ACT_RECOVERED" {
+		t.Errorf("Unexpected model: %s", utils.ToJSON(mdls[0]))
+	}
+	if rcv := mdls.AsTPThreshold(); !reflect.DeepEqual([]*utils.TPThresholdProfile{tpTH}, rcv) {
+		t.Errorf("Expecting: %s,\n received: %s", utils.ToJSON(tpTH), utils.ToJSON(rcv))
+	}
+	th, err := APItoThresholdProfile(tpTH, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tpTH.RecoveryFilterIDs, th.RecoveryFilterIDs) ||
+		!reflect.DeepEqual(tpTH.RecoveryActionIDs, th.RecoveryActionIDs) {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(th))
+	}
+	rcvTP := ThresholdProfileToAPI(th)
+	if !reflect.DeepEqual(tpTH.RecoveryFilterIDs, rcvTP.RecoveryFilterIDs) ||
+		!reflect.DeepEqual(tpTH.RecoveryActionIDs, rcvTP.RecoveryActionIDs) {
+		t.Errorf("Unexpected TP profile: %s", utils.ToJSON(rcvTP))
+	}
+}
+
 func TestTPFilterAsTPFilter(t *testing.T) {
 	tps := []*FilterMdl{
 		{
@@ -2833,7 +3184,7 @@ func TestCsvHeader(t *testing.T) {
 		utils.Sorting, utils.SortingParameters, utils.RouteID, utils.RouteFilterIDs,
 		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteRateProfileIDs, utils.RouteResourceIDs,
 		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker,
-		utils.RouteParameters, utils.Weight,
+		utils.RouteParameters, utils.Weight, utils.BreakerFailures, utils.BreakerCoolDown,
 	}
 	if rcv := tps.CSVHeader(); !reflect.DeepEqual(eOut, rcv) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eOut), utils.ToJSON(rcv))
@@ -5226,7 +5577,8 @@ func TestRateProfileMdlsCSVHeader(t *testing.T) {
 		utils.ActivationIntervalString, utils.Weight, utils.ConnectFee, utils.MinCost,
 		utils.MaxCost, utils.MaxCostStrategy, utils.RateID,
 		utils.RateFilterIDs, utils.RateActivationStart, utils.RateWeight, utils.RateBlocker,
-		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement}
+		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement,
+		utils.Currency, utils.VolumeCounter, utils.VolumeReset}
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", expected, result)
 	}
@@ -5598,7 +5950,8 @@ func TestThresholdMdlsCSVHeader(t *testing.T) {
 	}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async,
+		utils.RecoveryFilterIDs, utils.RecoveryActionIDs}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -5615,6 +5968,7 @@ func TestActionProfileMdlsCSVHeader(t *testing.T) {
 		utils.ActivationIntervalString, utils.Weight, utils.Schedule, utils.TargetType,
 		utils.TargetIDs, utils.ActionID, utils.ActionFilterIDs, utils.ActionBlocker, utils.ActionTTL,
 		utils.ActionType, utils.ActionOpts, utils.ActionPath, utils.ActionValue,
+		utils.MissedRuns,
 	}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
@@ -6594,7 +6948,8 @@ func TestStatMdlsCSVHeader(t *testing.T) {
 	}}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs,
+		utils.GroupBy, utils.GroupTTL, utils.WindowType, utils.WindowLength, utils.WindowHistory}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -6935,7 +7290,8 @@ func TestAccountProfileMdlsCSVHeader(t *testing.T) {
 	exp := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs,
 		utils.ActivationIntervalString, utils.Weight, utils.BalanceID,
 		utils.BalanceFilterIDs, utils.BalanceWeight, utils.BalanceBlocker,
-		utils.BalanceType, utils.BalanceOpts, utils.BalanceUnits, utils.ThresholdIDs}
+		utils.BalanceType, utils.BalanceOpts, utils.BalanceUnits, utils.ThresholdIDs,
+		utils.BalanceActivationInterval}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(exp, result) {
 		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(result))
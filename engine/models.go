@@ -253,6 +253,7 @@ type ResourceMdl struct {
 	Stored             bool    `index:"8" re:""`
 	Weight             float64 `index:"9" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"10" re:""`
+	RateWindow         string  `index:"11" re:""`
 	CreatedAt          time.Time
 }
 
@@ -276,6 +277,11 @@ type StatMdl struct {
 	Blocker            bool    `index:"10" re:""`
 	Weight             float64 `index:"11" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"12" re:""`
+	GroupBy            string  `index:"13" re:""`
+	GroupTTL           string  `index:"14" re:""`
+	WindowType         string  `index:"15" re:""`
+	WindowLength       string  `index:"16" re:""`
+	WindowHistory      int     `index:"17" re:""`
 	CreatedAt          time.Time
 }
 
@@ -297,6 +303,8 @@ type ThresholdMdl struct {
 	Weight             float64 `index:"8" re:"\d+\.?\d*"`
 	ActionIDs          string  `index:"9" re:""`
 	Async              bool    `index:"10" re:""`
+	RecoveryFilterIDs  string  `index:"11" re:""`
+	RecoveryActionIDs  string  `index:"12" re:""`
 	CreatedAt          time.Time
 }
 
@@ -427,6 +435,8 @@ type RouteMdl struct {
 	RouteBlocker       bool    `index:"13" re:""`
 	RouteParameters    string  `index:"14" re:""`
 	Weight             float64 `index:"15" re:"\d+\.?\d*"`
+	BreakerFailures    int     `index:"16" re:""`
+	BreakerCoolDown    string  `index:"17" re:""`
 	CreatedAt          time.Time
 }
 
@@ -531,6 +541,9 @@ type RateProfileMdl struct {
 	RateRecurrentFee    float64 `index:"15" re:"\d+\.?\d*"`
 	RateUnit            string  `index:"16" re:""`
 	RateIncrement       string  `index:"17" re:""`
+	Currency            string  `index:"18" re:""`
+	VolumeCounter       string  `index:"19" re:""`
+	VolumeReset         string  `index:"20" re:""`
 
 	CreatedAt time.Time
 }
@@ -558,6 +571,7 @@ type ActionProfileMdl struct {
 	ActionOpts         string  `index:"13" re:""`
 	ActionPath         string  `index:"14" re:""`
 	ActionValue        string  `index:"15" re:""`
+	MissedRuns         string  `index:"16" re:""`
 
 	CreatedAt time.Time
 }
@@ -567,26 +581,27 @@ func (ActionProfileMdl) TableName() string {
 }
 
 type AccountProfileMdl struct {
-	PK                    uint `gorm:"primary_key"`
-	Tpid                  string
-	Tenant                string  `index:"0" re:""`
-	ID                    string  `index:"1" re:""`
-	FilterIDs             string  `index:"2" re:""`
-	ActivationInterval    string  `index:"3" re:""`
-	Weights               string  `index:"4" re:""`
-	Opts                  string  `index:"5" re:""`
-	BalanceID             string  `index:"6" re:""`
-	BalanceFilterIDs      string  `index:"7" re:""`
-	BalanceWeights        string  `index:"8" re:""`
-	BalanceType           string  `index:"9" re:""`
-	BalanceUnits          float64 `index:"10" re:"\d+\.?\d*"`
-	BalanceUnitFactors    string  `index:"11" re:""`
-	BalanceOpts           string  `index:"12" re:""`
-	BalanceCostIncrements string  `index:"13" re:""`
-	BalanceAttributeIDs   string  `index:"14" re:""`
-	BalanceRateProfileIDs string  `index:"15" re:""`
-	ThresholdIDs          string  `index:"16" re:""`
-	CreatedAt             time.Time
+	PK                        uint `gorm:"primary_key"`
+	Tpid                      string
+	Tenant                    string  `index:"0" re:""`
+	ID                        string  `index:"1" re:""`
+	FilterIDs                 string  `index:"2" re:""`
+	ActivationInterval        string  `index:"3" re:""`
+	Weights                   string  `index:"4" re:""`
+	Opts                      string  `index:"5" re:""`
+	BalanceID                 string  `index:"6" re:""`
+	BalanceFilterIDs          string  `index:"7" re:""`
+	BalanceWeights            string  `index:"8" re:""`
+	BalanceType               string  `index:"9" re:""`
+	BalanceUnits              float64 `index:"10" re:"\d+\.?\d*"`
+	BalanceUnitFactors        string  `index:"11" re:""`
+	BalanceOpts               string  `index:"12" re:""`
+	BalanceCostIncrements     string  `index:"13" re:""`
+	BalanceAttributeIDs       string  `index:"14" re:""`
+	BalanceRateProfileIDs     string  `index:"15" re:""`
+	ThresholdIDs              string  `index:"16" re:""`
+	BalanceActivationInterval string  `index:"17" re:""`
+	CreatedAt                 time.Time
 }
 
 func (AccountProfileMdl) TableName() string {
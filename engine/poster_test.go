@@ -68,3 +68,35 @@ func TestKafkaParseURL(t *testing.T) {
 		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(kfk))
 	}
 }
+
+func TestNatsPosterOpts(t *testing.T) {
+	exp := &NatsPoster{
+		dialURL:   "nats://127.0.0.1:4222",
+		subject:   "cdr_billing",
+		jetStream: true,
+		attempts:  10,
+	}
+	if nts, err := NewNatsPoster("nats://127.0.0.1:4222", 10, map[string]interface{}{
+		utils.NatsSubject:   "cdr_billing",
+		utils.NatsJetStream: true,
+	}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, nts) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(nts))
+	}
+	exp = &NatsPoster{
+		dialURL:  "nats://127.0.0.1:4222",
+		subject:  utils.DefaultQueueID,
+		attempts: 10,
+	}
+	if nts, err := NewNatsPoster("nats://127.0.0.1:4222", 10, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, nts) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(nts))
+	}
+	if _, err := NewNatsPoster("nats://127.0.0.1:4222", 10, map[string]interface{}{
+		utils.NatsJetStream: "notabool",
+	}); err == nil {
+		t.Error("Expected error")
+	}
+}
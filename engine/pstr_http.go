@@ -20,6 +20,7 @@ package engine
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -82,8 +83,20 @@ func (pstr *HTTPPoster) PostValues(content interface{}, hdr http.Header) (err er
 	return
 }
 
+// PostValuesWithContext will post the event, giving up once the context is done
+func (pstr *HTTPPoster) PostValuesWithContext(ctx context.Context, content interface{}, hdr http.Header) (err error) {
+	_, err = pstr.GetResponseWithContext(ctx, content, hdr)
+	return
+}
+
 // GetResponse will post the event and return the response
 func (pstr *HTTPPoster) GetResponse(content interface{}, hdr http.Header) (respBody []byte, err error) {
+	return pstr.GetResponseWithContext(context.Background(), content, hdr)
+}
+
+// GetResponseWithContext will post the event and return the response
+// the remaining attempts are abandoned once the context is done
+func (pstr *HTTPPoster) GetResponseWithContext(ctx context.Context, content interface{}, hdr http.Header) (respBody []byte, err error) {
 	fib := utils.Fib()
 	for i := 0; i < pstr.attempts; i++ {
 		var req *http.Request
@@ -91,9 +104,13 @@ func (pstr *HTTPPoster) GetResponse(content interface{}, hdr http.Header) (respB
 			utils.Logger.Warning(fmt.Sprintf("<HTTPPoster> Posting to : <%s>, error creating request: <%s>", pstr.addr, err.Error()))
 			return
 		}
-		if respBody, err = pstr.do(req); err != nil {
+		if respBody, err = pstr.do(req.WithContext(ctx)); err != nil {
 			if i+1 < pstr.attempts {
-				time.Sleep(time.Duration(fib()) * time.Second)
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(time.Duration(fib()) * time.Second):
+				}
 			}
 			continue
 		}
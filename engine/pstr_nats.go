@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats.go"
+)
+
+// NewNatsPoster creates a poster for NATS, publishing over JetStream if requested in opts
+func NewNatsPoster(dialURL string, attempts int, opts map[string]interface{}) (pstr *NatsPoster, err error) {
+	pstr = &NatsPoster{
+		dialURL:  dialURL,
+		subject:  utils.DefaultQueueID,
+		attempts: attempts,
+	}
+	if vals, has := opts[utils.NatsSubject]; has {
+		pstr.subject = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NatsJetStream]; has {
+		if pstr.jetStream, err = utils.IfaceAsBool(vals); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// NatsPoster is a poster for NATS
+type NatsPoster struct {
+	sync.Mutex
+	dialURL   string
+	subject   string // subject where we publish
+	jetStream bool   // publish waiting for the acknowledgement of the stream
+	attempts  int
+	nc        *nats.Conn
+	js        nats.JetStreamContext
+}
+
+// Post is the method being called when we need to post anything in the queue
+func (pstr *NatsPoster) Post(content []byte, _ string) (err error) {
+	fib := utils.Fib()
+	for i := 0; i < pstr.attempts; i++ {
+		if err = pstr.publish(content); err == nil {
+			return
+		}
+		if i+1 < pstr.attempts {
+			time.Sleep(time.Duration(fib()) * time.Second)
+		}
+	}
+	utils.Logger.Warning(fmt.Sprintf("<NatsPoster> publishing on subject <%s>, err: %s",
+		pstr.subject, err.Error()))
+	return
+}
+
+func (pstr *NatsPoster) publish(content []byte) (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.nc == nil || pstr.nc.IsClosed() { // the client reconnects by itself until it gives up closing the connection
+		if pstr.nc, err = nats.Connect(pstr.dialURL, nats.Name(utils.CGRateS)); err != nil {
+			return
+		}
+		if pstr.jetStream {
+			if pstr.js, err = pstr.nc.JetStream(); err != nil {
+				pstr.nc.Close()
+				pstr.nc = nil
+				return
+			}
+		}
+	}
+	if pstr.jetStream {
+		_, err = pstr.js.Publish(pstr.subject, content)
+		return
+	}
+	return pstr.nc.Publish(pstr.subject, content)
+}
+
+// Close closes the connection
+func (pstr *NatsPoster) Close() {
+	pstr.Lock()
+	if pstr.nc != nil {
+		pstr.nc.Close()
+	}
+	pstr.nc = nil
+	pstr.js = nil
+	pstr.Unlock()
+}
@@ -39,7 +39,12 @@ type RateProfile struct {
 	MinCost            *utils.Decimal
 	MaxCost            *utils.Decimal
 	MaxCostStrategy    string
+	Currency           string // currency of the fees defined in Rates
+	VolumeCounter      string // <""|*account|*tenant> when populated the IntervalRates start on the volume accumulated
+	VolumeReset        string // cron formatted schedule when the accumulated volume is reset, eg: "0 0 1 * *"
 	Rates              map[string]*Rate
+
+	volumeResetSched cron.Schedule // compiled version of VolumeReset
 }
 
 func (rp *RateProfile) TenantID() string {
@@ -47,6 +52,11 @@ func (rp *RateProfile) TenantID() string {
 }
 
 func (rp *RateProfile) Compile() (err error) {
+	if rp.VolumeReset != utils.EmptyString {
+		if rp.volumeResetSched, err = cron.ParseStandard(rp.VolumeReset); err != nil {
+			return
+		}
+	}
 	for _, rtP := range rp.Rates {
 		rtP.uID = utils.ConcatenatedKey(rp.Tenant, rp.ID, rtP.ID)
 		if err = rtP.Compile(); err != nil {
@@ -56,6 +66,33 @@ func (rp *RateProfile) Compile() (err error) {
 	return
 }
 
+// NextVolumeReset returns the first time after aTime when the accumulated volume is reset
+// zero time is returned if the volume is never reset
+func (rp *RateProfile) NextVolumeReset(aTime time.Time) time.Time {
+	if rp.volumeResetSched == nil {
+		return time.Time{}
+	}
+	return rp.volumeResetSched.Next(aTime)
+}
+
+// VolumeCounterID returns the ID of the RateVolumeCounter used by the event
+func (rp *RateProfile) VolumeCounterID(ev utils.DataProvider) (cntID string, err error) {
+	switch rp.VolumeCounter {
+	case utils.MetaTenant:
+		return utils.ConcatenatedKey(rp.ID, utils.MetaTenant), nil
+	case utils.MetaAccount:
+		var acnt string
+		if acnt, err = ev.FieldAsString([]string{utils.MetaReq, utils.AccountField}); err != nil {
+			if err == utils.ErrNotFound {
+				err = utils.NewErrMandatoryIeMissing(utils.AccountField)
+			}
+			return
+		}
+		return utils.ConcatenatedKey(rp.ID, acnt), nil
+	}
+	return utils.EmptyString, fmt.Errorf("unsupported volume counter: <%s>", rp.VolumeCounter)
+}
+
 // Rate defines rate related information used within a RateProfile
 type Rate struct {
 	ID              string               // RateID
@@ -117,6 +154,19 @@ func (rt *Rate) RunTimes(sTime, eTime time.Time, verbosity int) (aTimes [][]time
 	return nil, utils.ErrMaxIterationsReached
 }
 
+// RateVolumeCounter is the volume accumulated on a RateProfile with VolumeCounter
+type RateVolumeCounter struct {
+	Tenant    string
+	ID        string        // RateProfileID:*tenant or RateProfileID:Account
+	Volume    time.Duration // usage accumulated since the last reset
+	ResetTime time.Time     // when the Volume will be reset, zero for never
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (rvc *RateVolumeCounter) TenantID() string {
+	return utils.ConcatenatedKey(rvc.Tenant, rvc.ID)
+}
+
 // RateProfileWithOpts is used in replicatorV1 for dispatcher
 type RateProfileWithOpts struct {
 	*RateProfile
@@ -149,10 +199,47 @@ type RateProfileCost struct {
 	MinCost         float64
 	MaxCost         float64
 	MaxCostStrategy string
+	Currency        string          // currency of Cost, MinCost and MaxCost
+	Conversion      *CostConversion // populated if the costs were converted out of the RateProfile currency
 	RateSIntervals  []*RateSInterval
 	Altered         []string
 }
 
+// CostConversion records the exchange rate applied on the costs of a RateProfileCost
+// the RateSIntervals remain in the currency of the RateProfile
+type CostConversion struct {
+	FromCurrency string
+	ToCurrency   string
+	ExchangeRate float64
+}
+
+// ArgsCostForEvents is used by RateSv1.CostForEvents to simulate the costs of a batch of events
+type ArgsCostForEvents struct {
+	Tenant         string
+	RateProfileIDs []string
+	RateProfile    *APIRateProfile        // candidate RateProfile, replaces the profile matching when present
+	CGREvents      []*utils.CGREvent      // events to be rated
+	CDRsFilter     *utils.RPCCDRsFilter   // rate also the CDRs stored in StorDB matching the filter
+	Opts           map[string]interface{} // applied on all the events, the ones within the event take precedence
+}
+
+// EventRateProfileCost is the result of rating one of the events within RateSv1.CostForEvents
+type EventRateProfileCost struct {
+	EventID         string
+	RateProfileCost *RateProfileCost
+	Error           string // populated if the event could not be rated
+}
+
+// RateProfileCosts is the reply of RateSv1.CostForEvents
+type RateProfileCosts struct {
+	EventCosts        []*EventRateProfileCost
+	RatedEvents       int
+	FailedEvents      int
+	TotalUsage        time.Duration
+	TotalCost         float64
+	RateProfileTotals map[string]float64 // total cost per RateProfile
+}
+
 // CorrectCost should be called in final phase of cost calculation
 // in order to apply further correction like Min/MaxCost or rounding
 func (rPc *RateProfileCost) CorrectCost(rndDec *int, rndMtd string) {
@@ -259,6 +346,9 @@ func (ext *APIRateProfile) AsRateProfile() (rp *RateProfile, err error) {
 		FilterIDs:          ext.FilterIDs,
 		ActivationInterval: ext.ActivationInterval,
 		MaxCostStrategy:    ext.MaxCostStrategy,
+		Currency:           ext.Currency,
+		VolumeCounter:      ext.VolumeCounter,
+		VolumeReset:        ext.VolumeReset,
 	}
 	if ext.Weights != utils.EmptyString {
 		if rp.Weights, err = utils.NewDynamicWeightsFromString(ext.Weights, ";", "&"); err != nil {
@@ -294,6 +384,9 @@ type APIRateProfile struct {
 	MinCost            *float64
 	MaxCost            *float64
 	MaxCostStrategy    string
+	Currency           string
+	VolumeCounter      string
+	VolumeReset        string
 	Rates              map[string]*APIRate
 }
 
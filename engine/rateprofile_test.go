@@ -1208,3 +1208,48 @@ func TestRateSIntervalCompressEqualsCase3(t *testing.T) {
 		t.Errorf("\nExpecting <true>,\nReceived <%+v>", result)
 	}
 }
+
+func TestRateProfileVolumeCounter(t *testing.T) {
+	rPrf := &RateProfile{
+		Tenant:        "cgrates.org",
+		ID:            "RP1",
+		VolumeCounter: utils.MetaAccount,
+		VolumeReset:   "0 0 1 * *",
+	}
+	if err := rPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, exp := rPrf.NextVolumeReset(time.Date(2021, 3, 15, 10, 0, 0, 0, time.UTC)),
+		time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC); !rcv.Equal(exp) {
+		t.Errorf("Expected %v, received %v", exp, rcv)
+	}
+	ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.AccountField: "1001"}}
+	if rcv, err := rPrf.VolumeCounterID(ev); err != nil {
+		t.Error(err)
+	} else if rcv != "RP1:1001" {
+		t.Errorf("Unexpected counter ID: %q", rcv)
+	}
+	expErr := utils.NewErrMandatoryIeMissing(utils.AccountField).Error()
+	if _, err := rPrf.VolumeCounterID(utils.MapStorage{}); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+	rPrf.VolumeCounter = utils.MetaTenant
+	if rcv, err := rPrf.VolumeCounterID(ev); err != nil {
+		t.Error(err)
+	} else if rcv != "RP1:*tenant" {
+		t.Errorf("Unexpected counter ID: %q", rcv)
+	}
+
+	rPrf.VolumeReset = utils.EmptyString
+	rPrf.volumeResetSched = nil
+	if err := rPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := rPrf.NextVolumeReset(time.Now()); !rcv.IsZero() {
+		t.Errorf("Expected zero time, received %v", rcv)
+	}
+	rPrf.VolumeReset = "invalid"
+	if err := rPrf.Compile(); err == nil {
+		t.Error("Expected error on invalid volume reset")
+	}
+}
@@ -46,8 +46,9 @@ type ResourceProfile struct {
 	AllocationMessage  string                    // message returned by the winning resource on allocation
 	Blocker            bool                      // blocker flag to stop processing on filters matched
 	Stored             bool
-	Weight             float64  // Weight to sort the resources
-	ThresholdIDs       []string // Thresholds to check after changing Limit
+	Weight             float64       // Weight to sort the resources
+	ThresholdIDs       []string      // Thresholds to check after changing Limit
+	RateWindow         time.Duration // if set, Limit applies to the units allocated within this sliding window
 }
 
 // ResourceProfileWithOpts is used in replicatorV1 for dispatcher
@@ -61,6 +62,11 @@ func (rp *ResourceProfile) TenantID() string {
 	return utils.ConcatenatedKey(rp.Tenant, rp.ID)
 }
 
+// isRateLimit returns true if the resource counts the allocations per RateWindow
+func (rp *ResourceProfile) isRateLimit() bool {
+	return rp.RateWindow > 0
+}
+
 // ResourceUsage represents an usage counted
 type ResourceUsage struct {
 	Tenant     string
@@ -110,6 +116,27 @@ func (r *Resource) TenantID() string {
 	return utils.ConcatenatedKey(r.Tenant, r.ID)
 }
 
+// clone returns a copy of the Resource
+// the ResourceUsages are shared since they are replaced, not modified in place
+func (r *Resource) clone() (cln *Resource) {
+	cln = &Resource{
+		Tenant: r.Tenant,
+		ID:     r.ID,
+		Usages: make(map[string]*ResourceUsage, len(r.Usages)),
+		TTLIdx: make([]string, len(r.TTLIdx)),
+		ttl:    r.ttl,
+		rPrf:   r.rPrf,
+	}
+	for id, ru := range r.Usages {
+		cln.Usages[id] = ru
+	}
+	copy(cln.TTLIdx, r.TTLIdx)
+	if r.tUsage != nil {
+		cln.tUsage = utils.Float64Pointer(*r.tUsage)
+	}
+	return
+}
+
 // removeExpiredUnits removes units which are expired from the resource
 func (r *Resource) removeExpiredUnits() {
 	var firstActive int
@@ -128,6 +155,9 @@ func (r *Resource) removeExpiredUnits() {
 			continue
 		}
 		delete(r.Usages, rID)
+		if r.tUsage == nil {
+			continue
+		}
 		*r.tUsage -= ru.Units
 		if *r.tUsage < 0 { // something went wrong
 			utils.Logger.Warning(
@@ -240,6 +270,9 @@ func (rs Resources) recordUsage(ru *ResourceUsage) (err error) {
 // clearUsage gives back the units to the pool
 func (rs Resources) clearUsage(ruTntID string) (err error) {
 	for _, r := range rs {
+		if r.rPrf != nil && r.rPrf.isRateLimit() {
+			continue // the allocations are released only when leaving the RateWindow
+		}
 		if errClear := r.clearUsage(ruTntID); errClear != nil &&
 			r.ttl != nil && *r.ttl != 0 { // we only consider not found error in case of ttl different than 0
 			utils.Logger.Warning(fmt.Sprintf("<%s>, clear ruID: %s, err: %s", utils.ResourceS, ruTntID, errClear.Error()))
@@ -522,7 +555,9 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 			if rPrf.Stored && r.dirty == nil {
 				r.dirty = utils.BoolPointer(false)
 			}
-			if usageTTL != nil {
+			if rPrf.isRateLimit() {
+				r.ttl = utils.DurationPointer(rPrf.RateWindow)
+			} else if usageTTL != nil {
 				if *usageTTL != 0 {
 					r.ttl = usageTTL
 				}
@@ -811,7 +846,14 @@ func (rS *ResourceService) V1GetResource(arg *utils.TenantIDWithOpts, reply *Res
 	if err != nil {
 		return err
 	}
-	*reply = *res
+	// remove the expired usages so the rate limited resources report the current window
+	// done on a copy since the cached resource is only modified while allocating or releasing
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		cln := res.clone()
+		cln.removeExpiredUnits()
+		*reply = *cln
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.ResourcesPrefix+res.TenantID()) // same lock as allocateResource
 	return nil
 }
 
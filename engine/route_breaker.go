@@ -0,0 +1,112 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ArgsRouteOutcome reports the outcome of a call sent over one route
+type ArgsRouteOutcome struct {
+	Tenant    string
+	ProfileID string // RouteProfile ID
+	RouteID   string
+	Success   bool
+	Opts      map[string]interface{}
+}
+
+// routeBreaker is the circuit breaker state of one route
+type routeBreaker struct {
+	failures  int       // consecutive failures reported
+	openUntil time.Time // zero when closed, the route is blocked until this time when open
+	probing   bool      // a probe was sent after cool-down, waiting for its outcome
+}
+
+// routeBreakers keeps the circuit breakers of the routes, indexed on tenant:profileID:routeID
+type routeBreakers struct {
+	sync.Mutex
+	brks map[string]*routeBreaker
+}
+
+func newRouteBreakers() *routeBreakers {
+	return &routeBreakers{brks: make(map[string]*routeBreaker)}
+}
+
+// filterRoutes removes the routes with open circuit out of the sorted list
+// and returns the ones which should be used as probes since the cool-down elapsed
+func (rbs *routeBreakers) filterRoutes(tntPrfID string, sRts *SortedRoutes,
+	now time.Time) (probes utils.StringSet) {
+	rbs.Lock()
+	defer rbs.Unlock()
+	probes = make(utils.StringSet)
+	rts := make([]*SortedRoute, 0, len(sRts.SortedRoutes))
+	for _, rt := range sRts.SortedRoutes {
+		brk, has := rbs.brks[utils.ConcatenatedKey(tntPrfID, rt.RouteID)]
+		if has && !brk.openUntil.IsZero() {
+			if now.Before(brk.openUntil) {
+				continue
+			}
+			probes.Add(rt.RouteID)
+		}
+		rts = append(rts, rt)
+	}
+	sRts.SortedRoutes = rts
+	return
+}
+
+// startProbes half-opens the circuits for the probes returned to the client
+// the circuit stays blocked for another coolDown period if no outcome is reported
+func (rbs *routeBreakers) startProbes(tntPrfID string, sRts *SortedRoutes,
+	probes utils.StringSet, coolDown time.Duration, now time.Time) {
+	rbs.Lock()
+	defer rbs.Unlock()
+	for _, rt := range sRts.SortedRoutes {
+		if !probes.Has(rt.RouteID) {
+			continue
+		}
+		if brk, has := rbs.brks[utils.ConcatenatedKey(tntPrfID, rt.RouteID)]; has {
+			brk.probing = true
+			brk.openUntil = now.Add(coolDown)
+		}
+	}
+}
+
+// reportOutcome updates the circuit of the route based on the call outcome
+func (rbs *routeBreakers) reportOutcome(rtKey string, success bool,
+	maxFailures int, coolDown time.Duration, now time.Time) {
+	rbs.Lock()
+	defer rbs.Unlock()
+	if success {
+		delete(rbs.brks, rtKey) // closed circuit
+		return
+	}
+	brk, has := rbs.brks[rtKey]
+	if !has {
+		brk = new(routeBreaker)
+		rbs.brks[rtKey] = brk
+	}
+	brk.failures++
+	if brk.probing || brk.failures >= maxFailures {
+		brk.probing = false
+		brk.openUntil = now.Add(coolDown)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewLeastCostQOSSorter returns the sorter for the *lc_qos strategy
+func NewLeastCostQOSSorter(rS *RouteService) *LeastCostQOSSorter {
+	return &LeastCostQOSSorter{rS: rS,
+		sorting: utils.MetaLCQOS}
+}
+
+// LeastCostQOSSorter sorts the routes based on cost after removing
+// the ones not meeting the QoS thresholds from SortingParameters
+type LeastCostQOSSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+// SortRoutes implements RoutesSorter interface
+func (lcq *LeastCostQOSSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	var thds map[string]float64
+	var missingStats string
+	if thds, missingStats, err = newQOSThresholds(extraOpts.sortingParameters); err != nil {
+		return
+	}
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting:      lcq.sorting,
+		SortedRoutes: make([]*SortedRoute, 0)}
+	noStats := &SortedRoutes{SortedRoutes: make([]*SortedRoute, 0)} // routes sorted after the ones with stats
+	for _, s := range routes {
+		if len(s.RatingPlanIDs) == 0 && len(s.AccountIDs) == 0 {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> supplier: <%s> - empty RatingPlanIDs or AccountIDs",
+					utils.RouteS, s.ID))
+			return nil, utils.NewErrMandatoryIeMissing("RatingPlanIDs or AccountIDs")
+		}
+		srtSpl, pass, err := lcq.rS.populateSortingData(ev, s, extraOpts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		qosPass, missing := passQOSThresholds(srtSpl.SortingData, thds)
+		if !qosPass {
+			continue
+		}
+		if missing {
+			switch missingStats {
+			case utils.MetaDrop:
+				continue
+			case utils.MetaLast:
+				noStats.SortedRoutes = append(noStats.SortedRoutes, srtSpl)
+				continue
+			}
+		}
+		sortedRoutes.SortedRoutes = append(sortedRoutes.SortedRoutes, srtSpl)
+	}
+	sortedRoutes.SortLeastCost()
+	noStats.SortLeastCost()
+	sortedRoutes.SortedRoutes = append(sortedRoutes.SortedRoutes, noStats.SortedRoutes...)
+	return
+}
+
+// newQOSThresholds parses the SortingParameters of *lc_qos strategy
+// in the format metricID:value and *missing_stats:<*pass|*last|*drop>
+func newQOSThresholds(params []string) (thds map[string]float64, missingStats string, err error) {
+	thds = make(map[string]float64)
+	missingStats = utils.MetaLast
+	for _, param := range params {
+		idx := strings.LastIndex(param, utils.InInFieldSep)
+		if idx == -1 {
+			return nil, utils.EmptyString, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaLCQOS, param)
+		}
+		key, val := param[:idx], param[idx+1:]
+		if key == utils.MetaMissingStats {
+			switch val {
+			case utils.MetaPass, utils.MetaLast, utils.MetaDrop:
+				missingStats = val
+			default:
+				return nil, utils.EmptyString, fmt.Errorf("unsupported %s value: <%s>", utils.MetaMissingStats, val)
+			}
+			continue
+		}
+		var thd float64
+		if thd, err = strconv.ParseFloat(val, 64); err != nil {
+			// durations are compared in seconds, as returned by the duration metrics
+			dur, errDur := utils.ParseDurationWithNanosecs(val)
+			if errDur != nil {
+				return nil, utils.EmptyString, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaLCQOS, param)
+			}
+			thd, err = dur.Seconds(), nil
+		}
+		thds[key] = thd
+	}
+	return
+}
+
+// passQOSThresholds checks the metrics out of sortingData against the thresholds
+// the *pdd is considered a maximum while the rest of the metrics a minimum
+func passQOSThresholds(sortingData map[string]interface{}, thds map[string]float64) (pass, missing bool) {
+	pass = true
+	for metricID, thd := range thds {
+		val, has := sortingData[metricID].(float64)
+		if !has || val == utils.StatsNA {
+			missing = true
+			continue
+		}
+		if metricID == utils.MetaPDD {
+			pass = pass && val <= thd
+		} else {
+			pass = pass && val >= thd
+		}
+	}
+	return
+}
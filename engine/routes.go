@@ -55,6 +55,8 @@ type RouteProfile struct {
 	SortingParameters  []string
 	Routes             []*Route
 	Weight             float64
+	BreakerFailures    int           // consecutive failures blocking a route, 0 disables the circuit breaker
+	BreakerCoolDown    time.Duration // time a blocked route is kept out before probing it again
 
 	cache map[string]interface{}
 }
@@ -117,10 +119,11 @@ func (lps RouteProfiles) Sort() {
 func NewRouteService(dm *DataManager,
 	filterS *FilterS, cgrcfg *config.CGRConfig, connMgr *ConnManager) (rS *RouteService, err error) {
 	rS = &RouteService{
-		dm:      dm,
-		filterS: filterS,
-		cgrcfg:  cgrcfg,
-		connMgr: connMgr,
+		dm:       dm,
+		filterS:  filterS,
+		cgrcfg:   cgrcfg,
+		connMgr:  connMgr,
+		breakers: newRouteBreakers(),
 	}
 	if rS.sorter, err = NewRouteSortDispatcher(rS); err != nil {
 		return nil, err
@@ -130,11 +133,12 @@ func NewRouteService(dm *DataManager,
 
 // RouteService is the service computing route queries
 type RouteService struct {
-	dm      *DataManager
-	filterS *FilterS
-	cgrcfg  *config.CGRConfig
-	sorter  RouteSortDispatcher
-	connMgr *ConnManager
+	dm       *DataManager
+	filterS  *FilterS
+	cgrcfg   *config.CGRConfig
+	sorter   RouteSortDispatcher
+	connMgr  *ConnManager
+	breakers *routeBreakers
 }
 
 // Shutdown is called to shutdown the service
@@ -461,7 +465,11 @@ func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 			//check if the route have the metric from sortingParameters
 			//in case that the metric don't exist
 			//we use 10000000 for *pdd and -1 for others
+			//*lc_qos handles the missing metrics itself
 			for _, metric := range extraOpts.sortingParameters {
+				if extraOpts.sortingStragety == utils.MetaLCQOS {
+					break
+				}
 				if _, hasMetric := metricSupp[metric]; !hasMetric {
 					switch metric {
 					default:
@@ -553,6 +561,13 @@ func (rpS *RouteService) sortedRoutesForEvent(tnt string, args *ArgsGetRoutes) (
 	if err != nil {
 		return nil, err
 	}
+	var probes utils.StringSet
+	now := time.Now()
+	if rPrfl.BreakerFailures > 0 && rpS.breakers != nil {
+		if probes = rpS.breakers.filterRoutes(rPrfl.TenantID(), sortedRoutes, now); len(sortedRoutes.SortedRoutes) == 0 {
+			return nil, utils.ErrNotFound
+		}
+	}
 	if args.Paginator.Offset != nil {
 		if *args.Paginator.Offset <= len(sortedRoutes.SortedRoutes) {
 			sortedRoutes.SortedRoutes = sortedRoutes.SortedRoutes[*args.Paginator.Offset:]
@@ -563,6 +578,9 @@ func (rpS *RouteService) sortedRoutesForEvent(tnt string, args *ArgsGetRoutes) (
 			sortedRoutes.SortedRoutes = sortedRoutes.SortedRoutes[:*args.Paginator.Limit]
 		}
 	}
+	if len(probes) != 0 {
+		rpS.breakers.startProbes(rPrfl.TenantID(), sortedRoutes, probes, rPrfl.BreakerCoolDown, now)
+	}
 	sortedRoutes.Count = len(sortedRoutes.SortedRoutes)
 	return
 }
@@ -705,3 +723,27 @@ func (rpS *RouteService) V1GetRouteProfilesForEvent(args *utils.CGREvent, reply
 	*reply = sPs
 	return
 }
+
+// V1ReportRouteOutcome feeds the circuit breaker of a route with the outcome of a call sent over it
+func (rpS *RouteService) V1ReportRouteOutcome(args *ArgsRouteOutcome, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ProfileID, utils.RouteID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rpS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	rPrfl, err := rpS.dm.GetRouteProfile(tnt, args.ProfileID, true, true, utils.NonTransactional)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	if rPrfl.BreakerFailures > 0 && rpS.breakers != nil {
+		rpS.breakers.reportOutcome(utils.ConcatenatedKey(rPrfl.TenantID(), args.RouteID),
+			args.Success, rPrfl.BreakerFailures, rPrfl.BreakerCoolDown, time.Now())
+	}
+	*reply = utils.OK
+	return
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/cgrates/cgrates/config"
 
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 func TestRoutesSort(t *testing.T) {
@@ -2211,3 +2212,189 @@ func TestRoutesMatchWithIndexFalse(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[2], sprf[0])
 	}
 }
+
+type routesLCQOSMock struct {
+	costs   map[string]float64            // indexed on RatingPlanID
+	metrics map[string]map[string]float64 // indexed on StatID
+}
+
+func (m *routesLCQOSMock) Call(serviceMethod string, args, reply interface{}) error {
+	switch serviceMethod {
+	case utils.ResponderGetCostOnRatingPlans:
+		rpID := args.(*utils.GetCostOnRatingPlansArgs).RatingPlanIDs[0]
+		*reply.(*map[string]interface{}) = map[string]interface{}{
+			utils.Cost:         m.costs[rpID],
+			utils.RatingPlanID: rpID,
+		}
+	case utils.StatSv1GetQueueFloatMetrics:
+		metrics, has := m.metrics[args.(*utils.TenantIDWithOpts).ID]
+		if !has {
+			return utils.ErrNotFound
+		}
+		*reply.(*map[string]float64) = metrics
+	default:
+		return utils.ErrNotImplemented
+	}
+	return nil
+}
+
+func TestRoutesLeastCostQOSSorter(t *testing.T) {
+	connID := utils.ConcatenatedKey(utils.MetaInternal, "routesLCQOS")
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().RALsConns = []string{connID}
+	cfg.RouteSCfg().StatSConns = []string{connID}
+	mock := &routesLCQOSMock{
+		costs: map[string]float64{
+			"RP_CHEAP": 0.1, "RP_MEDIUM": 0.2, "RP_EXPENSIVE": 0.3, "RP_NOSTATS": 0.05,
+		},
+		metrics: map[string]map[string]float64{
+			"ST_CHEAP":     {utils.MetaASR: 30, utils.MetaACD: 90},  // low ASR
+			"ST_MEDIUM":    {utils.MetaASR: 50, utils.MetaACD: 70},  // passing
+			"ST_EXPENSIVE": {utils.MetaASR: 80, utils.MetaACD: 120}, // passing
+			"ST_NOSTATS":   {utils.MetaASR: utils.StatsNA, utils.MetaACD: utils.StatsNA},
+		},
+	}
+	mockChan := make(chan rpcclient.ClientConnector, 1)
+	mockChan <- mock
+	rpS := &RouteService{cgrcfg: cfg,
+		connMgr: &ConnManager{cfg: cfg,
+			rpcInternal: map[string]chan rpcclient.ClientConnector{connID: mockChan}}}
+	defer Cache.Remove(utils.CacheRPCConnections, connID, true, utils.NonTransactional)
+	routes := map[string]*Route{
+		"CHEAP":     {ID: "CHEAP", RatingPlanIDs: []string{"RP_CHEAP"}, StatIDs: []string{"ST_CHEAP"}},
+		"MEDIUM":    {ID: "MEDIUM", RatingPlanIDs: []string{"RP_MEDIUM"}, StatIDs: []string{"ST_MEDIUM"}},
+		"EXPENSIVE": {ID: "EXPENSIVE", RatingPlanIDs: []string{"RP_EXPENSIVE"}, StatIDs: []string{"ST_EXPENSIVE"}},
+		"NOSTATS":   {ID: "NOSTATS", RatingPlanIDs: []string{"RP_NOSTATS"}, StatIDs: []string{"ST_NOSTATS"}},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "LCQOS",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+			utils.SetupTime:    time.Date(2020, 10, 1, 8, 0, 0, 0, time.UTC),
+			utils.Usage:        time.Minute,
+		},
+	}
+	lcq := NewLeastCostQOSSorter(rpS)
+	for _, tc := range []struct {
+		params []string
+		exp    []string
+	}{
+		{[]string{"*asr:40", "*acd:60s"}, []string{"MEDIUM", "EXPENSIVE", "NOSTATS"}},
+		{[]string{"*asr:40", "*acd:60s", "*missing_stats:*pass"}, []string{"NOSTATS", "MEDIUM", "EXPENSIVE"}},
+		{[]string{"*asr:40", "*acd:100", "*missing_stats:*drop"}, []string{"EXPENSIVE"}},
+	} {
+		sorted, err := lcq.SortRoutes("RT_LCQOS", routes, ev, &optsGetRoutes{
+			sortingParameters: tc.params,
+			sortingStragety:   utils.MetaLCQOS,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcv := sorted.RouteIDs(); !reflect.DeepEqual(tc.exp, rcv) {
+			t.Errorf("for %v expecting: %v, received: %v", tc.params, tc.exp, rcv)
+		}
+		if sorted.Sorting != utils.MetaLCQOS {
+			t.Errorf("unexpected sorting: %s", sorted.Sorting)
+		}
+		for _, sRt := range sorted.SortedRoutes {
+			if _, has := sRt.SortingData[utils.MetaASR]; !has {
+				t.Errorf("missing metrics in SortingData: %s", utils.ToJSON(sRt.SortingData))
+			}
+		}
+	}
+	if _, err := lcq.SortRoutes("RT_LCQOS", routes, ev, &optsGetRoutes{
+		sortingParameters: []string{"*asr"},
+		sortingStragety:   utils.MetaLCQOS,
+	}); err == nil {
+		t.Error("expecting error for invalid sorting parameter")
+	}
+}
+
+func TestRoutesCircuitBreaker(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().StringIndexedFields = nil
+	cfg.RouteSCfg().PrefixIndexedFields = nil
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	rpS, err := NewRouteService(dm, &FilterS{dm: dm, cfg: cfg}, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rPrf := &RouteProfile{
+		Tenant:          "cgrates.org",
+		ID:              "RP_BREAKER",
+		Sorting:         utils.MetaWeight,
+		BreakerFailures: 2,
+		BreakerCoolDown: time.Minute,
+		Routes: []*Route{
+			{ID: "RT1", Weight: 20},
+			{ID: "RT2", Weight: 10},
+		},
+		Weight: 10,
+	}
+	if err = dm.SetRouteProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveRouteProfile(rPrf.Tenant, rPrf.ID, utils.NonTransactional, true)
+	getRoutes := func() []string {
+		var reply SortedRoutes
+		if err := rpS.V1GetRoutes(&ArgsGetRoutes{CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "BREAKER",
+			Event:  map[string]interface{}{},
+		}}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		return reply.RouteIDs()
+	}
+	report := func(success bool) {
+		var reply string
+		if err := rpS.V1ReportRouteOutcome(&ArgsRouteOutcome{
+			Tenant:    "cgrates.org",
+			ProfileID: "RP_BREAKER",
+			RouteID:   "RT1",
+			Success:   success,
+		}, &reply); err != nil {
+			t.Fatal(err)
+		} else if reply != utils.OK {
+			t.Errorf("unexpected reply: %s", reply)
+		}
+	}
+	coolDown := func() { // simulate the elapsed cool-down
+		rpS.breakers.brks["cgrates.org:RP_BREAKER:RT1"].openUntil = time.Now().Add(-time.Second)
+	}
+	both := []string{"RT1", "RT2"}
+	report(false)
+	if rcv := getRoutes(); !reflect.DeepEqual(both, rcv) {
+		t.Errorf("expecting: %v, received: %v", both, rcv)
+	}
+	report(false) // opens the circuit
+	if rcv := getRoutes(); !reflect.DeepEqual([]string{"RT2"}, rcv) {
+		t.Errorf("expecting: %v, received: %v", []string{"RT2"}, rcv)
+	}
+	coolDown()
+	if rcv := getRoutes(); !reflect.DeepEqual(both, rcv) { // probe
+		t.Errorf("expecting: %v, received: %v", both, rcv)
+	}
+	if rcv := getRoutes(); !reflect.DeepEqual([]string{"RT2"}, rcv) { // only one probe at a time
+		t.Errorf("expecting: %v, received: %v", []string{"RT2"}, rcv)
+	}
+	report(false) // failed probe reopens the circuit
+	if rcv := getRoutes(); !reflect.DeepEqual([]string{"RT2"}, rcv) {
+		t.Errorf("expecting: %v, received: %v", []string{"RT2"}, rcv)
+	}
+	coolDown()
+	getRoutes()
+	report(true) // successful probe closes the circuit
+	for i := 0; i < 2; i++ {
+		if rcv := getRoutes(); !reflect.DeepEqual(both, rcv) {
+			t.Errorf("expecting: %v, received: %v", both, rcv)
+		}
+	}
+	var reply string
+	if err = rpS.V1ReportRouteOutcome(&ArgsRouteOutcome{ProfileID: "RP_BREAKER"},
+		&reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.RouteID).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -20,6 +20,8 @@ package engine
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -40,24 +42,36 @@ type StatWithCompress struct {
 	CompressFactor int
 }
 
+// StatWithSquaresCompress keeps the mean of the squares next to the mean of the values
+type StatWithSquaresCompress struct {
+	Stat           float64
+	SquaresStat    float64
+	CompressFactor int
+}
+
 // NewStatMetric instantiates the StatMetric
 // cfg serves as general purpose container to pass config options to metric
 func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMetric, err error) {
 	metrics := map[string]func(int, string, []string) (StatMetric, error){
-		utils.MetaASR:      NewASR,
-		utils.MetaACD:      NewACD,
-		utils.MetaTCD:      NewTCD,
-		utils.MetaACC:      NewACC,
-		utils.MetaTCC:      NewTCC,
-		utils.MetaPDD:      NewPDD,
-		utils.MetaDDC:      NewDDC,
-		utils.MetaSum:      NewStatSum,
-		utils.MetaAverage:  NewStatAverage,
-		utils.MetaDistinct: NewStatDistinct,
+		utils.MetaASR:        NewASR,
+		utils.MetaACD:        NewACD,
+		utils.MetaTCD:        NewTCD,
+		utils.MetaACC:        NewACC,
+		utils.MetaTCC:        NewTCC,
+		utils.MetaPDD:        NewPDD,
+		utils.MetaDDC:        NewDDC,
+		utils.MetaSum:        NewStatSum,
+		utils.MetaAverage:    NewStatAverage,
+		utils.MetaDistinct:   NewStatDistinct,
+		utils.MetaHighest:    NewStatHighest,
+		utils.MetaLowest:     NewStatLowest,
+		utils.MetaStdDev:     NewStatStdDev,
+		utils.MetaPercentile: NewStatPercentile,
 	}
 	// split the metricID
 	// in case of *sum we have *sum#~*req.FieldName
-	metricSplit := strings.Split(metricID, utils.HashtagSep)
+	// in case of *percentile we have *percentile#95#~*req.FieldName
+	metricSplit := strings.SplitN(metricID, utils.HashtagSep, 2)
 	if _, has := metrics[metricSplit[0]]; !has {
 		return nil, fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 	}
@@ -1453,3 +1467,569 @@ func (dst *StatDistinct) GetCompressFactor(events map[string]int) map[string]int
 	}
 	return events
 }
+
+func NewStatHighest(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatHighest{Events: make(map[string]*StatWithCompress),
+		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// StatHighest implements the maximum value metric
+type StatHighest struct {
+	FilterIDs []string
+	Highest   float64
+	Count     int64
+	Events    map[string]*StatWithCompress // map[EventTenantID]MaxValue
+	MinItems  int
+	FieldName string
+	val       *float64 // cached highest value
+}
+
+// getValue returns hgh.val
+func (hgh *StatHighest) getValue(roundingDecimal int) float64 {
+	if hgh.val == nil {
+		if (hgh.MinItems > 0 && hgh.Count < int64(hgh.MinItems)) || (hgh.Count == 0) {
+			hgh.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			hgh.val = utils.Float64Pointer(utils.Round(hgh.Highest,
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *hgh.val
+}
+
+func (hgh *StatHighest) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := hgh.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (hgh *StatHighest) GetValue(roundingDecimal int) (v interface{}) {
+	return hgh.getValue(roundingDecimal)
+}
+
+func (hgh *StatHighest) GetFloat64Value(roundingDecimal int) (v float64) {
+	return hgh.getValue(roundingDecimal)
+}
+
+func (hgh *StatHighest) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	var ival interface{}
+	if ival, err = utils.DPDynamicInterface(hgh.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, hgh.FieldName)
+		}
+		return
+	} else if val, err = utils.IfaceAsFloat64(ival); err != nil {
+		return
+	}
+	if hgh.Count == 0 || val > hgh.Highest {
+		hgh.Highest = val
+	}
+	if v, has := hgh.Events[evID]; !has {
+		hgh.Events[evID] = &StatWithCompress{Stat: val, CompressFactor: 1}
+	} else {
+		if val > v.Stat {
+			v.Stat = val
+		}
+		v.CompressFactor = v.CompressFactor + 1
+	}
+	hgh.Count++
+	hgh.val = nil
+	return
+}
+
+func (hgh *StatHighest) RemEvent(evID string) (err error) {
+	val, has := hgh.Events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	hgh.Count--
+	if val.CompressFactor > 1 {
+		val.CompressFactor = val.CompressFactor - 1
+		hgh.val = nil
+		return
+	}
+	delete(hgh.Events, evID)
+	if val.Stat == hgh.Highest { // recalculate the highest out of the remaining events
+		first := true
+		for _, v := range hgh.Events {
+			if first || v.Stat > hgh.Highest {
+				hgh.Highest = v.Stat
+				first = false
+			}
+		}
+	}
+	hgh.val = nil
+	return
+}
+
+func (hgh *StatHighest) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(hgh)
+}
+
+func (hgh *StatHighest) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, hgh)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (hgh *StatHighest) GetFilterIDs() []string {
+	return hgh.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (hgh *StatHighest) GetMinItems() (minIts int) { return hgh.MinItems }
+
+// Compress is part of StatMetric interface
+func (hgh *StatHighest) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	if hgh.Count < queueLen {
+		for id := range hgh.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
+	}
+	stat := &StatWithCompress{
+		Stat:           utils.Round(hgh.Highest, roundingDecimal, utils.MetaRoundingMiddle),
+		CompressFactor: int(hgh.Count),
+	}
+	hgh.Events = map[string]*StatWithCompress{defaultID: stat}
+	return []string{defaultID}
+}
+
+// Compress is part of StatMetric interface
+func (hgh *StatHighest) GetCompressFactor(events map[string]int) map[string]int {
+	for id, val := range hgh.Events {
+		if _, has := events[id]; !has {
+			events[id] = val.CompressFactor
+		}
+		if events[id] < val.CompressFactor {
+			events[id] = val.CompressFactor
+		}
+	}
+	return events
+}
+
+func NewStatLowest(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatLowest{Events: make(map[string]*StatWithCompress),
+		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// StatLowest implements the minimum value metric
+type StatLowest struct {
+	FilterIDs []string
+	Lowest    float64
+	Count     int64
+	Events    map[string]*StatWithCompress // map[EventTenantID]MinValue
+	MinItems  int
+	FieldName string
+	val       *float64 // cached lowest value
+}
+
+// getValue returns lwst.val
+func (lwst *StatLowest) getValue(roundingDecimal int) float64 {
+	if lwst.val == nil {
+		if (lwst.MinItems > 0 && lwst.Count < int64(lwst.MinItems)) || (lwst.Count == 0) {
+			lwst.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			lwst.val = utils.Float64Pointer(utils.Round(lwst.Lowest,
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *lwst.val
+}
+
+func (lwst *StatLowest) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := lwst.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (lwst *StatLowest) GetValue(roundingDecimal int) (v interface{}) {
+	return lwst.getValue(roundingDecimal)
+}
+
+func (lwst *StatLowest) GetFloat64Value(roundingDecimal int) (v float64) {
+	return lwst.getValue(roundingDecimal)
+}
+
+func (lwst *StatLowest) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	var ival interface{}
+	if ival, err = utils.DPDynamicInterface(lwst.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, lwst.FieldName)
+		}
+		return
+	} else if val, err = utils.IfaceAsFloat64(ival); err != nil {
+		return
+	}
+	if lwst.Count == 0 || val < lwst.Lowest {
+		lwst.Lowest = val
+	}
+	if v, has := lwst.Events[evID]; !has {
+		lwst.Events[evID] = &StatWithCompress{Stat: val, CompressFactor: 1}
+	} else {
+		if val < v.Stat {
+			v.Stat = val
+		}
+		v.CompressFactor = v.CompressFactor + 1
+	}
+	lwst.Count++
+	lwst.val = nil
+	return
+}
+
+func (lwst *StatLowest) RemEvent(evID string) (err error) {
+	val, has := lwst.Events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	lwst.Count--
+	if val.CompressFactor > 1 {
+		val.CompressFactor = val.CompressFactor - 1
+		lwst.val = nil
+		return
+	}
+	delete(lwst.Events, evID)
+	if val.Stat == lwst.Lowest { // recalculate the lowest out of the remaining events
+		first := true
+		for _, v := range lwst.Events {
+			if first || v.Stat < lwst.Lowest {
+				lwst.Lowest = v.Stat
+				first = false
+			}
+		}
+	}
+	lwst.val = nil
+	return
+}
+
+func (lwst *StatLowest) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(lwst)
+}
+
+func (lwst *StatLowest) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, lwst)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (lwst *StatLowest) GetFilterIDs() []string {
+	return lwst.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (lwst *StatLowest) GetMinItems() (minIts int) { return lwst.MinItems }
+
+// Compress is part of StatMetric interface
+func (lwst *StatLowest) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	if lwst.Count < queueLen {
+		for id := range lwst.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
+	}
+	stat := &StatWithCompress{
+		Stat:           utils.Round(lwst.Lowest, roundingDecimal, utils.MetaRoundingMiddle),
+		CompressFactor: int(lwst.Count),
+	}
+	lwst.Events = map[string]*StatWithCompress{defaultID: stat}
+	return []string{defaultID}
+}
+
+// Compress is part of StatMetric interface
+func (lwst *StatLowest) GetCompressFactor(events map[string]int) map[string]int {
+	for id, val := range lwst.Events {
+		if _, has := events[id]; !has {
+			events[id] = val.CompressFactor
+		}
+		if events[id] < val.CompressFactor {
+			events[id] = val.CompressFactor
+		}
+	}
+	return events
+}
+
+func NewStatStdDev(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatStdDev{Events: make(map[string]*StatWithSquaresCompress),
+		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// StatStdDev implements the population standard deviation metric
+type StatStdDev struct {
+	FilterIDs  []string
+	Sum        float64
+	SumSquares float64
+	Count      int64
+	Events     map[string]*StatWithSquaresCompress // map[EventTenantID]Value
+	MinItems   int
+	FieldName  string
+	val        *float64 // cached standard deviation value
+}
+
+// getValue returns stdDev.val
+func (stdDev *StatStdDev) getValue(roundingDecimal int) float64 {
+	if stdDev.val == nil {
+		if (stdDev.MinItems > 0 && stdDev.Count < int64(stdDev.MinItems)) || (stdDev.Count == 0) {
+			stdDev.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			mean := stdDev.Sum / float64(stdDev.Count)
+			variance := stdDev.SumSquares/float64(stdDev.Count) - mean*mean
+			if variance < 0 { // floating point errors
+				variance = 0
+			}
+			stdDev.val = utils.Float64Pointer(utils.Round(math.Sqrt(variance),
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *stdDev.val
+}
+
+func (stdDev *StatStdDev) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := stdDev.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (stdDev *StatStdDev) GetValue(roundingDecimal int) (v interface{}) {
+	return stdDev.getValue(roundingDecimal)
+}
+
+func (stdDev *StatStdDev) GetFloat64Value(roundingDecimal int) (v float64) {
+	return stdDev.getValue(roundingDecimal)
+}
+
+func (stdDev *StatStdDev) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	var ival interface{}
+	if ival, err = utils.DPDynamicInterface(stdDev.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, stdDev.FieldName)
+		}
+		return
+	} else if val, err = utils.IfaceAsFloat64(ival); err != nil {
+		return
+	}
+	stdDev.Sum += val
+	stdDev.SumSquares += val * val
+	if v, has := stdDev.Events[evID]; !has {
+		stdDev.Events[evID] = &StatWithSquaresCompress{Stat: val, SquaresStat: val * val, CompressFactor: 1}
+	} else {
+		v.Stat = (v.Stat*float64(v.CompressFactor) + val) / float64(v.CompressFactor+1)
+		v.SquaresStat = (v.SquaresStat*float64(v.CompressFactor) + val*val) / float64(v.CompressFactor+1)
+		v.CompressFactor = v.CompressFactor + 1
+	}
+	stdDev.Count++
+	stdDev.val = nil
+	return
+}
+
+func (stdDev *StatStdDev) RemEvent(evID string) (err error) {
+	val, has := stdDev.Events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	stdDev.Sum -= val.Stat
+	stdDev.SumSquares -= val.SquaresStat
+	stdDev.Count--
+	if val.CompressFactor <= 1 {
+		delete(stdDev.Events, evID)
+	} else {
+		val.CompressFactor = val.CompressFactor - 1
+	}
+	stdDev.val = nil
+	return
+}
+
+func (stdDev *StatStdDev) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(stdDev)
+}
+
+func (stdDev *StatStdDev) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, stdDev)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (stdDev *StatStdDev) GetFilterIDs() []string {
+	return stdDev.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (stdDev *StatStdDev) GetMinItems() (minIts int) { return stdDev.MinItems }
+
+// Compress is part of StatMetric interface
+// the means are not rounded so removing the compressed events gives back the exact Sum and SumSquares
+func (stdDev *StatStdDev) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	if stdDev.Count < queueLen {
+		for id := range stdDev.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
+	}
+	stat := &StatWithSquaresCompress{
+		Stat:           stdDev.Sum / float64(stdDev.Count),
+		SquaresStat:    stdDev.SumSquares / float64(stdDev.Count),
+		CompressFactor: int(stdDev.Count),
+	}
+	stdDev.Events = map[string]*StatWithSquaresCompress{defaultID: stat}
+	return []string{defaultID}
+}
+
+// Compress is part of StatMetric interface
+func (stdDev *StatStdDev) GetCompressFactor(events map[string]int) map[string]int {
+	for id, val := range stdDev.Events {
+		if _, has := events[id]; !has {
+			events[id] = val.CompressFactor
+		}
+		if events[id] < val.CompressFactor {
+			events[id] = val.CompressFactor
+		}
+	}
+	return events
+}
+
+// NewStatPercentile expects the extraParams in the form: 95#~*req.FieldName
+func NewStatPercentile(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	params := strings.SplitN(extraParams, utils.HashtagSep, 2)
+	if len(params) != 2 || params[1] == utils.EmptyString {
+		return nil, fmt.Errorf("invalid format for %s metric: <%s>", utils.MetaPercentile, extraParams)
+	}
+	prcnt, err := strconv.ParseFloat(params[0], 64)
+	if err != nil {
+		return nil, err
+	}
+	if prcnt <= 0 || prcnt > 100 {
+		return nil, fmt.Errorf("invalid percentile value: <%s>", params[0])
+	}
+	return &StatPercentile{Events: make(map[string][]float64), Percentile: prcnt,
+		MinItems: minItems, FieldName: params[1], FilterIDs: filterIDs}, nil
+}
+
+// StatPercentile implements the percentile metric using the nearest-rank method
+type StatPercentile struct {
+	FilterIDs  []string
+	Percentile float64
+	Count      int64
+	Events     map[string][]float64 // map[EventTenantID][]Value
+	MinItems   int
+	FieldName  string
+	val        *float64 // cached percentile value
+}
+
+// getValue returns prcnt.val
+func (prcnt *StatPercentile) getValue(roundingDecimal int) float64 {
+	if prcnt.val == nil {
+		if (prcnt.MinItems > 0 && prcnt.Count < int64(prcnt.MinItems)) || (prcnt.Count == 0) {
+			prcnt.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			vals := make([]float64, 0, prcnt.Count)
+			for _, evVals := range prcnt.Events {
+				vals = append(vals, evVals...)
+			}
+			sort.Float64s(vals)
+			rank := int(math.Ceil(prcnt.Percentile / 100 * float64(len(vals))))
+			if rank < 1 {
+				rank = 1
+			}
+			prcnt.val = utils.Float64Pointer(utils.Round(vals[rank-1],
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *prcnt.val
+}
+
+func (prcnt *StatPercentile) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := prcnt.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (prcnt *StatPercentile) GetValue(roundingDecimal int) (v interface{}) {
+	return prcnt.getValue(roundingDecimal)
+}
+
+func (prcnt *StatPercentile) GetFloat64Value(roundingDecimal int) (v float64) {
+	return prcnt.getValue(roundingDecimal)
+}
+
+func (prcnt *StatPercentile) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	var ival interface{}
+	if ival, err = utils.DPDynamicInterface(prcnt.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, prcnt.FieldName)
+		}
+		return
+	} else if val, err = utils.IfaceAsFloat64(ival); err != nil {
+		return
+	}
+	prcnt.Events[evID] = append(prcnt.Events[evID], val)
+	prcnt.Count++
+	prcnt.val = nil
+	return
+}
+
+// RemEvent removes the oldest value added for the event
+func (prcnt *StatPercentile) RemEvent(evID string) (err error) {
+	vals, has := prcnt.Events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	prcnt.Count--
+	if len(vals) <= 1 {
+		delete(prcnt.Events, evID)
+	} else {
+		prcnt.Events[evID] = vals[1:]
+	}
+	prcnt.val = nil
+	return
+}
+
+func (prcnt *StatPercentile) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(prcnt)
+}
+
+func (prcnt *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, prcnt)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (prcnt *StatPercentile) GetFilterIDs() []string {
+	return prcnt.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (prcnt *StatPercentile) GetMinItems() (minIts int) { return prcnt.MinItems }
+
+// Compress is part of StatMetric interface
+// the values are not compressed since the percentile needs all of them
+// StatQueue.Compress is skipping the queues having this metric
+func (prcnt *StatPercentile) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	for id := range prcnt.Events {
+		eventIDs = append(eventIDs, id)
+	}
+	return
+}
+
+// Compress is part of StatMetric interface
+func (prcnt *StatPercentile) GetCompressFactor(events map[string]int) map[string]int {
+	for id, vals := range prcnt.Events {
+		if _, has := events[id]; !has {
+			events[id] = len(vals)
+		}
+		if events[id] < len(vals) {
+			events[id] = len(vals)
+		}
+	}
+	return events
+}
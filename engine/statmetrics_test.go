@@ -21,6 +21,7 @@ import (
 	"net"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -4164,3 +4165,302 @@ func TestStatMetricsStatASRAddEventErr3(t *testing.T) {
 		t.Errorf("\nExpecting <%+v>,\n Recevied <%+v>", utils.ErrAccountNotFound, err)
 	}
 }
+
+func TestStatHighestGetFloat64Value(t *testing.T) {
+	hgh, _ := NewStatHighest(2, "~*req.Cost", []string{})
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{"Cost": 12.3}}
+	hgh.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event})
+	if v := hgh.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != -1.0 {
+		t.Errorf("wrong highest value: %v", v)
+	}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_2",
+		Event: map[string]interface{}{"Cost": 18.3}}
+	hgh.AddEvent(ev2.ID, utils.MapStorage{utils.MetaReq: ev2.Event})
+	ev3 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_3",
+		Event: map[string]interface{}{"Cost": 5.1}}
+	hgh.AddEvent(ev3.ID, utils.MapStorage{utils.MetaReq: ev3.Event})
+	if v := hgh.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 18.3 {
+		t.Errorf("wrong highest value: %v", v)
+	}
+	if err := hgh.RemEvent(ev2.ID); err != nil {
+		t.Error(err)
+	}
+	if v := hgh.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 12.3 {
+		t.Errorf("wrong highest value: %v", v)
+	}
+	if err := hgh.RemEvent(ev2.ID); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := hgh.RemEvent(ev.ID); err != nil {
+		t.Error(err)
+	}
+	if v := hgh.GetStringValue(config.CgrConfig().GeneralCfg().RoundingDecimals); v != utils.NotAvailable {
+		t.Errorf("wrong highest value: %v", v)
+	}
+}
+
+func TestStatHighestCompress(t *testing.T) {
+	hgh := &StatHighest{
+		Events: map[string]*StatWithCompress{
+			"EVENT_1": {Stat: 6, CompressFactor: 1},
+			"EVENT_2": {Stat: 18, CompressFactor: 1},
+		},
+		FieldName: "~*req.Cost",
+		Highest:   18,
+		Count:     2,
+	}
+	expIDs := []string{"EVENT_1", "EVENT_2"}
+	rply := hgh.Compress(10, "EVENT_3", config.CgrConfig().GeneralCfg().RoundingDecimals)
+	sort.Strings(rply)
+	if !reflect.DeepEqual(expIDs, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expIDs), utils.ToJSON(rply))
+	}
+	expected := &StatHighest{
+		Events: map[string]*StatWithCompress{
+			"EVENT_3": {Stat: 18, CompressFactor: 2},
+		},
+		FieldName: "~*req.Cost",
+		Highest:   18,
+		Count:     2,
+	}
+	expIDs = []string{"EVENT_3"}
+	if rply := hgh.Compress(1, "EVENT_3", config.CgrConfig().GeneralCfg().RoundingDecimals); !reflect.DeepEqual(expIDs, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expIDs), utils.ToJSON(rply))
+	} else if !reflect.DeepEqual(expected, hgh) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expected), utils.ToJSON(hgh))
+	}
+	if v := hgh.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 18 {
+		t.Errorf("wrong highest value: %v", v)
+	}
+	expCF := map[string]int{"EVENT_3": 2}
+	if rply := hgh.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(expCF, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expCF), utils.ToJSON(rply))
+	}
+}
+
+func TestStatLowestGetFloat64Value(t *testing.T) {
+	lwst, _ := NewStatLowest(2, "~*req.Cost", []string{})
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{"Cost": 12.3}}
+	lwst.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event})
+	if v := lwst.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != -1.0 {
+		t.Errorf("wrong lowest value: %v", v)
+	}
+	ev2 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_2",
+		Event: map[string]interface{}{"Cost": 5.1}}
+	lwst.AddEvent(ev2.ID, utils.MapStorage{utils.MetaReq: ev2.Event})
+	ev3 := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_3",
+		Event: map[string]interface{}{"Cost": 18.3}}
+	lwst.AddEvent(ev3.ID, utils.MapStorage{utils.MetaReq: ev3.Event})
+	if v := lwst.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 5.1 {
+		t.Errorf("wrong lowest value: %v", v)
+	}
+	if err := lwst.RemEvent(ev2.ID); err != nil {
+		t.Error(err)
+	}
+	if v := lwst.GetStringValue(config.CgrConfig().GeneralCfg().RoundingDecimals); v != "12.3" {
+		t.Errorf("wrong lowest value: %v", v)
+	}
+}
+
+func TestStatLowestCompress(t *testing.T) {
+	lwst := &StatLowest{
+		Events: map[string]*StatWithCompress{
+			"EVENT_1": {Stat: 6, CompressFactor: 1},
+			"EVENT_2": {Stat: 18, CompressFactor: 1},
+		},
+		FieldName: "~*req.Cost",
+		Lowest:    6,
+		Count:     2,
+	}
+	expected := &StatLowest{
+		Events: map[string]*StatWithCompress{
+			"EVENT_3": {Stat: 6, CompressFactor: 2},
+		},
+		FieldName: "~*req.Cost",
+		Lowest:    6,
+		Count:     2,
+	}
+	expIDs := []string{"EVENT_3"}
+	if rply := lwst.Compress(1, "EVENT_3", config.CgrConfig().GeneralCfg().RoundingDecimals); !reflect.DeepEqual(expIDs, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expIDs), utils.ToJSON(rply))
+	} else if !reflect.DeepEqual(expected, lwst) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expected), utils.ToJSON(lwst))
+	}
+}
+
+func TestStatStdDevGetFloat64Value(t *testing.T) {
+	stdDev, _ := NewStatStdDev(2, "~*req.Cost", []string{})
+	for i, cost := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_" + strconv.Itoa(i),
+			Event: map[string]interface{}{"Cost": cost}}
+		if err := stdDev.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event}); err != nil {
+			t.Error(err)
+		}
+	}
+	if v := stdDev.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 2 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	// removing 2 and 9 leaves 4, 4, 4, 5, 5, 7
+	stdDev.RemEvent("EVENT_0")
+	stdDev.RemEvent("EVENT_7")
+	if v := stdDev.GetStringValue(config.CgrConfig().GeneralCfg().RoundingDecimals); v != "1.06719" {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	// compressing does not alter the deviation
+	if rply := stdDev.Compress(1, "EVENT_COMPRESSED", config.CgrConfig().GeneralCfg().RoundingDecimals); !reflect.DeepEqual([]string{"EVENT_COMPRESSED"}, rply) {
+		t.Errorf("received: %s", utils.ToJSON(rply))
+	}
+	if v := stdDev.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 1.06719 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	expCF := map[string]int{"EVENT_COMPRESSED": 6}
+	if rply := stdDev.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(expCF, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expCF), utils.ToJSON(rply))
+	}
+}
+
+func TestStatStdDevRemCompressed(t *testing.T) {
+	stdDev, _ := NewStatStdDev(0, "~*req.Cost", []string{})
+	for i, cost := range []float64{1, 3} {
+		if err := stdDev.AddEvent("EVENT_"+strconv.Itoa(i),
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": cost}}); err != nil {
+			t.Error(err)
+		}
+	}
+	stdDev.Compress(1, "EVENT_COMPRESSED", config.CgrConfig().GeneralCfg().RoundingDecimals)
+	if err := stdDev.AddEvent("EVENT_2",
+		utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": 5}}); err != nil {
+		t.Error(err)
+	}
+	// removing 1 and 3 leaves only 5
+	for i := 0; i < 2; i++ {
+		if err := stdDev.RemEvent("EVENT_COMPRESSED"); err != nil {
+			t.Error(err)
+		}
+	}
+	if v := stdDev.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 0 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+}
+
+func TestStatPercentileGetFloat64Value(t *testing.T) {
+	prcnt, err := NewStatMetric("*percentile#90#~*req.Usage", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{"Usage": time.Second}}
+	prcnt.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event})
+	if v := prcnt.GetStringValue(config.CgrConfig().GeneralCfg().RoundingDecimals); v != utils.NotAvailable {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	for i := 2; i <= 10; i++ {
+		ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_" + strconv.Itoa(i),
+			Event: map[string]interface{}{"Usage": time.Duration(i) * time.Second}}
+		if err := prcnt.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event}); err != nil {
+			t.Error(err)
+		}
+	}
+	if v := prcnt.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != float64(9*time.Second) {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	prcnt.RemEvent("EVENT_10")
+	prcnt.RemEvent("EVENT_9")
+	if v := prcnt.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != float64(8*time.Second) {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	expIDs := []string{"EVENT_1", "EVENT_2", "EVENT_3", "EVENT_4", "EVENT_5", "EVENT_6", "EVENT_7", "EVENT_8"}
+	rply := prcnt.Compress(1, "EVENT_COMPRESSED", config.CgrConfig().GeneralCfg().RoundingDecimals)
+	sort.Strings(rply)
+	if !reflect.DeepEqual(expIDs, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expIDs), utils.ToJSON(rply))
+	}
+}
+
+func TestStatPercentileNewErrors(t *testing.T) {
+	for _, metricID := range []string{
+		"*percentile",
+		"*percentile#95",
+		"*percentile#~*req.Usage",
+		"*percentile#0#~*req.Usage",
+		"*percentile#101#~*req.Usage",
+	} {
+		if _, err := NewStatMetric(metricID, 0, []string{}); err == nil {
+			t.Errorf("expecting error for metric <%s>", metricID)
+		}
+	}
+}
+
+func TestStatHighestMarshal(t *testing.T) {
+	hgh, _ := NewStatHighest(2, "~*req.Cost", []string{})
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{"Cost": "20"}}
+	hgh.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event})
+	var nHgh StatHighest
+	expected := []byte(`{"FilterIDs":[],"Highest":20,"Count":1,"Events":{"EVENT_1":{"Stat":20,"CompressFactor":1}},"MinItems":2,"FieldName":"~*req.Cost"}`)
+	if b, err := hgh.Marshal(&jMarshaler); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, b) {
+		t.Errorf("Expected: %s , received: %s", string(expected), string(b))
+	} else if err := nHgh.LoadMarshaled(&jMarshaler, b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(hgh, &nHgh) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(hgh), utils.ToJSON(nHgh))
+	}
+}
+
+func TestStatLowestMarshal(t *testing.T) {
+	lwst, _ := NewStatLowest(2, "~*req.Cost", []string{})
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{"Cost": "20"}}
+	lwst.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event})
+	var nLwst StatLowest
+	expected := []byte(`{"FilterIDs":[],"Lowest":20,"Count":1,"Events":{"EVENT_1":{"Stat":20,"CompressFactor":1}},"MinItems":2,"FieldName":"~*req.Cost"}`)
+	if b, err := lwst.Marshal(&jMarshaler); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, b) {
+		t.Errorf("Expected: %s , received: %s", string(expected), string(b))
+	} else if err := nLwst.LoadMarshaled(&jMarshaler, b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(lwst, &nLwst) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(lwst), utils.ToJSON(nLwst))
+	}
+}
+
+func TestStatStdDevMarshal(t *testing.T) {
+	stdDev, _ := NewStatStdDev(2, "~*req.Cost", []string{})
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{"Cost": "20"}}
+	stdDev.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event})
+	var nStdDev StatStdDev
+	expected := []byte(`{"FilterIDs":[],"Sum":20,"SumSquares":400,"Count":1,"Events":{"EVENT_1":{"Stat":20,"SquaresStat":400,"CompressFactor":1}},"MinItems":2,"FieldName":"~*req.Cost"}`)
+	if b, err := stdDev.Marshal(&jMarshaler); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, b) {
+		t.Errorf("Expected: %s , received: %s", string(expected), string(b))
+	} else if err := nStdDev.LoadMarshaled(&jMarshaler, b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(stdDev, &nStdDev) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(stdDev), utils.ToJSON(nStdDev))
+	}
+}
+
+func TestStatPercentileMarshal(t *testing.T) {
+	prcnt, _ := NewStatPercentile(2, "95#~*req.Cost", []string{})
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1",
+		Event: map[string]interface{}{"Cost": "20"}}
+	prcnt.AddEvent(ev.ID, utils.MapStorage{utils.MetaReq: ev.Event})
+	var nPrcnt StatPercentile
+	expected := []byte(`{"FilterIDs":[],"Percentile":95,"Count":1,"Events":{"EVENT_1":[20]},"MinItems":2,"FieldName":"~*req.Cost"}`)
+	if b, err := prcnt.Marshal(&jMarshaler); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, b) {
+		t.Errorf("Expected: %s , received: %s", string(expected), string(b))
+	} else if err := nPrcnt.LoadMarshaled(&jMarshaler, b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(prcnt, &nPrcnt) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(prcnt), utils.ToJSON(nPrcnt))
+	}
+}
@@ -39,6 +39,7 @@ func NewStatService(dm *DataManager, cgrcfg *config.CGRConfig,
 		filterS:          filterS,
 		cgrcfg:           cgrcfg,
 		storedStatQueues: make(utils.StringSet),
+		sqGroups:         make(map[string]map[string]time.Time),
 		loopStoped:       make(chan struct{}),
 		stopBackup:       make(chan struct{}),
 	}
@@ -52,8 +53,10 @@ type StatService struct {
 	cgrcfg           *config.CGRConfig
 	loopStoped       chan struct{}
 	stopBackup       chan struct{}
-	storedStatQueues utils.StringSet // keep a record of stats which need saving, map[statsTenantID]bool
-	ssqMux           sync.RWMutex    // protects storedStatQueues
+	storedStatQueues utils.StringSet                 // keep a record of stats which need saving, map[statsTenantID]bool
+	ssqMux           sync.RWMutex                    // protects storedStatQueues
+	sqGroups         map[string]map[string]time.Time // last usage of the child queues, map[sqPrflTenantID]map[sqID]time.Time
+	sqgMux           sync.RWMutex                    // protects sqGroups
 }
 
 // Shutdown is called to shutdown the service
@@ -173,20 +176,39 @@ func (sS *StatService) matchingStatQueuesForEvent(tnt string, statsIDs []string,
 		} else if !pass {
 			continue
 		}
+		sqID := sqPrfl.ID
+		if sqPrfl.GroupBy != utils.EmptyString {
+			if sqID, err = sqPrfl.groupQueueID(evNm); err != nil {
+				if err == utils.ErrNotFound {
+					continue
+				}
+				return nil, err
+			}
+		}
 		var sq *StatQueue
-		lkID := utils.StatQueuePrefix + utils.ConcatenatedKey(sqPrfl.Tenant, sqPrfl.ID)
+		lkID := utils.StatQueuePrefix + utils.ConcatenatedKey(sqPrfl.Tenant, sqID)
 		guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
-			sq, err = sS.dm.GetStatQueue(sqPrfl.Tenant, sqPrfl.ID, true, true, "")
+			sq, err = sS.dm.GetStatQueue(sqPrfl.Tenant, sqID, true, true, "")
+			if err == utils.ErrNotFound && sqPrfl.GroupBy != utils.EmptyString {
+				sq, err = sS.newGroupStatQueue(sqPrfl, sqID)
+			}
 			return
 		}, sS.cgrcfg.GeneralCfg().LockingTimeout, lkID)
 		if err != nil {
 			return nil, err
 		}
+		if sqPrfl.GroupBy != utils.EmptyString {
+			if err = sS.touchGroupStatQueue(sqPrfl, sqID); err != nil {
+				return nil, err
+			}
+		}
 		if sqPrfl.Stored && sq.dirty == nil {
 			sq.dirty = utils.BoolPointer(false)
 		}
 		if sqPrfl.TTL > 0 {
 			sq.ttl = utils.DurationPointer(sqPrfl.TTL)
+		} else if sqPrfl.WindowType == utils.MetaSliding && sqPrfl.WindowLength > 0 {
+			sq.ttl = utils.DurationPointer(sqPrfl.WindowLength)
 		}
 		sq.sqPrfl = sqPrfl
 		sqs = append(sqs, sq)
@@ -246,23 +268,91 @@ func (attr *StatsArgsProcessEvent) Clone() *StatsArgsProcessEvent {
 	}
 }
 
-func (sS *StatService) getStatQueue(tnt, id string) (sq *StatQueue, err error) {
+// getStatQueue returns the queue with the expired items removed and the current window processed
+// sqPrfl is the profile of the queue, queried from DataDB if not provided
+func (sS *StatService) getStatQueue(tnt, id string, sqPrfl *StatQueueProfile) (sq *StatQueue, err error) {
 	if sq, err = sS.dm.GetStatQueue(tnt, id, true, true, utils.EmptyString); err != nil {
 		return
 	}
+	if sqPrfl == nil {
+		if sqPrfl, err = sS.dm.GetStatQueueProfile(tnt, id, true, true, utils.NonTransactional); err != nil {
+			if err != utils.ErrNotFound {
+				return nil, err
+			}
+			err = nil // queue without profile, nothing to process
+		}
+	}
 	lkID := utils.StatQueuePrefix + sq.TenantID()
-	var removed int
+	var changed bool
 	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
-		removed, err = sq.remExpired()
+		if sq.sqPrfl == nil {
+			sq.sqPrfl = sqPrfl
+		}
+		var removed int
+		if removed, err = sq.remExpired(); err != nil {
+			return
+		}
+		var closed bool
+		closed, err = sS.processWindow(sq, time.Now())
+		changed = removed != 0 || closed
 		return
 	}, sS.cgrcfg.GeneralCfg().LockingTimeout, lkID)
-	if err != nil || removed == 0 {
+	if err != nil || !changed {
 		return
 	}
 	sS.storeStatQueue(sq)
 	return
 }
 
+// processWindow starts a new window once the end of the current one is reached,
+// storing the snapshot of the metrics and resetting the *tumbling queues
+// the queue should be locked by the caller
+func (sS *StatService) processWindow(sq *StatQueue, now time.Time) (changed bool, err error) {
+	if sq.sqPrfl == nil || sq.sqPrfl.WindowType == utils.EmptyString ||
+		sq.sqPrfl.WindowLength <= 0 {
+		return
+	}
+	wStart := now.Truncate(sq.sqPrfl.WindowLength)
+	if sq.WindowStart != nil {
+		if !sq.WindowStart.Before(wStart) { // still within the current window
+			return
+		}
+		if sq.sqPrfl.WindowHistory > 0 {
+			if err = sS.storeWindow(sq); err != nil {
+				return
+			}
+		}
+		if sq.sqPrfl.WindowType == utils.MetaTumbling {
+			if err = sq.reset(); err != nil {
+				return
+			}
+		}
+	}
+	sq.WindowStart = &wStart
+	return true, nil
+}
+
+// storeWindow adds the snapshot of the current window to the history of the queue
+func (sS *StatService) storeWindow(sq *StatQueue) (err error) {
+	var sqh *StatQueueHistory
+	if sqh, err = sS.dm.GetStatQueueHistory(sq.Tenant, sq.ID); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		sqh = &StatQueueHistory{Tenant: sq.Tenant, ID: sq.ID}
+	}
+	sqw := &StatQueueWindow{
+		StartTime: *sq.WindowStart,
+		EndTime:   sq.WindowStart.Add(sq.sqPrfl.WindowLength),
+		Metrics:   make(map[string]float64, len(sq.SQMetrics)),
+	}
+	for metricID, metric := range sq.SQMetrics {
+		sqw.Metrics[metricID] = metric.GetFloat64Value(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+	}
+	sqh.AddWindow(sqw, sq.sqPrfl.WindowHistory)
+	return sS.dm.SetStatQueueHistory(sqh)
+}
+
 // storeStatQueue will store the sq if needed
 func (sS *StatService) storeStatQueue(sq *StatQueue) {
 	if sS.cgrcfg.StatSCfg().StoreInterval != 0 && sq.dirty != nil { // don't save
@@ -277,6 +367,153 @@ func (sS *StatService) storeStatQueue(sq *StatQueue) {
 	}
 }
 
+// newGroupStatQueue creates and caches a new child queue for a grouped profile
+func (sS *StatService) newGroupStatQueue(sqPrfl *StatQueueProfile, sqID string) (sq *StatQueue, err error) {
+	if sq, err = NewStatQueue(sqPrfl.Tenant, sqID, sqPrfl.Metrics,
+		sqPrfl.MinItems); err != nil {
+		return
+	}
+	if err = sS.dm.SetStatQueue(sq, nil, 0, nil, 0, true); err != nil {
+		return
+	}
+	err = Cache.Set(utils.CacheStatQueues, sq.TenantID(), sq, nil,
+		true, utils.NonTransactional)
+	return
+}
+
+// loadGroupStatQueues populates the usage of the child queues from DataDB
+// for the grouped profiles not yet known to this service (ie: after restart)
+func (sS *StatService) loadGroupStatQueues(sqPrfl *StatQueueProfile) (err error) {
+	sS.sqgMux.RLock()
+	_, has := sS.sqGroups[sqPrfl.TenantID()]
+	sS.sqgMux.RUnlock()
+	if has {
+		return
+	}
+	prfx := utils.StatQueuePrefix + sqPrfl.TenantID() + utils.ConcatenatedKeySep
+	var keys []string
+	if keys, err = sS.dm.DataDB().GetKeysForPrefix(prfx); err != nil {
+		return
+	}
+	idIdx := len(utils.StatQueuePrefix + sqPrfl.Tenant + utils.ConcatenatedKeySep)
+	now := time.Now()
+	sS.sqgMux.Lock()
+	if sS.sqGroups == nil {
+		sS.sqGroups = make(map[string]map[string]time.Time)
+	}
+	if _, has = sS.sqGroups[sqPrfl.TenantID()]; !has {
+		sS.sqGroups[sqPrfl.TenantID()] = make(map[string]time.Time)
+		for _, key := range keys {
+			sS.sqGroups[sqPrfl.TenantID()][key[idIdx:]] = now
+		}
+	}
+	sS.sqgMux.Unlock()
+	return
+}
+
+// touchGroupStatQueue records the usage of a child queue and evicts the idle ones
+func (sS *StatService) touchGroupStatQueue(sqPrfl *StatQueueProfile, sqID string) (err error) {
+	if err = sS.loadGroupStatQueues(sqPrfl); err != nil {
+		return
+	}
+	sS.sqgMux.Lock()
+	sS.sqGroups[sqPrfl.TenantID()][sqID] = time.Now()
+	sS.sqgMux.Unlock()
+	sS.evictGroupStatQueues(sqPrfl)
+	return
+}
+
+// evictGroupStatQueues removes the child queues idle for longer than the GroupTTL
+func (sS *StatService) evictGroupStatQueues(sqPrfl *StatQueueProfile) {
+	if sqPrfl.GroupTTL <= 0 {
+		return
+	}
+	var expIDs []string
+	now := time.Now()
+	sS.sqgMux.Lock()
+	for sqID, lastUsed := range sS.sqGroups[sqPrfl.TenantID()] {
+		if now.Sub(lastUsed) >= sqPrfl.GroupTTL {
+			expIDs = append(expIDs, sqID)
+			delete(sS.sqGroups[sqPrfl.TenantID()], sqID)
+		}
+	}
+	sS.sqgMux.Unlock()
+	for _, sqID := range expIDs {
+		tntID := utils.ConcatenatedKey(sqPrfl.Tenant, sqID)
+		guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+			if err := sS.dm.RemoveStatQueue(sqPrfl.Tenant, sqID, utils.NonTransactional); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> failed removing idle StatQueue with ID: %s, error: %s",
+						utils.StatService, tntID, err.Error()))
+			}
+			Cache.Remove(utils.CacheStatQueues, tntID, true, utils.NonTransactional)
+			return
+		}, sS.cgrcfg.GeneralCfg().LockingTimeout, utils.StatQueuePrefix+tntID)
+		sS.ssqMux.Lock()
+		sS.storedStatQueues.Remove(tntID)
+		sS.ssqMux.Unlock()
+	}
+}
+
+// getGroupStatQueues returns the child queues of a grouped profile indexed on the group value
+func (sS *StatService) getGroupStatQueues(sqPrfl *StatQueueProfile) (sqs map[string]*StatQueue, err error) {
+	if err = sS.loadGroupStatQueues(sqPrfl); err != nil {
+		return
+	}
+	sS.evictGroupStatQueues(sqPrfl)
+	sS.sqgMux.RLock()
+	sqIDs := make([]string, 0, len(sS.sqGroups[sqPrfl.TenantID()]))
+	for sqID := range sS.sqGroups[sqPrfl.TenantID()] {
+		sqIDs = append(sqIDs, sqID)
+	}
+	sS.sqgMux.RUnlock()
+	sqs = make(map[string]*StatQueue)
+	for _, sqID := range sqIDs {
+		var sq *StatQueue
+		if sq, err = sS.getStatQueue(sqPrfl.Tenant, sqID, sqPrfl); err != nil {
+			if err != utils.ErrNotFound {
+				return nil, err
+			}
+			// removed in the meantime
+			sS.sqgMux.Lock()
+			delete(sS.sqGroups[sqPrfl.TenantID()], sqID)
+			sS.sqgMux.Unlock()
+			continue
+		}
+		sqs[sqID[len(sqPrfl.ID)+1:]] = sq
+	}
+	if len(sqs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return sqs, nil
+}
+
+// statQueuesForMetrics returns the queue with the given ID or, for grouped profiles,
+// all the child queues indexed on the group value
+func (sS *StatService) statQueuesForMetrics(tnt, id string) (sqs map[string]*StatQueue, err error) {
+	var sqPrfl *StatQueueProfile
+	if sqPrfl, err = sS.dm.GetStatQueueProfile(tnt, id, true, true, utils.NonTransactional); err != nil &&
+		err != utils.ErrNotFound {
+		return
+	}
+	if err == nil && sqPrfl.GroupBy != utils.EmptyString {
+		return sS.getGroupStatQueues(sqPrfl)
+	}
+	var sq *StatQueue
+	if sq, err = sS.getStatQueue(tnt, id, sqPrfl); err != nil {
+		return
+	}
+	return map[string]*StatQueue{utils.EmptyString: sq}, nil
+}
+
+// groupMetricID prefixes the metricID with the group value of the child queue
+func groupMetricID(grpVal, metricID string) string {
+	if grpVal == utils.EmptyString {
+		return metricID
+	}
+	return utils.ConcatenatedKey(grpVal, metricID)
+}
+
 // processEvent processes a new event, dispatching to matching queues
 // queues matching are also cached to speed up
 func (sS *StatService) processEvent(tnt string, args *StatsArgsProcessEvent) (statQueueIDs []string, err error) {
@@ -301,6 +538,9 @@ func (sS *StatService) processEvent(tnt string, args *StatsArgsProcessEvent) (st
 		stsIDs = append(stsIDs, sq.ID)
 		lkID := utils.StatQueuePrefix + sq.TenantID()
 		guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+			if _, err = sS.processWindow(sq, time.Now()); err != nil {
+				return
+			}
 			err = sq.ProcessEvent(tnt, args.ID, sS.filterS, evNm)
 			return
 		}, sS.cgrcfg.GeneralCfg().LockingTimeout, lkID)
@@ -415,7 +655,7 @@ func (sS *StatService) V1GetStatQueue(args *utils.TenantIDWithOpts, reply *StatQ
 	if tnt == utils.EmptyString {
 		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
 	}
-	sq, err := sS.getStatQueue(tnt, args.ID)
+	sq, err := sS.getStatQueue(tnt, args.ID, nil)
 	if err != nil {
 		return err
 	}
@@ -432,19 +672,21 @@ func (sS *StatService) V1GetQueueStringMetrics(args *utils.TenantID, reply *map[
 	if tnt == utils.EmptyString {
 		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
 	}
-	sq, err := sS.getStatQueue(tnt, args.ID)
+	sqs, err := sS.statQueuesForMetrics(tnt, args.ID)
 	if err != nil {
 		if err != utils.ErrNotFound {
 			err = utils.NewErrServerError(err)
 		}
 		return err
 	}
-	sq.RLock()
-	metrics := make(map[string]string, len(sq.SQMetrics))
-	for metricID, metric := range sq.SQMetrics {
-		metrics[metricID] = metric.GetStringValue(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+	metrics := make(map[string]string)
+	for grpVal, sq := range sqs {
+		sq.RLock()
+		for metricID, metric := range sq.SQMetrics {
+			metrics[groupMetricID(grpVal, metricID)] = metric.GetStringValue(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+		}
+		sq.RUnlock()
 	}
-	sq.RUnlock()
 	*reply = metrics
 	return
 }
@@ -458,23 +700,58 @@ func (sS *StatService) V1GetQueueFloatMetrics(args *utils.TenantID, reply *map[s
 	if tnt == utils.EmptyString {
 		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
 	}
-	sq, err := sS.getStatQueue(tnt, args.ID)
+	sqs, err := sS.statQueuesForMetrics(tnt, args.ID)
 	if err != nil {
 		if err != utils.ErrNotFound {
 			err = utils.NewErrServerError(err)
 		}
 		return err
 	}
-	sq.RLock()
-	metrics := make(map[string]float64, len(sq.SQMetrics))
-	for metricID, metric := range sq.SQMetrics {
-		metrics[metricID] = metric.GetFloat64Value(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+	metrics := make(map[string]float64)
+	for grpVal, sq := range sqs {
+		sq.RLock()
+		for metricID, metric := range sq.SQMetrics {
+			metrics[groupMetricID(grpVal, metricID)] = metric.GetFloat64Value(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+		}
+		sq.RUnlock()
 	}
-	sq.RUnlock()
 	*reply = metrics
 	return
 }
 
+// V1GetQueueHistory returns the metric snapshots of the past windows of a StatQueue
+func (sS *StatService) V1GetQueueHistory(args *utils.TenantID, reply *StatQueueHistory) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	// closes the current window if it ended
+	if _, err = sS.getStatQueue(tnt, args.ID, nil); err != nil &&
+		err != utils.ErrNotFound {
+		return utils.NewErrServerError(err)
+	}
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		var sqh *StatQueueHistory
+		if sqh, err = sS.dm.GetStatQueueHistory(tnt, args.ID); err != nil {
+			return
+		}
+		*reply = StatQueueHistory{
+			Tenant:  sqh.Tenant,
+			ID:      sqh.ID,
+			Windows: make([]*StatQueueWindow, len(sqh.Windows)),
+		}
+		copy(reply.Windows, sqh.Windows)
+		return
+	}, sS.cgrcfg.GeneralCfg().LockingTimeout, utils.StatQueuePrefix+utils.ConcatenatedKey(tnt, args.ID))
+	if err != nil && err != utils.ErrNotFound {
+		err = utils.NewErrServerError(err)
+	}
+	return
+}
+
 // V1GetQueueIDs returns list of queueIDs registered for a tenant
 func (sS *StatService) V1GetQueueIDs(tenant string, qIDs *[]string) (err error) {
 	if tenant == utils.EmptyString {
@@ -515,16 +792,8 @@ func (sS *StatService) V1ResetStatQueue(tntID *utils.TenantID, rply *string) (er
 	}
 	sq.Lock()
 	defer sq.Unlock()
-	sq.SQItems = make([]SQItem, 0)
-	metrics := sq.SQMetrics
-	sq.SQMetrics = make(map[string]StatMetric)
-	for id, m := range metrics {
-		var metric StatMetric
-		if metric, err = NewStatMetric(id,
-			m.GetMinItems(), m.GetFilterIDs()); err != nil {
-			return
-		}
-		sq.SQMetrics[id] = metric
+	if err = sq.reset(); err != nil {
+		return
 	}
 	sq.dirty = utils.BoolPointer(true)
 	sS.storeStatQueue(sq)
@@ -1021,3 +1021,244 @@ func TestStatQueuesV1ProcessEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected, reply)
 	}
 }
+
+func TestStatQueuesGroupBy(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.StatSCfg().StoreInterval = 0
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	sS := NewStatService(dm, cfg, &FilterS{dm: dm, cfg: cfg}, nil)
+	sqp := &StatQueueProfile{
+		Tenant:      "cgrates.org",
+		ID:          "SQ_PREFIX",
+		QueueLength: 10,
+		Metrics: []*MetricWithFilters{
+			{MetricID: utils.MetaSum + utils.HashtagSep + "~*req.Cost"},
+		},
+		MinItems: 1,
+		GroupBy:  "~*req.Destination{*prefix:4}",
+		GroupTTL: 50 * time.Millisecond,
+	}
+	if err := dm.SetStatQueueProfile(sqp, false); err != nil {
+		t.Fatal(err)
+	}
+	if sqp.groupBy == nil {
+		t.Error("Expected GroupBy to be compiled when setting the profile")
+	}
+	defer func() {
+		dm.RemoveStatQueueProfile("cgrates.org", "SQ_PREFIX", utils.NonTransactional, false)
+		for _, sqID := range []string{"SQ_PREFIX:+498", "SQ_PREFIX:+402"} {
+			dm.RemoveStatQueue("cgrates.org", sqID, utils.NonTransactional)
+			Cache.Remove(utils.CacheStatQueues, "cgrates.org:"+sqID, true, utils.NonTransactional)
+		}
+	}()
+	for i, dst := range []string{"+4986517174963", "+4986517174964", "+40214039011"} {
+		args := &StatsArgsProcessEvent{
+			StatIDs: []string{"SQ_PREFIX"},
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     utils.GenUUID(),
+				Event: map[string]interface{}{
+					utils.Destination: dst,
+					utils.Cost:        float64(i + 1),
+				},
+			},
+		}
+		var ids []string
+		if err := sS.V1ProcessEvent(args, &ids); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// event without the group field does not match
+	var ids []string
+	if err := sS.V1ProcessEvent(&StatsArgsProcessEvent{
+		StatIDs: []string{"SQ_PREFIX"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "NO_DEST",
+			Event:  map[string]interface{}{utils.Cost: 1.0},
+		},
+	}, &ids); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	metricID := utils.MetaSum + utils.HashtagSep + "~*req.Cost"
+	exp := map[string]string{
+		"+498:" + metricID: "3",
+		"+402:" + metricID: "3",
+	}
+	var rply map[string]string
+	if err := sS.V1GetQueueStringMetrics(&utils.TenantID{Tenant: "cgrates.org", ID: "SQ_PREFIX"}, &rply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+	exp = map[string]string{metricID: "3"}
+	if err := sS.V1GetQueueStringMetrics(&utils.TenantID{Tenant: "cgrates.org", ID: "SQ_PREFIX:+498"}, &rply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+	// idle children are evicted
+	time.Sleep(60 * time.Millisecond)
+	if err := sS.V1ProcessEvent(&StatsArgsProcessEvent{
+		StatIDs: []string{"SQ_PREFIX"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EV_498",
+			Event: map[string]interface{}{
+				utils.Destination: "+4986517174965",
+				utils.Cost:        1.0,
+			},
+		},
+	}, &ids); err != nil {
+		t.Fatal(err)
+	}
+	var fRply map[string]float64
+	expF := map[string]float64{"+498:" + metricID: 4}
+	if err := sS.V1GetQueueFloatMetrics(&utils.TenantID{Tenant: "cgrates.org", ID: "SQ_PREFIX"}, &fRply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expF, fRply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expF), utils.ToJSON(fRply))
+	}
+	if _, err := dm.GetStatQueue("cgrates.org", "SQ_PREFIX:+402", true, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestStatQueuesWindows(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.StatSCfg().StoreInterval = 0
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	sS := NewStatService(dm, cfg, &FilterS{dm: dm, cfg: cfg}, nil)
+	sqp := &StatQueueProfile{
+		Tenant: "cgrates.org",
+		ID:     "SQ_WINDOWS",
+		Metrics: []*MetricWithFilters{
+			{MetricID: utils.MetaSum + utils.HashtagSep + "~*req.Cost"},
+		},
+		WindowType:    utils.MetaTumbling,
+		WindowLength:  time.Hour,
+		WindowHistory: 2,
+	}
+	sq, err := NewStatQueue(sqp.Tenant, sqp.ID, sqp.Metrics, sqp.MinItems)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sq.sqPrfl = sqp
+	if err := dm.SetStatQueue(sq, nil, 0, nil, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dm.RemoveStatQueue(sqp.Tenant, sqp.ID, utils.NonTransactional)
+		dm.RemoveStatQueueHistory(sqp.Tenant, sqp.ID)
+	}()
+	addEv := func(cost float64) {
+		if err := sq.ProcessEvent(sqp.Tenant, utils.GenUUID(), sS.filterS, utils.MapStorage{
+			utils.MetaReq: map[string]interface{}{utils.Cost: cost}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	if changed, err := sS.processWindow(sq, base.Add(15*time.Minute)); err != nil {
+		t.Fatal(err)
+	} else if !changed || !sq.WindowStart.Equal(base) {
+		t.Errorf("unexpected window start: %v", sq.WindowStart)
+	}
+	addEv(10)
+	if changed, err := sS.processWindow(sq, base.Add(45*time.Minute)); err != nil {
+		t.Fatal(err)
+	} else if changed {
+		t.Error("window should not change")
+	}
+	if _, err := sS.processWindow(sq, base.Add(time.Hour+5*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	metricID := utils.MetaSum + utils.HashtagSep + "~*req.Cost"
+	if val := sq.SQMetrics[metricID].GetFloat64Value(2); val != utils.StatsNA {
+		t.Errorf("expected the metrics to be reset, received: %v", val)
+	}
+	addEv(5)
+	if _, err := sS.processWindow(sq, base.Add(2*time.Hour+10*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	// the third window is closed on query, keeping only the last two
+	exp := StatQueueHistory{
+		Tenant: sqp.Tenant,
+		ID:     sqp.ID,
+		Windows: []*StatQueueWindow{
+			{
+				StartTime: base.Add(time.Hour),
+				EndTime:   base.Add(2 * time.Hour),
+				Metrics:   map[string]float64{metricID: 5},
+			},
+			{
+				StartTime: base.Add(2 * time.Hour),
+				EndTime:   base.Add(3 * time.Hour),
+				Metrics:   map[string]float64{metricID: utils.StatsNA},
+			},
+		},
+	}
+	var rply StatQueueHistory
+	if err := sS.V1GetQueueHistory(&utils.TenantID{Tenant: sqp.Tenant, ID: sqp.ID}, &rply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+	// *sliding windows keep the items
+	sqp.WindowType = utils.MetaSliding
+	addEv(7)
+	if _, err := sS.processWindow(sq, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if val := sq.SQMetrics[metricID].GetFloat64Value(2); val != 7 {
+		t.Errorf("expected the metrics to be kept, received: %v", val)
+	}
+	if err := sS.V1GetQueueHistory(&utils.TenantID{Tenant: sqp.Tenant, ID: "SQ_NO_HISTORY"}, &rply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestStatQueuesWindowsOnRead(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.StatSCfg().StoreInterval = 0
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	sS := NewStatService(dm, cfg, &FilterS{dm: dm, cfg: cfg}, nil)
+	sqp := &StatQueueProfile{
+		Tenant: "cgrates.org",
+		ID:     "SQ_WINDOWS_READ",
+		Metrics: []*MetricWithFilters{
+			{MetricID: utils.MetaSum + utils.HashtagSep + "~*req.Cost"},
+		},
+		WindowType:    utils.MetaTumbling,
+		WindowLength:  time.Hour,
+		WindowHistory: 1,
+	}
+	if err := dm.SetStatQueueProfile(sqp, true); err != nil {
+		t.Fatal(err)
+	}
+	// queue loaded after a restart, without the profile attached
+	sq, err := NewStatQueue(sqp.Tenant, sqp.ID, sqp.Metrics, sqp.MinItems)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wStart := time.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+	sq.WindowStart = &wStart
+	if err := dm.SetStatQueue(sq, nil, 0, nil, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dm.RemoveStatQueueProfile(sqp.Tenant, sqp.ID, utils.NonTransactional, true)
+		dm.RemoveStatQueue(sqp.Tenant, sqp.ID, utils.NonTransactional)
+		dm.RemoveStatQueueHistory(sqp.Tenant, sqp.ID)
+	}()
+	var rply StatQueueHistory
+	if err := sS.V1GetQueueHistory(&utils.TenantID{Tenant: sqp.Tenant, ID: sqp.ID}, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply.Windows) != 1 || !rply.Windows[0].StartTime.Equal(wStart) {
+		t.Errorf("expected the expired window to be closed on read, received: %s", utils.ToJSON(rply))
+	}
+	if rcv, err := dm.GetStatQueue(sqp.Tenant, sqp.ID, true, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if exp := time.Now().Truncate(time.Hour); rcv.WindowStart == nil || !rcv.WindowStart.Equal(exp) {
+		t.Errorf("expected window start: %v, received: %v", exp, rcv.WindowStart)
+	}
+}
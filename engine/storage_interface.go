@@ -138,6 +138,21 @@ type DataDB interface {
 	GetAccountProfileDrv(string, string) (*utils.AccountProfile, error)
 	SetAccountProfileDrv(profile *utils.AccountProfile) error
 	RemoveAccountProfileDrv(string, string) error
+	GetCurrencyConversionsDrv(string) (*CurrencyConversions, error)
+	SetCurrencyConversionsDrv(*CurrencyConversions) error
+	RemoveCurrencyConversionsDrv(string) error
+	GetRateVolumeCounterDrv(string, string) (*RateVolumeCounter, error)
+	SetRateVolumeCounterDrv(*RateVolumeCounter) error
+	RemoveRateVolumeCounterDrv(string, string) error
+	GetActionScheduleDrv(string, string) (*ActionSchedule, error)
+	SetActionScheduleDrv(*ActionSchedule) error
+	RemoveActionScheduleDrv(string, string) error
+	GetStatQueueHistoryDrv(string, string) (*StatQueueHistory, error)
+	SetStatQueueHistoryDrv(*StatQueueHistory) error
+	RemoveStatQueueHistoryDrv(string, string) error
+	GetStoredSessionDrv(string, string) (*StoredSession, error)
+	SetStoredSessionDrv(*StoredSession) error
+	RemoveStoredSessionDrv(string, string) error
 }
 
 type StorDB interface {
@@ -596,6 +596,9 @@ func (iDB *InternalDB) GetStatQueueProfileDrv(tenant string, id string) (sq *Sta
 
 }
 func (iDB *InternalDB) SetStatQueueProfileDrv(sq *StatQueueProfile) (err error) {
+	if err = sq.Compile(); err != nil {
+		return
+	}
 	Cache.SetWithoutReplicate(utils.CacheStatQueueProfiles, sq.TenantID(), sq, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
@@ -955,3 +958,103 @@ func (iDB *InternalDB) RemoveAccountProfileDrv(tenant, id string) (err error) {
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
+
+func (iDB *InternalDB) GetCurrencyConversionsDrv(tenant string) (cC *CurrencyConversions, err error) {
+	x, ok := Cache.Get(utils.CacheCurrencyConversions, tenant)
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*CurrencyConversions), nil
+}
+
+func (iDB *InternalDB) SetCurrencyConversionsDrv(cC *CurrencyConversions) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheCurrencyConversions, cC.Tenant, cC, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveCurrencyConversionsDrv(tenant string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheCurrencyConversions, tenant,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetRateVolumeCounterDrv(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	x, ok := Cache.Get(utils.CacheRateVolumeCounters, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*RateVolumeCounter), nil
+}
+
+func (iDB *InternalDB) SetRateVolumeCounterDrv(rvc *RateVolumeCounter) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheRateVolumeCounters, rvc.TenantID(), rvc, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveRateVolumeCounterDrv(tenant, id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheRateVolumeCounters, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetActionScheduleDrv(tenant, id string) (aSched *ActionSchedule, err error) {
+	x, ok := Cache.Get(utils.CacheActionSchedules, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*ActionSchedule), nil
+}
+
+func (iDB *InternalDB) SetActionScheduleDrv(aSched *ActionSchedule) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheActionSchedules, aSched.TenantID(), aSched, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveActionScheduleDrv(tenant, id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheActionSchedules, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetStatQueueHistoryDrv(tenant, id string) (sqh *StatQueueHistory, err error) {
+	x, ok := Cache.Get(utils.CacheStatQueueHistories, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*StatQueueHistory), nil
+}
+
+func (iDB *InternalDB) SetStatQueueHistoryDrv(sqh *StatQueueHistory) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheStatQueueHistories, sqh.TenantID(), sqh, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveStatQueueHistoryDrv(tenant, id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheStatQueueHistories, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetStoredSessionDrv(tenant, id string) (ss *StoredSession, err error) {
+	x, ok := Cache.Get(utils.CacheStoredSessions, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*StoredSession), nil
+}
+
+func (iDB *InternalDB) SetStoredSessionDrv(ss *StoredSession) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheStoredSessions, ss.TenantID(), ss, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveStoredSessionDrv(tenant, id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheStoredSessions, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
@@ -80,6 +80,11 @@ const (
 	ColApp  = "action_profiles"
 	ColLID  = "load_ids"
 	ColAnp  = "account_profiles"
+	ColCcv  = "currency_conversions"
+	ColRvc  = "rate_volume_counters"
+	ColAcs  = "action_schedules"
+	ColSqh  = "statqueue_histories"
+	ColSts  = "stored_sessions"
 )
 
 var (
@@ -316,7 +321,8 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "key"); err != nil {
 			return
 		}
-	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp, ColApp, ColAnp:
+	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp, ColApp, ColAnp,
+		ColRvc, ColAcs, ColSqh, ColSts:
 		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
 			return
 		}
@@ -324,6 +330,10 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "id"); err != nil {
 			return
 		}
+	case ColCcv:
+		if err = ms.enusureIndex(col, true, "tenant"); err != nil {
+			return
+		}
 		//StorDB
 	case utils.TBLTPTimings, utils.TBLTPDestinations,
 		utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
@@ -381,7 +391,7 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 		for _, col := range []string{ColAct, ColApl, ColAAp, ColAtr,
 			ColRpl, ColDst, ColRds, ColLht, ColIndx, ColRsP, ColRes, ColSqs, ColSqp,
 			ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColRpp, ColApp,
-			ColRpf, ColShg, ColAcc, ColAnp} {
+			ColRpf, ColShg, ColAcc, ColAnp, ColCcv, ColRvc, ColAcs, ColSqh, ColSts} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -646,6 +656,16 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField2(sctx, ColAnp, utils.AccountProfilePrefix, subject, tntID)
 		case utils.DispatcherHostPrefix:
 			result, err = ms.getField2(sctx, ColDph, utils.DispatcherHostPrefix, subject, tntID)
+		case utils.CurrencyConversionsPrefix:
+			result, err = ms.getField(sctx, ColCcv, utils.CurrencyConversionsPrefix, subject, "tenant")
+		case utils.RateVolumeCounterPrefix:
+			result, err = ms.getField2(sctx, ColRvc, utils.RateVolumeCounterPrefix, subject, tntID)
+		case utils.ActionSchedulePrefix:
+			result, err = ms.getField2(sctx, ColAcs, utils.ActionSchedulePrefix, subject, tntID)
+		case utils.StatQueueHistoryPrefix:
+			result, err = ms.getField2(sctx, ColSqh, utils.StatQueueHistoryPrefix, subject, tntID)
+		case utils.StoredSessionPrefix:
+			result, err = ms.getField2(sctx, ColSts, utils.StoredSessionPrefix, subject, tntID)
 		case utils.AttributeFilterIndexes:
 			result, err = ms.getField3(sctx, ColIndx, utils.AttributeFilterIndexes, "key")
 		case utils.ResourceFilterIndexes:
@@ -2241,3 +2261,183 @@ func (ms *MongoStorage) RemoveAccountProfileDrv(tenant, id string) (err error) {
 		return err
 	})
 }
+
+func (ms *MongoStorage) GetCurrencyConversionsDrv(tenant string) (cC *CurrencyConversions, err error) {
+	cC = new(CurrencyConversions)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColCcv).FindOne(sctx, bson.M{"tenant": tenant})
+		if err := cur.Decode(cC); err != nil {
+			cC = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetCurrencyConversionsDrv(cC *CurrencyConversions) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColCcv).UpdateOne(sctx, bson.M{"tenant": cC.Tenant},
+			bson.M{"$set": cC},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveCurrencyConversionsDrv(tenant string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColCcv).DeleteOne(sctx, bson.M{"tenant": tenant})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetRateVolumeCounterDrv(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	rvc = new(RateVolumeCounter)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColRvc).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(rvc); err != nil {
+			rvc = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetRateVolumeCounterDrv(rvc *RateVolumeCounter) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColRvc).UpdateOne(sctx, bson.M{"tenant": rvc.Tenant, "id": rvc.ID},
+			bson.M{"$set": rvc},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveRateVolumeCounterDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColRvc).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetActionScheduleDrv(tenant, id string) (aSched *ActionSchedule, err error) {
+	aSched = new(ActionSchedule)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColAcs).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(aSched); err != nil {
+			aSched = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetActionScheduleDrv(aSched *ActionSchedule) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColAcs).UpdateOne(sctx, bson.M{"tenant": aSched.Tenant, "id": aSched.ID},
+			bson.M{"$set": aSched},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveActionScheduleDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColAcs).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetStatQueueHistoryDrv(tenant, id string) (sqh *StatQueueHistory, err error) {
+	sqh = new(StatQueueHistory)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColSqh).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(sqh); err != nil {
+			sqh = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetStatQueueHistoryDrv(sqh *StatQueueHistory) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSqh).UpdateOne(sctx, bson.M{"tenant": sqh.Tenant, "id": sqh.ID},
+			bson.M{"$set": sqh},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveStatQueueHistoryDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColSqh).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetStoredSessionDrv(tenant, id string) (ss *StoredSession, err error) {
+	ss = new(StoredSession)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColSts).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(ss); err != nil {
+			ss = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetStoredSessionDrv(ss *StoredSession) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSts).UpdateOne(sctx, bson.M{"tenant": ss.Tenant, "id": ss.ID},
+			bson.M{"$set": ss},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveStoredSessionDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColSts).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
@@ -1383,3 +1383,123 @@ func (rs *RedisStorage) SetAccountProfileDrv(ap *utils.AccountProfile) (err erro
 func (rs *RedisStorage) RemoveAccountProfileDrv(tenant, id string) (err error) {
 	return rs.Cmd(nil, redis_DEL, utils.AccountProfilePrefix+utils.ConcatenatedKey(tenant, id))
 }
+
+func (rs *RedisStorage) GetCurrencyConversionsDrv(tenant string) (cC *CurrencyConversions, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.CurrencyConversionsPrefix+tenant); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &cC)
+	return
+}
+
+func (rs *RedisStorage) SetCurrencyConversionsDrv(cC *CurrencyConversions) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(cC); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.CurrencyConversionsPrefix+cC.Tenant, string(result))
+}
+
+func (rs *RedisStorage) RemoveCurrencyConversionsDrv(tenant string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.CurrencyConversionsPrefix+tenant)
+}
+
+func (rs *RedisStorage) GetRateVolumeCounterDrv(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &rvc)
+	return
+}
+
+func (rs *RedisStorage) SetRateVolumeCounterDrv(rvc *RateVolumeCounter) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(rvc); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.RateVolumeCounterPrefix+rvc.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveRateVolumeCounterDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetActionScheduleDrv(tenant, id string) (aSched *ActionSchedule, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.ActionSchedulePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &aSched)
+	return
+}
+
+func (rs *RedisStorage) SetActionScheduleDrv(aSched *ActionSchedule) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(aSched); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.ActionSchedulePrefix+aSched.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveActionScheduleDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.ActionSchedulePrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetStatQueueHistoryDrv(tenant, id string) (sqh *StatQueueHistory, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.StatQueueHistoryPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &sqh)
+	return
+}
+
+func (rs *RedisStorage) SetStatQueueHistoryDrv(sqh *StatQueueHistory) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(sqh); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.StatQueueHistoryPrefix+sqh.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveStatQueueHistoryDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.StatQueueHistoryPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetStoredSessionDrv(tenant, id string) (ss *StoredSession, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.StoredSessionPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &ss)
+	return
+}
+
+func (rs *RedisStorage) SetStoredSessionDrv(ss *StoredSession) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(ss); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.StoredSessionPrefix+ss.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveStoredSessionDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.StoredSessionPrefix+utils.ConcatenatedKey(tenant, id))
+}
@@ -0,0 +1,55 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// StoredSession is the checkpoint of an active session
+// used by SessionS to recover the session after restart
+type StoredSession struct {
+	Tenant        string
+	ID            string // CGRID of the session
+	NodeID        string // node owning the session
+	ResourceID    string
+	ClientConnID  string
+	EventStart    MapEvent
+	DebitInterval time.Duration
+	SRuns         []*StoredSRun
+	OptsStart     MapEvent
+}
+
+// StoredSRun is the checkpoint of one session run
+type StoredSRun struct {
+	Event         MapEvent
+	CD            *CallDescriptor
+	EventCost     *EventCost
+	ExtraDuration time.Duration
+	LastUsage     time.Duration
+	TotalUsage    time.Duration
+	NextAutoDebit *time.Time
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (ss *StoredSession) TenantID() string {
+	return utils.ConcatenatedKey(ss.Tenant, ss.ID)
+}
@@ -55,6 +55,8 @@ type ThresholdProfile struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	RecoveryFilterIDs  []string // filters clearing an alarmed threshold, enables the *ok/*alarm states
+	RecoveryActionIDs  []string // actions executed when the threshold recovers
 }
 
 // TenantID returns the concatenated key beteen tenant and ID
@@ -74,6 +76,7 @@ type Threshold struct {
 	ID     string
 	Hits   int       // number of hits for this threshold
 	Snooze time.Time // prevent threshold to run too early
+	State  string    // *ok or *alarm, populated only for profiles with RecoveryFilterIDs
 
 	tPrfl *ThresholdProfile
 	dirty *bool // needs save
@@ -93,6 +96,22 @@ func (t *Threshold) ProcessEvent(args *ThresholdsArgsProcessEvent, dm *DataManag
 			t.Hits > t.tPrfl.MaxHits) {
 		return
 	}
+	if len(t.tPrfl.RecoveryFilterIDs) != 0 {
+		t.State = utils.MetaAlarm
+	}
+	return t.executeActions(args, t.tPrfl.ActionIDs)
+}
+
+// recover clears the alarm of the threshold and executes the RecoveryActionIDs
+func (t *Threshold) recover(args *ThresholdsArgsProcessEvent) (err error) {
+	t.State = utils.MetaOK
+	t.Hits = 0
+	t.Snooze = time.Time{}
+	return t.executeActions(args, t.tPrfl.RecoveryActionIDs)
+}
+
+// executeActions executes the given action sets on behalf of the threshold
+func (t *Threshold) executeActions(args *ThresholdsArgsProcessEvent, actionIDs []string) (err error) {
 	var tntAcnt string
 	var acnt string
 	if utils.IfaceAsString(args.Opts[utils.MetaEventType]) == utils.AccountUpdate {
@@ -104,7 +123,7 @@ func (t *Threshold) ProcessEvent(args *ThresholdsArgsProcessEvent, dm *DataManag
 		tntAcnt = utils.ConcatenatedKey(args.Tenant, acnt)
 	}
 
-	for _, actionSetID := range t.tPrfl.ActionIDs {
+	for _, actionSetID := range actionIDs {
 		at := &ActionTiming{
 			Uuid:      utils.GenUUID(),
 			ActionsID: actionSetID,
@@ -136,10 +155,12 @@ func (ts Thresholds) Sort() {
 }
 
 // NewThresholdService the constructor for ThresoldS service
-func NewThresholdService(dm *DataManager, cgrcfg *config.CGRConfig, filterS *FilterS) (tS *ThresholdService) {
+func NewThresholdService(dm *DataManager, cgrcfg *config.CGRConfig, filterS *FilterS,
+	connMgr *ConnManager) (tS *ThresholdService) {
 	return &ThresholdService{dm: dm,
 		cgrcfg:      cgrcfg,
 		filterS:     filterS,
+		connMgr:     connMgr,
 		stopBackup:  make(chan struct{}),
 		loopStoped:  make(chan struct{}),
 		storedTdIDs: make(utils.StringSet),
@@ -151,6 +172,7 @@ type ThresholdService struct {
 	dm          *DataManager
 	cgrcfg      *config.CGRConfig
 	filterS     *FilterS
+	connMgr     *ConnManager
 	stopBackup  chan struct{}
 	loopStoped  chan struct{}
 	storedTdIDs utils.StringSet // keep a record of stats which need saving, map[statsTenantID]bool
@@ -229,6 +251,18 @@ func (tS *ThresholdService) StoreThreshold(t *Threshold) (err error) {
 
 // matchingThresholdsForEvent returns ordered list of matching thresholds which are active for an Event
 func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *ThresholdsArgsProcessEvent) (ts Thresholds, err error) {
+	if ts, _, err = tS.thresholdsForEvent(tnt, args); err != nil {
+		return
+	}
+	if len(ts) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// thresholdsForEvent returns the ordered list of thresholds matching an Event
+// together with the alarmed thresholds whose RecoveryFilterIDs are passing
+func (tS *ThresholdService) thresholdsForEvent(tnt string, args *ThresholdsArgsProcessEvent) (ts, recTs Thresholds, err error) {
 	evNm := utils.MapStorage{
 		utils.MetaReq:  args.Event,
 		utils.MetaOpts: args.Opts,
@@ -244,7 +278,7 @@ func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *Thresho
 			tS.cgrcfg.ThresholdSCfg().NestedFields,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 	ts = make(Thresholds, 0, len(tIDs))
@@ -254,34 +288,45 @@ func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *Thresho
 			if err == utils.ErrNotFound {
 				continue
 			}
-			return nil, err
+			return nil, nil, err
 		}
 		if tPrfl.ActivationInterval != nil && args.Time != nil &&
 			!tPrfl.ActivationInterval.IsActiveAtTime(*args.Time) { // not active
 			continue
 		}
-		if pass, err := tS.filterS.Pass(tnt, tPrfl.FilterIDs,
-			evNm); err != nil {
-			return nil, err
-		} else if !pass {
-			continue
+		pass, err := tS.filterS.Pass(tnt, tPrfl.FilterIDs, evNm)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !pass {
+			if len(tPrfl.RecoveryFilterIDs) == 0 {
+				continue
+			}
+			if recPass, err := tS.filterS.Pass(tnt, tPrfl.RecoveryFilterIDs, evNm); err != nil {
+				return nil, nil, err
+			} else if !recPass {
+				continue
+			}
 		}
 		t, err := tS.dm.GetThreshold(tPrfl.Tenant, tPrfl.ID, true, true, "")
 		if err != nil {
 			if err == utils.ErrNotFound { // corner case where the threshold was removed due to MaxHits
 				continue
 			}
-			return nil, err
+			return nil, nil, err
 		}
 		if t.dirty == nil || tPrfl.MaxHits == -1 || t.Hits < tPrfl.MaxHits {
 			t.dirty = utils.BoolPointer(false)
 		}
 		t.tPrfl = tPrfl
-		ts = append(ts, t)
-	}
-	// All good, convert from Map to Slice so we can sort
-	if len(ts) == 0 {
-		return nil, utils.ErrNotFound
+		if len(tPrfl.RecoveryFilterIDs) != 0 && t.State == utils.EmptyString {
+			t.State = utils.MetaOK
+		}
+		if pass {
+			ts = append(ts, t)
+		} else if t.State == utils.MetaAlarm { // only the alarmed thresholds can recover
+			recTs = append(recTs, t)
+		}
 	}
 	ts.Sort()
 	for i, t := range ts {
@@ -330,16 +375,51 @@ func (attr *ThresholdsArgsProcessEvent) Clone() *ThresholdsArgsProcessEvent {
 
 // processEvent processes a new event, dispatching to matching thresholds
 func (tS *ThresholdService) processEvent(tnt string, args *ThresholdsArgsProcessEvent) (thresholdsIDs []string, err error) {
-	matchTs, err := tS.matchingThresholdsForEvent(tnt, args)
+	matchTs, recTs, err := tS.thresholdsForEvent(tnt, args)
 	if err != nil {
 		return nil, err
 	}
 	var withErrors bool
 	var tIDs []string
+	for _, t := range recTs {
+		tIDs = append(tIDs, t.ID)
+		prevState := t.State
+		// one time threshold kept in alarm until recovery
+		exhausted := t.tPrfl.MaxHits != -1 && t.Hits >= t.tPrfl.MaxHits
+		if err = t.recover(args); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<ThresholdService> threshold: %s, failed recovering on event: %s, error: %s",
+					t.TenantID(), utils.ConcatenatedKey(tnt, args.CGREvent.ID), err.Error()))
+			withErrors = true
+		}
+		if err = tS.exportStateChange(t, prevState); err != nil {
+			withErrors = true
+		}
+		if exhausted {
+			if err = tS.removeThreshold(t); err != nil {
+				withErrors = true
+			}
+			continue
+		}
+		t.dirty = utils.BoolPointer(true) // mark it to be saved
+		if tS.cgrcfg.ThresholdSCfg().StoreInterval == -1 {
+			tS.StoreThreshold(t)
+		} else {
+			tS.stMux.Lock()
+			tS.storedTdIDs.Add(t.TenantID())
+			tS.stMux.Unlock()
+		}
+	}
 	for _, t := range matchTs {
 		tIDs = append(tIDs, t.ID)
 		t.Hits++
+		prevState := t.State
 		err = t.ProcessEvent(args, tS.dm)
+		if t.State != prevState {
+			if errExp := tS.exportStateChange(t, prevState); errExp != nil {
+				withErrors = true
+			}
+		}
 		if err != nil {
 			utils.Logger.Warning(
 				fmt.Sprintf("<ThresholdService> threshold: %s, ignoring event: %s, error: %s",
@@ -347,18 +427,9 @@ func (tS *ThresholdService) processEvent(tnt string, args *ThresholdsArgsProcess
 			withErrors = true
 			continue
 		}
-		if t.dirty == nil || t.Hits == t.tPrfl.MaxHits { // one time threshold
-			if err = tS.dm.RemoveThreshold(t.Tenant, t.ID, utils.NonTransactional); err != nil {
-				utils.Logger.Warning(
-					fmt.Sprintf("<ThresholdService> failed removing from database non-recurrent threshold: %s, error: %s",
-						t.TenantID(), err.Error()))
-				withErrors = true
-			}
-			//since we don't handle in DataManager caching we do a manual remove here
-			if err = tS.dm.CacheDataFromDB(utils.ThresholdPrefix, []string{t.TenantID()}, true); err != nil {
-				utils.Logger.Warning(
-					fmt.Sprintf("<ThresholdService> failed removing from cache non-recurrent threshold: %s, error: %s",
-						t.TenantID(), err.Error()))
+		if (t.dirty == nil || t.Hits == t.tPrfl.MaxHits) && // one time threshold
+			len(t.tPrfl.RecoveryFilterIDs) == 0 { // the recoverable ones are removed after recovery
+			if err = tS.removeThreshold(t); err != nil {
 				withErrors = true
 			}
 			continue
@@ -384,6 +455,58 @@ func (tS *ThresholdService) processEvent(tnt string, args *ThresholdsArgsProcess
 	return
 }
 
+// removeThreshold removes the non-recurrent threshold out of database and cache
+func (tS *ThresholdService) removeThreshold(t *Threshold) (err error) {
+	if err = tS.dm.RemoveThreshold(t.Tenant, t.ID, utils.NonTransactional); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<ThresholdService> failed removing from database non-recurrent threshold: %s, error: %s",
+				t.TenantID(), err.Error()))
+	}
+	//since we don't handle in DataManager caching we do a manual remove here
+	if errCh := tS.dm.CacheDataFromDB(utils.ThresholdPrefix, []string{t.TenantID()}, true); errCh != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<ThresholdService> failed removing from cache non-recurrent threshold: %s, error: %s",
+				t.TenantID(), errCh.Error()))
+		err = errCh
+	}
+	return
+}
+
+// exportStateChange sends the threshold state transition to EEs
+func (tS *ThresholdService) exportStateChange(t *Threshold, prevState string) (err error) {
+	if len(tS.cgrcfg.ThresholdSCfg().EEsConns) == 0 {
+		return
+	}
+	cgrEv := &utils.CGREventWithEeIDs{
+		CGREvent: &utils.CGREvent{
+			Tenant: t.Tenant,
+			ID:     utils.GenUUID(),
+			Time:   utils.TimePointer(time.Now()),
+			Event: map[string]interface{}{
+				utils.EventType:     utils.ThresholdUpdate,
+				utils.ThresholdID:   t.ID,
+				utils.State:         t.State,
+				utils.PreviousState: prevState,
+				utils.Hits:          t.Hits,
+			},
+			Opts: map[string]interface{}{
+				utils.MetaEventType: utils.ThresholdUpdate,
+			},
+		},
+	}
+	var reply map[string]map[string]interface{}
+	if err = tS.connMgr.Call(tS.cgrcfg.ThresholdSCfg().EEsConns, nil,
+		utils.EeSv1ProcessEvent, cgrEv, &reply); err != nil {
+		if err.Error() == utils.ErrNotFound.Error() {
+			return nil // NotFound is not considered error
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<ThresholdService> error: %s exporting state change of threshold: %s",
+				err.Error(), t.TenantID()))
+	}
+	return
+}
+
 // V1ProcessEvent implements ThresholdService method for processing an Event
 func (tS *ThresholdService) V1ProcessEvent(args *ThresholdsArgsProcessEvent, reply *[]string) (err error) {
 	if args.CGREvent == nil {
@@ -482,9 +605,15 @@ func (tS *ThresholdService) V1ResetThreshold(tntID *utils.TenantID, rply *string
 	if thd, err = tS.dm.GetThreshold(tnt, tntID.ID, true, true, ""); err != nil {
 		return
 	}
-	if thd.Hits != 0 {
+	if thd.Hits != 0 || thd.State == utils.MetaAlarm {
 		thd.Hits = 0
 		thd.Snooze = time.Time{}
+		if thd.State == utils.MetaAlarm {
+			thd.State = utils.MetaOK
+			if err = tS.exportStateChange(thd, utils.MetaAlarm); err != nil {
+				return
+			}
+		}
 		thd.dirty = utils.BoolPointer(true) // mark it to be saved
 		if tS.cgrcfg.ThresholdSCfg().StoreInterval == -1 {
 			if err = tS.StoreThreshold(thd); err != nil {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 func TestThresholdsSort(t *testing.T) {
@@ -293,7 +294,7 @@ func TestThresholdsmatchingThresholdsForEvent(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -503,7 +504,7 @@ func TestThresholdsProcessEvent(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -704,7 +705,7 @@ func TestThresholdsVerifyIfExecuted(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -925,7 +926,7 @@ func TestThresholdsProcessEvent2(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -1068,3 +1069,182 @@ func TestThresholdsProcessEvent2(t *testing.T) {
 		}
 	}
 }
+
+type thdEEsMock struct {
+	events []*utils.CGREventWithEeIDs
+}
+
+func (m *thdEEsMock) Call(serviceMethod string, args, reply interface{}) error {
+	if serviceMethod != utils.EeSv1ProcessEvent {
+		return utils.ErrNotImplemented
+	}
+	m.events = append(m.events, args.(*utils.CGREventWithEeIDs))
+	return nil
+}
+
+func TestThresholdsRecovery(t *testing.T) {
+	connID := utils.ConcatenatedKey(utils.MetaInternal, "thdRecoveryEEs")
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ThresholdSCfg().StoreInterval = 0
+	cfg.ThresholdSCfg().EEsConns = []string{connID}
+	mock := new(thdEEsMock)
+	eesChan := make(chan rpcclient.ClientConnector, 1)
+	eesChan <- mock
+	connMgr := &ConnManager{cfg: cfg,
+		rpcInternal: map[string]chan rpcclient.ClientConnector{connID: eesChan}}
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	tS := NewThresholdService(dm, cfg, &FilterS{dm: dm, cfg: cfg}, connMgr)
+	tPrfl := &ThresholdProfile{
+		Tenant:            "cgrates.org",
+		ID:                "TH_LOW_ASR",
+		FilterIDs:         []string{"*lt:~*req.ASR:50"},
+		MaxHits:           -1,
+		MinHits:           2,
+		RecoveryFilterIDs: []string{"*gte:~*req.ASR:60"},
+	}
+	if err := dm.SetThresholdProfile(tPrfl, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetThreshold(&Threshold{Tenant: tPrfl.Tenant, ID: tPrfl.ID}, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dm.RemoveThresholdProfile(tPrfl.Tenant, tPrfl.ID, utils.NonTransactional, true)
+		dm.RemoveThreshold(tPrfl.Tenant, tPrfl.ID, utils.NonTransactional)
+		Cache.Remove(utils.CacheRPCConnections, connID, true, utils.NonTransactional)
+	}()
+	process := func(asr float64) (ids []string, err error) {
+		return tS.processEvent(tPrfl.Tenant, &ThresholdsArgsProcessEvent{
+			ThresholdIDs: []string{tPrfl.ID},
+			CGREvent: &utils.CGREvent{
+				Tenant: tPrfl.Tenant,
+				ID:     utils.GenUUID(),
+				Event:  map[string]interface{}{"ASR": asr},
+			},
+		})
+	}
+	checkState := func(state string, hits int) {
+		t.Helper()
+		var thd Threshold
+		if err := tS.V1GetThreshold(&utils.TenantID{Tenant: tPrfl.Tenant, ID: tPrfl.ID}, &thd); err != nil {
+			t.Fatal(err)
+		} else if thd.State != state || thd.Hits != hits {
+			t.Errorf("expecting state: %s with hits: %d, received: %s with %d", state, hits, thd.State, thd.Hits)
+		}
+	}
+
+	// ASR between the two filters, neither alarm nor recovery
+	if _, err := process(55); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// healthy threshold does not recover
+	if _, err := process(70); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := process(40); err != nil {
+		t.Fatal(err)
+	}
+	checkState(utils.MetaOK, 1)
+	if len(mock.events) != 0 {
+		t.Errorf("unexpected events: %s", utils.ToJSON(mock.events))
+	}
+	if _, err := process(30); err != nil {
+		t.Fatal(err)
+	}
+	checkState(utils.MetaAlarm, 2)
+	if _, err := process(30); err != nil {
+		t.Fatal(err)
+	}
+	checkState(utils.MetaAlarm, 3)
+	if len(mock.events) != 1 {
+		t.Fatalf("expecting one event, received: %s", utils.ToJSON(mock.events))
+	}
+	exp := map[string]interface{}{
+		utils.EventType:     utils.ThresholdUpdate,
+		utils.ThresholdID:   tPrfl.ID,
+		utils.State:         utils.MetaAlarm,
+		utils.PreviousState: utils.MetaOK,
+		utils.Hits:          2,
+	}
+	if !reflect.DeepEqual(exp, mock.events[0].Event) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(mock.events[0].Event))
+	}
+	if ids, err := process(65); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual([]string{tPrfl.ID}, ids) {
+		t.Errorf("expecting: %v, received: %v", []string{tPrfl.ID}, ids)
+	}
+	checkState(utils.MetaOK, 0)
+	if len(mock.events) != 2 {
+		t.Fatalf("expecting two events, received: %s", utils.ToJSON(mock.events))
+	}
+	exp = map[string]interface{}{
+		utils.EventType:     utils.ThresholdUpdate,
+		utils.ThresholdID:   tPrfl.ID,
+		utils.State:         utils.MetaOK,
+		utils.PreviousState: utils.MetaAlarm,
+		utils.Hits:          0,
+	}
+	if !reflect.DeepEqual(exp, mock.events[1].Event) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(mock.events[1].Event))
+	}
+	// already recovered
+	if _, err := process(65); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestThresholdsRecoveryMaxHits(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ThresholdSCfg().StoreInterval = 0
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	tS := NewThresholdService(dm, cfg, &FilterS{dm: dm, cfg: cfg}, nil)
+	tPrfl := &ThresholdProfile{
+		Tenant:            "cgrates.org",
+		ID:                "TH_LOW_ASR_ONCE",
+		FilterIDs:         []string{"*lt:~*req.ASR:50"},
+		MaxHits:           1,
+		MinHits:           1,
+		RecoveryFilterIDs: []string{"*gte:~*req.ASR:60"},
+	}
+	if err := dm.SetThresholdProfile(tPrfl, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetThreshold(&Threshold{Tenant: tPrfl.Tenant, ID: tPrfl.ID}, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dm.RemoveThresholdProfile(tPrfl.Tenant, tPrfl.ID, utils.NonTransactional, true)
+		dm.RemoveThreshold(tPrfl.Tenant, tPrfl.ID, utils.NonTransactional)
+	}()
+	process := func(asr float64) (err error) {
+		_, err = tS.processEvent(tPrfl.Tenant, &ThresholdsArgsProcessEvent{
+			ThresholdIDs: []string{tPrfl.ID},
+			CGREvent: &utils.CGREvent{
+				Tenant: tPrfl.Tenant,
+				ID:     utils.GenUUID(),
+				Event:  map[string]interface{}{"ASR": asr},
+			},
+		})
+		return
+	}
+	var thd Threshold
+	// MaxHits reached, the threshold is kept until it recovers
+	for i := 1; i <= 2; i++ {
+		if err := process(30); err != nil {
+			t.Fatal(err)
+		}
+		if err := tS.V1GetThreshold(&utils.TenantID{Tenant: tPrfl.Tenant, ID: tPrfl.ID}, &thd); err != nil {
+			t.Fatal(err)
+		} else if thd.State != utils.MetaAlarm || thd.Hits != i {
+			t.Errorf("expecting state: %s with hits: %d, received: %s with %d", utils.MetaAlarm, i, thd.State, thd.Hits)
+		}
+	}
+	if err := process(65); err != nil {
+		t.Fatal(err)
+	}
+	// recovered, the one time threshold is removed
+	if err := tS.V1GetThreshold(&utils.TenantID{Tenant: tPrfl.Tenant, ID: tPrfl.ID}, &thd); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -2820,3 +2820,80 @@ func TestResourceCaching(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", resources[0].ttl, mres[0].ttl)
 	}
 }
+
+func TestResourceRateLimit(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ResourceSCfg().StoreInterval = 0
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	rS := NewResourceService(dm, cfg, &FilterS{dm: dm, cfg: cfg}, nil)
+	rPrf := &ResourceProfile{
+		Tenant:     "ratelimit.org",
+		ID:         "RES_CPS",
+		Limit:      2,
+		UsageTTL:   -1,
+		RateWindow: 50 * time.Millisecond,
+	}
+	if err := dm.SetResourceProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetResource(&Resource{Tenant: rPrf.Tenant, ID: rPrf.ID,
+		Usages: make(map[string]*ResourceUsage)}, nil, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dm.RemoveResourceProfile(rPrf.Tenant, rPrf.ID, utils.NonTransactional, true)
+		dm.RemoveResource(rPrf.Tenant, rPrf.ID, utils.NonTransactional)
+	}()
+	args := func(usageID string) utils.ArgRSv1ResourceUsage {
+		return utils.ArgRSv1ResourceUsage{
+			CGREvent: &utils.CGREvent{
+				Tenant: rPrf.Tenant,
+				ID:     utils.GenUUID(),
+				Event:  map[string]interface{}{utils.AccountField: "1001"},
+			},
+			UsageID: usageID,
+			Units:   1,
+		}
+	}
+	var reply string
+	for _, usageID := range []string{"RL_CALL1", "RL_CALL2"} {
+		if err := rS.V1AllocateResource(args(usageID), &reply); err != nil {
+			t.Fatal(err)
+		} else if reply != rPrf.ID {
+			t.Errorf("expecting: %s, received: %s", rPrf.ID, reply)
+		}
+	}
+	if err := rS.V1AuthorizeResources(args("RL_CALL3"), &reply); err != utils.ErrResourceUnauthorized {
+		t.Errorf("expecting: %v, received: %v", utils.ErrResourceUnauthorized, err)
+	}
+	// releasing does not give back the units within the window
+	if err := rS.V1ReleaseResource(args("RL_CALL1"), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := rS.V1AllocateResource(args("RL_CALL4"), &reply); err != utils.ErrResourceUnavailable {
+		t.Errorf("expecting: %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	var res Resource
+	if err := rS.V1GetResource(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{Tenant: rPrf.Tenant, ID: rPrf.ID}}, &res); err != nil {
+		t.Fatal(err)
+	} else if res.totalUsage() != 2 {
+		t.Errorf("expecting usage: 2, received: %v", res.totalUsage())
+	}
+	time.Sleep(60 * time.Millisecond)
+	if err := rS.V1GetResource(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{Tenant: rPrf.Tenant, ID: rPrf.ID}}, &res); err != nil {
+		t.Fatal(err)
+	} else if len(res.Usages) != 0 {
+		t.Errorf("expecting no usages, received: %s", utils.ToJSON(res.Usages))
+	}
+	// the cached resource is cleaned only when allocating
+	if cached, err := dm.GetResource(rPrf.Tenant, rPrf.ID, true, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if len(cached.Usages) == 0 {
+		t.Error("expecting the usages kept on the cached resource")
+	}
+	if err := rS.V1AllocateResource(args("RL_CALL5"), &reply); err != nil {
+		t.Error(err)
+	}
+}
@@ -32,6 +32,7 @@ import (
 type erEvent struct {
 	cgrEvent *utils.CGREvent
 	rdrCfg   *config.EventReaderCfg
+	ack      func(err error) // if set, called with the processing result so the reader can acknowledge the source
 }
 
 // NewERService instantiates the ERService
@@ -87,10 +88,14 @@ func (erS *ERService) ListenAndServe(stopChan, cfgRldChan chan struct{}) (err er
 			erS.closeAllRdrs()
 			return
 		case erEv := <-erS.rdrEvents:
-			if err := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg); err != nil {
+			errEv := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg)
+			if errEv != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> reading event: <%s> got error: <%s>",
-						utils.ERs, utils.ToIJSON(erEv.cgrEvent), err.Error()))
+						utils.ERs, utils.ToIJSON(erEv.cgrEvent), errEv.Error()))
+			}
+			if erEv.ack != nil {
+				erEv.ack(errEv)
 			}
 		case <-cfgRldChan: // handle reload
 			cfgIDs := make(map[string]int)
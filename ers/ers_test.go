@@ -21,6 +21,7 @@ package ers
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -67,3 +68,40 @@ func TestERsAddReader(t *testing.T) {
 		t.Errorf("Expecting: <%+v>, received: <%+v>", reader, erS.rdrs["file_reader"].Config())
 	}
 }
+
+func TestERsListenAndServeAck(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	erS := NewERService(cfg, &engine.FilterS{}, nil)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	go erS.ListenAndServe(stopChan, make(chan struct{}))
+	acks := make(chan error, 1)
+	ack := func(err error) { acks <- err }
+	erS.rdrEvents <- &erEvent{
+		cgrEvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "EV_NONE"},
+		rdrCfg:   &config.EventReaderCfg{ID: "nats", Flags: utils.FlagsWithParams{utils.MetaNone: {}}},
+		ack:      ack,
+	}
+	select {
+	case err := <-acks:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event not acknowledged")
+	}
+	erS.rdrEvents <- &erEvent{
+		cgrEvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "EV_UNSUPPORTED"},
+		rdrCfg:   &config.EventReaderCfg{ID: "nats", Flags: utils.FlagsWithParams{}},
+		ack:      ack,
+	}
+	expErr := "unsupported reqType: <>"
+	select {
+	case err := <-acks:
+		if err == nil || err.Error() != expErr {
+			t.Errorf("Expected error: %s, received: %v", expErr, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event not acknowledged")
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats.go"
+)
+
+// NewNatsER return a new NATS event reader
+func NewNatsER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &NatsER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	if err = rdr.setOpts(rdr.Config().Opts); err != nil {
+		return
+	}
+	if err = rdr.createPoster(); err != nil {
+		return
+	}
+	return rdr, nil
+}
+
+// NatsER implements EventReader interface for NATS messages
+type NatsER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	subject      string
+	queueID      string // queue group, the messages are load balanced between its members
+	jetStream    bool
+	consumerName string // durable consumer of the JetStream
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+
+	poster engine.Poster
+}
+
+// Config returns the curent configuration
+func (rdr *NatsER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will subscribe to the NATS subject
+func (rdr *NatsER) Serve() (err error) {
+	var nc *nats.Conn
+	if nc, err = nats.Connect(rdr.Config().SourcePath, nats.Name(utils.CGRateS),
+		nats.ClosedHandler(func(nc *nats.Conn) {
+			if rdr.poster != nil {
+				rdr.poster.Close()
+			}
+		})); err != nil {
+		return
+	}
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		nc.Close()
+		return
+	}
+	if err = rdr.subscribe(nc); err != nil {
+		nc.Close()
+		return
+	}
+	go func() {
+		<-rdr.rdrExit
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> stop monitoring nats subject <%s>",
+				utils.ERs, rdr.subject))
+		nc.Drain() // keeps the durable consumers, unlike the Unsubscribe
+	}()
+	return
+}
+
+func (rdr *NatsER) subscribe(nc *nats.Conn) (err error) {
+	if !rdr.jetStream {
+		_, err = nc.QueueSubscribe(rdr.subject, rdr.queueID, func(msg *nats.Msg) {
+			rdr.handleMessage(msg, false)
+		})
+		return
+	}
+	var js nats.JetStreamContext
+	if js, err = nc.JetStream(); err != nil {
+		return
+	}
+	_, err = js.QueueSubscribe(rdr.subject, rdr.queueID, func(msg *nats.Msg) {
+		rdr.handleMessage(msg, true)
+	}, nats.Durable(rdr.consumerName), nats.ManualAck())
+	return
+}
+
+// handleMessage processes the message out of the subscription callback,
+// the JetStream ones being acknowledged only after ERs processed their event
+func (rdr *NatsER) handleMessage(msg *nats.Msg, ack bool) {
+	if rdr.Config().ConcurrentReqs != -1 {
+		<-rdr.cap // do not try to read if the limit is reached
+	}
+	go func() {
+		cgrEv, err := rdr.processMessage(msg.Data)
+		switch {
+		case err != nil:
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> processing message error: %s",
+					utils.ERs, err.Error()))
+			if ack { // the message would fail again so do not have it redelivered
+				natsAck(msg.Term)
+			}
+		case cgrEv == nil: // not passing the filters
+			if ack {
+				natsAck(msg.Ack)
+			}
+		default:
+			erEv := &erEvent{
+				cgrEvent: cgrEv,
+				rdrCfg:   rdr.Config(),
+			}
+			if ack {
+				erEv.ack = func(err error) {
+					if err != nil { // have it redelivered
+						natsAck(msg.Nak)
+						return
+					}
+					natsAck(msg.Ack)
+				}
+			}
+			rdr.rdrEvents <- erEv
+		}
+		if rdr.poster != nil { // post it
+			if err := rdr.poster.Post(msg.Data, utils.EmptyString); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> writing message error: %s",
+						utils.ERs, err.Error()))
+			}
+		}
+		if rdr.Config().ConcurrentReqs != -1 {
+			rdr.cap <- struct{}{}
+		}
+	}()
+}
+
+// processMessage builds the event out of the message, returning nil if the filters are not passing
+func (rdr *NatsER) processMessage(msg []byte) (cgrEv *utils.CGREvent, err error) {
+	var decodedMessage map[string]interface{}
+	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
+		return
+	}
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(decodedMessage), nil,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	return config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts), nil
+}
+
+// natsAck sends the acknowledgement of a JetStream message
+func natsAck(ackFunc func(...nats.AckOpt) error) {
+	if err := ackFunc(); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> acknowledging message error: %s",
+				utils.ERs, err.Error()))
+	}
+}
+
+func (rdr *NatsER) setOpts(opts map[string]interface{}) (err error) {
+	rdr.subject = utils.DefaultQueueID
+	rdr.consumerName = utils.NatsDefaultConsumerName
+	if vals, has := opts[utils.NatsSubject]; has {
+		rdr.subject = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NatsQueueID]; has {
+		rdr.queueID = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NatsConsumerName]; has {
+		rdr.consumerName = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NatsJetStream]; has {
+		rdr.jetStream, err = utils.IfaceAsBool(vals)
+	}
+	return
+}
+
+func (rdr *NatsER) createPoster() (err error) {
+	processedOpt := getProcessOptions(rdr.Config().Opts)
+	if len(processedOpt) == 0 &&
+		len(rdr.Config().ProcessedPath) == 0 {
+		return
+	}
+	rdr.poster, err = engine.NewNatsPoster(utils.FirstNonEmpty(rdr.Config().ProcessedPath, rdr.Config().SourcePath),
+		rdr.cgrCfg.GeneralCfg().PosterAttempts, processedOpt)
+	return
+}
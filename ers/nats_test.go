@@ -0,0 +1,71 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"testing"
+)
+
+func TestNatssetOpts(t *testing.T) {
+	n := new(NatsER)
+	expNats := &NatsER{
+		subject:      "cdrs",
+		queueID:      "ers",
+		jetStream:    true,
+		consumerName: "ers_cdrs",
+	}
+	if err := n.setOpts(map[string]interface{}{
+		"natsSubject":      "cdrs",
+		"natsQueueID":      "ers",
+		"natsJetStream":    "true",
+		"natsConsumerName": "ers_cdrs",
+	}); err != nil {
+		t.Fatal(err)
+	} else if expNats.subject != n.subject {
+		t.Errorf("Expected: %s ,received: %s", expNats.subject, n.subject)
+	} else if expNats.queueID != n.queueID {
+		t.Errorf("Expected: %s ,received: %s", expNats.queueID, n.queueID)
+	} else if expNats.jetStream != n.jetStream {
+		t.Errorf("Expected: %v ,received: %v", expNats.jetStream, n.jetStream)
+	} else if expNats.consumerName != n.consumerName {
+		t.Errorf("Expected: %s ,received: %s", expNats.consumerName, n.consumerName)
+	}
+	n = new(NatsER)
+	expNats = &NatsER{
+		subject:      "cgrates_cdrs",
+		consumerName: "cgrates",
+	}
+	if err := n.setOpts(map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	} else if expNats.subject != n.subject {
+		t.Errorf("Expected: %s ,received: %s", expNats.subject, n.subject)
+	} else if expNats.queueID != n.queueID {
+		t.Errorf("Expected: %s ,received: %s", expNats.queueID, n.queueID)
+	} else if expNats.jetStream != n.jetStream {
+		t.Errorf("Expected: %v ,received: %v", expNats.jetStream, n.jetStream)
+	} else if expNats.consumerName != n.consumerName {
+		t.Errorf("Expected: %s ,received: %s", expNats.consumerName, n.consumerName)
+	}
+	n = new(NatsER)
+	if err := n.setOpts(map[string]interface{}{
+		"natsJetStream": "notabool",
+	}); err == nil {
+		t.Error("Expected error")
+	}
+}
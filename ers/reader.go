@@ -63,6 +63,8 @@ func NewEventReader(cfg *config.CGRConfig, cfgIdx int,
 		return NewSQSER(cfg, cfgIdx, rdrEvents, rdrErr, fltrS, rdrExit)
 	case utils.MetaAMQPV1jsonMap:
 		return NewAMQPv1ER(cfg, cfgIdx, rdrEvents, rdrErr, fltrS, rdrExit)
+	case utils.MetaNatsjsonMap:
+		return NewNatsER(cfg, cfgIdx, rdrEvents, rdrErr, fltrS, rdrExit)
 	}
 	return
 }
@@ -672,6 +672,40 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 			}
 		}
+	case utils.MetaCurrencyConversions:
+		cacheIDs = []string{utils.CacheCurrencyConversions}
+		for _, lDataSet := range lds {
+			var cC *engine.CurrencyConversions
+			for _, ld := range lDataSet {
+				if cC == nil { // all the lines in the set are for the same tenant
+					tnt := utils.IfaceAsString(ld[utils.Tenant])
+					if cC, err = ldr.dm.GetCurrencyConversions(tnt, false, false, utils.NonTransactional); err != nil {
+						if err != utils.ErrNotFound {
+							return
+						}
+						cC, err = &engine.CurrencyConversions{Tenant: tnt}, nil
+					}
+				}
+				var xRate float64
+				if xRate, err = utils.IfaceAsFloat64(ld[utils.ExchangeRate]); err != nil {
+					return
+				}
+				cC.SetExchangeRate(utils.IfaceAsString(ld[utils.FromCurrency]),
+					utils.IfaceAsString(ld[utils.ToCurrency]), utils.NewDecimalFromFloat64(xRate))
+			}
+			if cC == nil {
+				continue
+			}
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: CurrencyConversions: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(cC)))
+				continue
+			}
+			if err = ldr.dm.SetCurrencyConversions(cC); err != nil {
+				return
+			}
+		}
 	}
 
 	if len(ldr.cacheConns) != 0 {
@@ -1028,6 +1062,33 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				}
 			}
 		}
+	case utils.MetaCurrencyConversions:
+		cacheIDs = []string{utils.CacheCurrencyConversions}
+		for tntID, ldData := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: CurrencyConversions: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+				continue
+			}
+			tnt := utils.NewTenantID(tntID).Tenant
+			var cC *engine.CurrencyConversions
+			if cC, err = ldr.dm.GetCurrencyConversions(tnt, false, false, utils.NonTransactional); err != nil {
+				return
+			}
+			for _, ld := range ldData {
+				cC.RemoveExchangeRate(utils.IfaceAsString(ld[utils.FromCurrency]),
+					utils.IfaceAsString(ld[utils.ToCurrency]))
+			}
+			if len(cC.ExchangeRates) == 0 { // no more conversions for the tenant
+				err = ldr.dm.RemoveCurrencyConversions(tnt)
+			} else {
+				err = ldr.dm.SetCurrencyConversions(cC)
+			}
+			if err != nil {
+				return
+			}
+		}
 	}
 
 	if len(ldr.cacheConns) != 0 {
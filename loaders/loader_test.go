@@ -308,6 +308,10 @@ func TestLoaderProcessResource(t *testing.T) {
 				Path:  "Thresholds",
 				Type:  utils.MetaComposed,
 				Value: config.NewRSRParsersMustCompile("~*req.10", utils.InfieldSep)},
+			{Tag: "RateWindow",
+				Path:  "RateWindow",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.11", utils.InfieldSep)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ResourcesCSVContent))
@@ -350,6 +354,7 @@ func TestLoaderProcessResource(t *testing.T) {
 		Blocker:           true,
 		Stored:            true,
 		ThresholdIDs:      []string{},
+		RateWindow:        time.Second,
 	}
 	if len(ldr.bufLoaderData) != 0 {
 		t.Errorf("wrong buffer content: %+v", ldr.bufLoaderData)
@@ -569,6 +574,14 @@ func TestLoaderProcessThresholds(t *testing.T) {
 				Path:  "Async",
 				Type:  utils.MetaComposed,
 				Value: config.NewRSRParsersMustCompile("~*req.10", utils.InfieldSep)},
+			{Tag: "RecoveryFilterIDs",
+				Path:  "RecoveryFilterIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.11", utils.InfieldSep)},
+			{Tag: "RecoveryActionIDs",
+				Path:  "RecoveryActionIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.12", utils.InfieldSep)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ThresholdsCSVContent))
@@ -591,13 +604,15 @@ func TestLoaderProcessThresholds(t *testing.T) {
 		FilterIDs: []string{"*string:~*req.Account:1001", "*string:~*req.RunID:*default"},
 		ActivationInterval: &utils.ActivationInterval{
 			ActivationTime: time.Date(2014, 7, 29, 15, 0, 0, 0, time.UTC)},
-		MaxHits:   12,
-		MinHits:   10,
-		MinSleep:  time.Second,
-		Blocker:   true,
-		Weight:    10,
-		ActionIDs: []string{"THRESH1"},
-		Async:     true,
+		MaxHits:           12,
+		MinHits:           10,
+		MinSleep:          time.Second,
+		Blocker:           true,
+		Weight:            10,
+		ActionIDs:         []string{"THRESH1"},
+		Async:             true,
+		RecoveryFilterIDs: []string{"*gte:~*req.ASR:60"},
+		RecoveryActionIDs: []string{"THRESH_RECOVERED"},
 	}
 	aps, err := ldr.dm.GetThresholdProfile("cgrates.org", "Threshold1",
 		true, false, utils.NonTransactional)
@@ -690,6 +705,26 @@ func TestLoaderProcessStats(t *testing.T) {
 				Path:  "ThresholdIDs",
 				Type:  utils.MetaComposed,
 				Value: config.NewRSRParsersMustCompile("~*req.12", utils.InfieldSep)},
+			{Tag: "GroupBy",
+				Path:  "GroupBy",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.13", utils.InfieldSep)},
+			{Tag: "GroupTTL",
+				Path:  "GroupTTL",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.14", utils.InfieldSep)},
+			{Tag: "WindowType",
+				Path:  "WindowType",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.15", utils.InfieldSep)},
+			{Tag: "WindowLength",
+				Path:  "WindowLength",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.16", utils.InfieldSep)},
+			{Tag: "WindowHistory",
+				Path:  "WindowHistory",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.17", utils.InfieldSep)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.StatsCSVContent))
@@ -726,11 +761,14 @@ func TestLoaderProcessStats(t *testing.T) {
 				MetricID: "*sum#~*req.Usage",
 			},
 		},
-		ThresholdIDs: []string{"Th1", "Th2"},
-		Blocker:      true,
-		Stored:       true,
-		Weight:       20,
-		MinItems:     2,
+		ThresholdIDs:  []string{"Th1", "Th2"},
+		Blocker:       true,
+		Stored:        true,
+		Weight:        20,
+		MinItems:      2,
+		WindowType:    utils.MetaTumbling,
+		WindowLength:  time.Hour,
+		WindowHistory: 24,
 	}
 
 	aps, err := ldr.dm.GetStatQueueProfile("cgrates.org", "TestStats",
@@ -745,6 +783,12 @@ func TestLoaderProcessStats(t *testing.T) {
 	} else if !reflect.DeepEqual(eSt1, aps) {
 		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eSt1), utils.ToJSON(aps))
 	}
+	if aps, err = ldr.dm.GetStatQueueProfile("cgrates.org", "TestStats2",
+		true, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if aps.GroupBy != "~*req.Destination{*prefix:4}" || aps.GroupTTL != time.Hour {
+		t.Errorf("unexpected GroupBy: %q, GroupTTL: %v", aps.GroupBy, aps.GroupTTL)
+	}
 
 	//cannot set statsProfile when dryrun is true
 	ldr.dryRun = true
@@ -898,6 +942,14 @@ func TestLoaderProcessRoutes(t *testing.T) {
 				Path:  "Weight",
 				Type:  utils.MetaComposed,
 				Value: config.NewRSRParsersMustCompile("~*req.15", utils.InfieldSep)},
+			{Tag: "BreakerFailures",
+				Path:  "BreakerFailures",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.16", utils.InfieldSep)},
+			{Tag: "BreakerCoolDown",
+				Path:  "BreakerCoolDown",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.17", utils.InfieldSep)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.RoutesCSVContent))
@@ -957,7 +1009,9 @@ func TestLoaderProcessRoutes(t *testing.T) {
 				RouteParameters: utils.EmptyString,
 			},
 		},
-		Weight: 20,
+		Weight:          20,
+		BreakerFailures: 3,
+		BreakerCoolDown: time.Minute,
 	}
 	sort.Slice(eSp.Routes, func(i, j int) bool {
 		return strings.Compare(eSp.Routes[i].ID+strings.Join(eSp.Routes[i].FilterIDs, utils.ConcatenatedKeySep),
@@ -1052,6 +1106,98 @@ cgrates.org,ACTPRF_ID1
 	}
 }
 
+func TestLoaderProcessCurrencyConversions(t *testing.T) {
+	engine.Cache.Clear(nil)
+	data := engine.NewInternalDB(nil, nil, true)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessCurrencyConversions",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaCurrencyConversions: {
+			{Tag: "Tenant",
+				Path:      "Tenant",
+				Type:      utils.MetaVariable,
+				Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "FromCurrency",
+				Path:      "FromCurrency",
+				Type:      utils.MetaVariable,
+				Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "ToCurrency",
+				Path:      "ToCurrency",
+				Type:      utils.MetaVariable,
+				Value:     config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "ExchangeRate",
+				Path:      "ExchangeRate",
+				Type:      utils.MetaVariable,
+				Value:     config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep),
+				Mandatory: true},
+		},
+	}
+	ccvCsv := `
+#Tenant[0],FromCurrency[1],ToCurrency[2],ExchangeRate[3]
+cgrates.org,USD,EUR,0.85
+cgrates.org,GBP,EUR,1.15
+`
+	setReader := func(content string) {
+		rdr := ioutil.NopCloser(strings.NewReader(content))
+		csvRdr := csv.NewReader(rdr)
+		csvRdr.Comment = '#'
+		ldr.rdrs = map[string]map[string]*openedCSVFile{
+			utils.MetaCurrencyConversions: {
+				utils.CurrencyConversionsCsv: &openedCSVFile{
+					fileName: utils.CurrencyConversionsCsv, rdr: rdr,
+					csvRdr: csvRdr,
+				},
+			},
+		}
+	}
+	setReader(ccvCsv)
+	if err := ldr.processContent(utils.MetaCurrencyConversions, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eCC := &engine.CurrencyConversions{
+		Tenant: "cgrates.org",
+		ExchangeRates: map[string]*utils.Decimal{
+			"USD:EUR": utils.NewDecimalFromFloat64(0.85),
+			"GBP:EUR": utils.NewDecimalFromFloat64(1.15),
+		},
+	}
+	if rcv, err := ldr.dm.GetCurrencyConversions("cgrates.org", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if utils.ToJSON(eCC) != utils.ToJSON(rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eCC), utils.ToJSON(rcv))
+	}
+
+	setReader(`
+cgrates.org,GBP,EUR,
+`)
+	if err := ldr.removeContent(utils.MetaCurrencyConversions, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	delete(eCC.ExchangeRates, "GBP:EUR")
+	if rcv, err := ldr.dm.GetCurrencyConversions("cgrates.org", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if utils.ToJSON(eCC) != utils.ToJSON(rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eCC), utils.ToJSON(rcv))
+	}
+
+	setReader(`
+cgrates.org,USD,EUR,
+`)
+	if err := ldr.removeContent(utils.MetaCurrencyConversions, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldr.dm.GetCurrencyConversions("cgrates.org", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
 func TestLoaderProcessChargers(t *testing.T) {
 	data := engine.NewInternalDB(nil, nil, true)
 	ldr := &Loader{
@@ -1599,6 +1745,18 @@ func TestLoaderProcessRateProfile(t *testing.T) {
 				Path:  "RateIncrement",
 				Type:  utils.MetaComposed,
 				Value: config.NewRSRParsersMustCompile("~*req.17", utils.InfieldSep)},
+			{Tag: "Currency",
+				Path:  "Currency",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.18", utils.InfieldSep)},
+			{Tag: "VolumeCounter",
+				Path:  "VolumeCounter",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.19", utils.InfieldSep)},
+			{Tag: "VolumeReset",
+				Path:  "VolumeReset",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.20", utils.InfieldSep)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.RateProfileCSVContent))
@@ -1635,6 +1793,9 @@ func TestLoaderProcessRateProfile(t *testing.T) {
 		MinCost:         utils.NewDecimal(1, 1),
 		MaxCost:         utils.NewDecimal(6, 1),
 		MaxCostStrategy: "*free",
+		Currency:        "EUR",
+		VolumeCounter:   utils.MetaAccount,
+		VolumeReset:     "0 0 1 * *",
 		Rates: map[string]*engine.Rate{
 			"RT_WEEK": {
 				ID: "RT_WEEK",
@@ -2403,20 +2564,21 @@ func TestNewLoaderWithMultiFiles(t *testing.T) {
 		}
 	}
 	expected := utils.StringSet{
-		utils.AttributesCsv:         {},
-		utils.ChargersCsv:           {},
-		utils.DispatcherHostsCsv:    {},
-		utils.DispatcherProfilesCsv: {},
-		"File1.csv":                 {},
-		"File2.csv":                 {},
-		utils.FiltersCsv:            {},
-		utils.RateProfilesCsv:       {},
-		utils.ResourcesCsv:          {},
-		utils.RoutesCsv:             {},
-		utils.StatsCsv:              {},
-		utils.ThresholdsCsv:         {},
-		utils.ActionProfilesCsv:     {},
-		utils.AccountProfilesCsv:    {},
+		utils.AttributesCsv:          {},
+		utils.ChargersCsv:            {},
+		utils.DispatcherHostsCsv:     {},
+		utils.DispatcherProfilesCsv:  {},
+		"File1.csv":                  {},
+		"File2.csv":                  {},
+		utils.FiltersCsv:             {},
+		utils.RateProfilesCsv:        {},
+		utils.ResourcesCsv:           {},
+		utils.RoutesCsv:              {},
+		utils.StatsCsv:               {},
+		utils.ThresholdsCsv:          {},
+		utils.ActionProfilesCsv:      {},
+		utils.AccountProfilesCsv:     {},
+		utils.CurrencyConversionsCsv: {},
 	}
 	if !reflect.DeepEqual(expected, openRdrs) {
 		t.Errorf("Expected %s,received %s", utils.ToJSON(expected), utils.ToJSON(openRdrs))
@@ -2515,6 +2677,11 @@ func TestLoaderActionProfile(t *testing.T) {
 				Type:   utils.MetaVariable,
 				Value:  config.NewRSRParsersMustCompile("~*req.15", utils.InfieldSep),
 				Layout: time.RFC3339},
+			{Tag: "MissedRuns",
+				Path:   "MissedRuns",
+				Type:   utils.MetaVariable,
+				Value:  config.NewRSRParsersMustCompile("~*req.16", utils.InfieldSep),
+				Layout: time.RFC3339},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ActionProfileCSVContent))
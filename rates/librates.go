@@ -275,3 +275,65 @@ func computeRateSIntervals(rts []*orderedRate, intervalStart, usage time.Duratio
 	}
 	return
 }
+
+// shiftOrderedRates returns a copy of the ordered rates moved with the volume already consumed
+// so the IntervalRates can be applied on the accumulated usage
+func shiftOrderedRates(ordRts []*orderedRate, volume time.Duration) (shftRts []*orderedRate) {
+	if volume == 0 {
+		return ordRts
+	}
+	shftRts = make([]*orderedRate, len(ordRts))
+	for i, ordRt := range ordRts {
+		shftRts[i] = &orderedRate{ordRt.Duration + volume, ordRt.Rate}
+	}
+	return
+}
+
+// convertCost converts the costs of the RateProfileCost into toCurrency
+// recording the applied exchange rate
+func convertCost(rpCost *engine.RateProfileCost, cC *engine.CurrencyConversions, toCurrency string) (err error) {
+	if rpCost.Currency == utils.EmptyString {
+		return fmt.Errorf("<%s> cannot convert the cost of RateProfile <%s> without currency into <%s>",
+			utils.RateS, rpCost.ID, toCurrency)
+	}
+	var xRate *decimal.Big
+	if xRate, err = cC.ExchangeRate(rpCost.Currency, toCurrency); err != nil {
+		return
+	}
+	convert := func(val float64) (cnvrted float64) {
+		cnvrted, _ = utils.MultiplyBig(utils.NewDecimalFromFloat64(val).Big, xRate).Float64()
+		return
+	}
+	rpCost.Cost = convert(rpCost.Cost)
+	rpCost.MinCost = convert(rpCost.MinCost)
+	rpCost.MaxCost = convert(rpCost.MaxCost)
+	rpCost.Conversion = &engine.CostConversion{
+		FromCurrency: rpCost.Currency,
+		ToCurrency:   toCurrency,
+	}
+	rpCost.Conversion.ExchangeRate, _ = xRate.Float64()
+	rpCost.Currency = toCurrency
+	return
+}
+
+// eventWithOpts returns a copy of the event populated with the default tenant and the common options
+// the options of the event take precedence
+func eventWithOpts(cgrEv *utils.CGREvent, tnt string, opts map[string]interface{}) (ev *utils.CGREvent) {
+	ev = &utils.CGREvent{
+		Tenant: cgrEv.Tenant,
+		ID:     cgrEv.ID,
+		Time:   cgrEv.Time,
+		Event:  cgrEv.Event,
+		Opts:   make(map[string]interface{}, len(opts)+len(cgrEv.Opts)),
+	}
+	if ev.Tenant == utils.EmptyString {
+		ev.Tenant = tnt
+	}
+	for k, v := range opts {
+		ev.Opts[k] = v
+	}
+	for k, v := range cgrEv.Opts {
+		ev.Opts[k] = v
+	}
+	return
+}
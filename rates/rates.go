@@ -24,15 +24,18 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
 )
 
 // NewRateS instantiates the RateS
-func NewRateS(cfg *config.CGRConfig, filterS *engine.FilterS, dm *engine.DataManager) *RateS {
+func NewRateS(cfg *config.CGRConfig, filterS *engine.FilterS, dm *engine.DataManager, connMgr *engine.ConnManager) *RateS {
 	return &RateS{
 		cfg:     cfg,
 		filterS: filterS,
 		dm:      dm,
+		connMgr: connMgr,
 	}
 }
 
@@ -41,6 +44,7 @@ type RateS struct {
 	cfg     *config.CGRConfig
 	filterS *engine.FilterS
 	dm      *engine.DataManager
+	connMgr *engine.ConnManager
 }
 
 // ListenAndServe keeps the service alive
@@ -130,13 +134,12 @@ func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *
 
 // rateProfileCostForEvent computes the rateProfileCost for an event based on a preselected rate profile
 func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.ArgsCostForEvent, verbosity int) (rpCost *engine.RateProfileCost, err error) {
-	evNm := utils.MapStorage{
-		utils.MetaReq:  args.CGREvent.Event,
-		utils.MetaOpts: args.Opts,
-	}
 	var rtIDs utils.StringSet
 	if rtIDs, err = engine.MatchingItemIDsForEvent(
-		evNm,
+		utils.MapStorage{
+			utils.MetaReq:  args.CGREvent.Event,
+			utils.MetaOpts: args.Opts,
+		},
 		rS.cfg.RateSCfg().RateStringIndexedFields,
 		rS.cfg.RateSCfg().RatePrefixIndexedFields,
 		rS.cfg.RateSCfg().RateSuffixIndexedFields,
@@ -148,9 +151,22 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 	); err != nil {
 		return
 	}
+	return rS.rateProfileCostForRates(rtPfl, rtIDs, args, verbosity)
+}
+
+// rateProfileCostForRates computes the rateProfileCost for an event considering only the rates with the given IDs
+func (rS *RateS) rateProfileCostForRates(rtPfl *engine.RateProfile, rtIDs utils.StringSet,
+	args *utils.ArgsCostForEvent, verbosity int) (rpCost *engine.RateProfileCost, err error) {
+	evNm := utils.MapStorage{
+		utils.MetaReq:  args.CGREvent.Event,
+		utils.MetaOpts: args.Opts,
+	}
 	aRates := make([]*engine.Rate, 0, len(rtIDs))
 	for rtID := range rtIDs {
-		rt := rtPfl.Rates[rtID] // pick the rate directly from map based on matched ID
+		rt, has := rtPfl.Rates[rtID] // pick the rate directly from map based on matched ID
+		if !has {
+			continue
+		}
 		var pass bool
 		if pass, err = rS.filterS.Pass(args.CGREvent.Tenant, rt.FilterIDs, evNm); err != nil {
 			return
@@ -179,6 +195,15 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 	if ordRts, err = orderRatesOnIntervals(aRates, wghts, sTime, usage, true, verbosity); err != nil {
 		return
 	}
+	var volume time.Duration // the IntervalRates are applied on top of the volume already consumed
+	if rtPfl.VolumeCounter != utils.EmptyString {
+		var rvc *engine.RateVolumeCounter
+		if rvc, err = rS.volumeCounter(rtPfl, evNm, args.CGREvent.Tenant, sTime); err != nil {
+			return
+		}
+		volume = rvc.Volume
+		ordRts = shiftOrderedRates(ordRts, volume)
+	}
 	rpCost = &engine.RateProfileCost{
 		ID: rtPfl.ID,
 	}
@@ -194,17 +219,36 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 		}
 	}
 
-	if rpCost.RateSIntervals, err = computeRateSIntervals(ordRts, 0, usage); err != nil {
+	if rpCost.RateSIntervals, err = computeRateSIntervals(ordRts, volume, usage); err != nil {
 		return nil, err
 	}
 	// in case we have error it is returned in the function from above
 	// this came to light in coverage tests
 	rpCost.Cost, _ = engine.CostForIntervals(rpCost.RateSIntervals).Float64()
+	rpCost.Currency = rtPfl.Currency
 
+	toCurrency := args.Currency()
+	if toCurrency == utils.EmptyString ||
+		toCurrency == rpCost.Currency { // no conversion needed
+		return
+	}
+	var cC *engine.CurrencyConversions
+	if cC, err = rS.dm.GetCurrencyConversions(args.CGREvent.Tenant, true, true, utils.NonTransactional); err != nil {
+		if err == utils.ErrNotFound {
+			err = fmt.Errorf("<%s> missing currency conversions for tenant <%s>",
+				utils.RateS, args.CGREvent.Tenant)
+		}
+		return nil, err
+	}
+	if err = convertCost(rpCost, cC, toCurrency); err != nil {
+		return nil, err
+	}
 	return
 }
 
 // V1CostForEvent will be called to calculate the cost for an event
+// the volume counters are updated only when *ratesUpdateVolume is true, so the caller charging
+// the event sets it once per event while the cost queries (ie. from AccountS) leave the volume unchanged
 func (rS *RateS) V1CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.RateProfileCost) (err error) {
 	rPfIDs := make([]string, len(args.RateProfileIDs))
 	for i, rpID := range args.RateProfileIDs {
@@ -217,8 +261,39 @@ func (rS *RateS) V1CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.Rat
 		}
 		return
 	}
+	var updVolume bool
+	if rtPrl.VolumeCounter != utils.EmptyString {
+		if updVolume, err = args.UpdateVolume(); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
 	var rcvCost *engine.RateProfileCost
-	if rcvCost, err = rS.rateProfileCostForEvent(rtPrl, args, rS.cfg.RateSCfg().Verbosity); err != nil {
+	if !updVolume {
+		if rcvCost, err = rS.rateProfileCostForEvent(rtPrl, args, rS.cfg.RateSCfg().Verbosity); err != nil {
+			if err != utils.ErrNotFound {
+				err = utils.NewErrServerError(err)
+			}
+			return
+		}
+		*rpCost = *rcvCost
+		return
+	}
+	var cntID string
+	if cntID, err = rtPrl.VolumeCounterID(utils.MapStorage{
+		utils.MetaReq:  args.CGREvent.Event,
+		utils.MetaOpts: args.Opts,
+	}); err != nil {
+		return
+	}
+	// rate and consume the volume under the same lock so concurrent events see consistent tiers
+	if _, err = guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+		if rcvCost, gErr = rS.rateProfileCostForEvent(rtPrl, args, rS.cfg.RateSCfg().Verbosity); gErr != nil {
+			return
+		}
+		gErr = rS.updateVolumeCounter(rtPrl, args)
+		return
+	}, rS.cfg.GeneralCfg().LockingTimeout,
+		utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(args.CGREvent.Tenant, cntID)); err != nil {
 		if err != utils.ErrNotFound {
 			err = utils.NewErrServerError(err)
 		}
@@ -227,3 +302,145 @@ func (rS *RateS) V1CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.Rat
 	*rpCost = *rcvCost
 	return
 }
+
+// volumeCounter returns a copy of the RateVolumeCounter used by the event
+// the counter is returned empty if it was not yet created or if its reset time was reached
+func (rS *RateS) volumeCounter(rtPfl *engine.RateProfile, ev utils.DataProvider,
+	tnt string, sTime time.Time) (rvc *engine.RateVolumeCounter, err error) {
+	var cntID string
+	if cntID, err = rtPfl.VolumeCounterID(ev); err != nil {
+		return
+	}
+	var storedRvc *engine.RateVolumeCounter
+	if storedRvc, err = rS.dm.GetRateVolumeCounter(tnt, cntID); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+	}
+	if storedRvc != nil &&
+		(storedRvc.ResetTime.IsZero() || sTime.Before(storedRvc.ResetTime)) {
+		rvc = new(engine.RateVolumeCounter)
+		*rvc = *storedRvc // do not modify the cached version
+		return
+	}
+	return &engine.RateVolumeCounter{
+		Tenant:    tnt,
+		ID:        cntID,
+		ResetTime: rtPfl.NextVolumeReset(sTime),
+	}, nil
+}
+
+// updateVolumeCounter adds the usage of the event to the volume accumulated on the RateProfile
+func (rS *RateS) updateVolumeCounter(rtPfl *engine.RateProfile, args *utils.ArgsCostForEvent) (err error) {
+	var sTime time.Time
+	if sTime, err = args.StartTime(rS.cfg.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
+	var usage time.Duration
+	if usage, err = args.Usage(); err != nil {
+		return
+	}
+	var rvc *engine.RateVolumeCounter
+	if rvc, err = rS.volumeCounter(rtPfl, utils.MapStorage{
+		utils.MetaReq:  args.CGREvent.Event,
+		utils.MetaOpts: args.Opts,
+	}, args.CGREvent.Tenant, sTime); err != nil {
+		return
+	}
+	rvc.Volume += usage
+	return rS.dm.SetRateVolumeCounter(rvc)
+}
+
+// V1CostForEvents simulates the costs for a batch of events, out of the stored RateProfiles or out of a candidate one
+func (rS *RateS) V1CostForEvents(args *engine.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rS.cfg.GeneralCfg().DefaultTenant
+	}
+	var cndRtPfl *engine.RateProfile // candidate RateProfile, not stored in DataDB
+	var cndRtIDs utils.StringSet
+	if args.RateProfile != nil {
+		if cndRtPfl, err = args.RateProfile.AsRateProfile(); err != nil {
+			return utils.NewErrServerError(err)
+		}
+		if cndRtPfl.Tenant == utils.EmptyString {
+			cndRtPfl.Tenant = tnt
+		}
+		cndRtIDs = make(utils.StringSet)
+		for rtID := range cndRtPfl.Rates {
+			cndRtIDs.Add(rtID)
+		}
+	}
+	cgrEvs := args.CGREvents
+	if args.CDRsFilter != nil {
+		if len(rS.cfg.RateSCfg().CDRsConns) == 0 {
+			return utils.NewErrNotConnected(utils.CDRs)
+		}
+		var cdrs []*engine.CDR
+		if err = rS.connMgr.Call(rS.cfg.RateSCfg().CDRsConns, nil, utils.CDRsV1GetCDRs,
+			&utils.RPCCDRsFilterWithOpts{
+				RPCCDRsFilter: args.CDRsFilter,
+				Opts:          args.Opts,
+				Tenant:        tnt,
+			}, &cdrs); err != nil && err.Error() != utils.ErrNotFound.Error() {
+			return utils.NewErrCDRS(err)
+		}
+		err = nil
+		for _, cdr := range cdrs {
+			cgrEv := cdr.AsCGREvent()
+			cgrEv.ID = utils.FirstNonEmpty(cdr.CGRID, cgrEv.ID) // so the costs can be matched with the CDRs
+			cgrEvs = append(cgrEvs, cgrEv)
+		}
+	}
+	if len(cgrEvs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventsFld)
+	}
+	rplyCosts := &engine.RateProfileCosts{
+		EventCosts:        make([]*engine.EventRateProfileCost, len(cgrEvs)),
+		RateProfileTotals: make(map[string]float64),
+	}
+	totalCost := new(decimal.Big)
+	rpTotals := make(map[string]*decimal.Big)
+	for i, cgrEv := range cgrEvs {
+		evArgs := &utils.ArgsCostForEvent{
+			RateProfileIDs: args.RateProfileIDs,
+			CGREvent:       eventWithOpts(cgrEv, tnt, args.Opts),
+		}
+		evCost := &engine.EventRateProfileCost{EventID: cgrEv.ID}
+		rplyCosts.EventCosts[i] = evCost
+		var usage time.Duration
+		var rpCost *engine.RateProfileCost
+		if usage, err = evArgs.Usage(); err == nil {
+			if cndRtPfl != nil {
+				rpCost, err = rS.rateProfileCostForRates(cndRtPfl, cndRtIDs, evArgs, rS.cfg.RateSCfg().Verbosity)
+			} else {
+				var rtPfl *engine.RateProfile
+				if rtPfl, err = rS.matchingRateProfileForEvent(evArgs.Tenant, evArgs.RateProfileIDs, evArgs); err == nil {
+					rpCost, err = rS.rateProfileCostForEvent(rtPfl, evArgs, rS.cfg.RateSCfg().Verbosity)
+				}
+			}
+		}
+		if err != nil { // errors are reported per event, the others are still rated
+			evCost.Error = err.Error()
+			rplyCosts.FailedEvents++
+			err = nil
+			continue
+		}
+		evCost.RateProfileCost = rpCost
+		rplyCosts.RatedEvents++
+		rplyCosts.TotalUsage += usage
+		cost := utils.NewDecimalFromFloat64(rpCost.Cost).Big
+		totalCost = utils.SumBig(totalCost, cost)
+		if _, has := rpTotals[rpCost.ID]; !has {
+			rpTotals[rpCost.ID] = new(decimal.Big)
+		}
+		rpTotals[rpCost.ID] = utils.SumBig(rpTotals[rpCost.ID], cost)
+	}
+	rplyCosts.TotalCost, _ = totalCost.Float64()
+	for rpID, rpTotal := range rpTotals {
+		rplyCosts.RateProfileTotals[rpID], _ = rpTotal.Float64()
+	}
+	*rpCosts = *rplyCosts
+	return
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/rpcclient"
 )
 
 func TestListenAndServe(t *testing.T) {
@@ -52,7 +53,7 @@ func TestNewRateS(t *testing.T) {
 		filterS: filters,
 		dm:      dataManager,
 	}
-	if newRateS := NewRateS(config, filters, dataManager); !reflect.DeepEqual(newRateS, expected) {
+	if newRateS := NewRateS(config, filters, dataManager, nil); !reflect.DeepEqual(newRateS, expected) {
 		t.Errorf("Expected %+v, received %+v", expected, newRateS)
 	}
 }
@@ -125,7 +126,7 @@ func TestRateProfileCostForEvent(t *testing.T) {
 	data := engine.NewInternalDB(nil, nil, true)
 	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
 	filters := engine.NewFilterS(defaultCfg, nil, dm)
-	rateS := NewRateS(defaultCfg, filters, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
 	minDecimal, err := utils.NewDecimalFromUsage("1m")
 	if err != nil {
 		t.Error(err)
@@ -230,7 +231,7 @@ func TestRateProfileCostForEventUnmatchEvent(t *testing.T) {
 	data := engine.NewInternalDB(nil, nil, true)
 	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
 	filters := engine.NewFilterS(defaultCfg, nil, dm)
-	rateS := NewRateS(defaultCfg, filters, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
 	minDecimal, err := utils.NewDecimalFromUsage("1m")
 	if err != nil {
 		t.Error(err)
@@ -322,6 +323,274 @@ func TestRateProfileCostForEventUnmatchEvent(t *testing.T) {
 	}
 }
 
+func TestRateProfileCostForEventCurrency(t *testing.T) {
+	engine.Cache.Clear(nil)
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant:   "cgrates.org",
+		ID:       "RATE_PRF_USD",
+		Currency: "USD",
+		Rates: map[string]*engine.Rate{
+			"RATE1": {
+				ID:              "RATE1",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						RecurrentFee:  utils.NewDecimal(2, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsCostForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "RATE_CURRENCY",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsRatesCurrency: "EUR",
+			},
+		},
+	}
+	expErr := "<RateS> missing currency conversions for tenant <cgrates.org>"
+	if _, err := rateS.rateProfileCostForEvent(rPrf, args,
+		rateS.cfg.RateSCfg().Verbosity); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+
+	cC := &engine.CurrencyConversions{Tenant: "cgrates.org"}
+	cC.SetExchangeRate("EUR", "USD", utils.NewDecimal(125, 2))
+	if err := dm.SetCurrencyConversions(cC); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := rateS.rateProfileCostForEvent(rPrf, args,
+		rateS.cfg.RateSCfg().Verbosity); err != nil {
+		t.Error(err)
+	} else if rcv.Cost != 0.16 || rcv.Currency != "EUR" {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rcv))
+	} else if eConv := (&engine.CostConversion{FromCurrency: "USD",
+		ToCurrency: "EUR", ExchangeRate: 0.8}); !reflect.DeepEqual(eConv, rcv.Conversion) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eConv), utils.ToJSON(rcv.Conversion))
+	}
+
+	args.Opts[utils.OptsRatesCurrency] = "GBP"
+	expErr = "missing exchange rate from <USD> to <GBP>"
+	if _, err := rateS.rateProfileCostForEvent(rPrf, args,
+		rateS.cfg.RateSCfg().Verbosity); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+
+	args.Opts[utils.OptsRatesCurrency] = "USD"
+	if rcv, err := rateS.rateProfileCostForEvent(rPrf, args,
+		rateS.cfg.RateSCfg().Verbosity); err != nil {
+		t.Error(err)
+	} else if rcv.Cost != 0.2 || rcv.Currency != "USD" || rcv.Conversion != nil {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestV1CostForEventVolumeCounter(t *testing.T) {
+	engine.Cache.Clear(nil)
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant:        "cgrates.org",
+		ID:            "RATE_PRF_VOLUME",
+		VolumeCounter: utils.MetaAccount,
+		VolumeReset:   "0 0 1 * *",
+		Rates: map[string]*engine.Rate{
+			"RATE1": {
+				ID:              "RATE1",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						RecurrentFee:  utils.NewDecimal(2, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+					{
+						IntervalStart: time.Minute,
+						RecurrentFee:  utils.NewDecimal(1, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsCostForEvent{
+		RateProfileIDs: []string{"RATE_PRF_VOLUME"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "RATE_VOLUME",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsRatesUsage:        "1m",
+				utils.OptsRatesStartTime:    time.Date(2021, 3, 15, 10, 0, 0, 0, time.UTC),
+				utils.OptsRatesUpdateVolume: true,
+			},
+		},
+	}
+	var rpCost engine.RateProfileCost
+	if err := rateS.V1CostForEvent(args, &rpCost); err != nil {
+		t.Fatal(err)
+	} else if rpCost.Cost != 0.2 {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCost))
+	}
+	eRvc := &engine.RateVolumeCounter{
+		Tenant:    "cgrates.org",
+		ID:        "RATE_PRF_VOLUME:1001",
+		Volume:    time.Minute,
+		ResetTime: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if rcv, err := dm.GetRateVolumeCounter("cgrates.org", "RATE_PRF_VOLUME:1001"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eRvc, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eRvc), utils.ToJSON(rcv))
+	}
+	// the second minute is rated on the next tier
+	if err := rateS.V1CostForEvent(args, &rpCost); err != nil {
+		t.Fatal(err)
+	} else if rpCost.Cost != 0.1 {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCost))
+	}
+	eRvc.Volume = 2 * time.Minute
+	if rcv, err := dm.GetRateVolumeCounter("cgrates.org", "RATE_PRF_VOLUME:1001"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eRvc, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eRvc), utils.ToJSON(rcv))
+	}
+	// after the reset the volume is accumulated from zero without updating the counter
+	args.Opts[utils.OptsRatesStartTime] = time.Date(2021, 4, 2, 10, 0, 0, 0, time.UTC)
+	args.Opts[utils.OptsRatesUpdateVolume] = false
+	if err := rateS.V1CostForEvent(args, &rpCost); err != nil {
+		t.Fatal(err)
+	} else if rpCost.Cost != 0.2 {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCost))
+	}
+	if rcv, err := dm.GetRateVolumeCounter("cgrates.org", "RATE_PRF_VOLUME:1001"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eRvc, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eRvc), utils.ToJSON(rcv))
+	}
+
+	delete(args.CGREvent.Event, utils.AccountField)
+	expErr := utils.NewErrServerError(utils.NewErrMandatoryIeMissing(utils.AccountField))
+	if err := rateS.V1CostForEvent(args, &rpCost); err == nil || err.Error() != expErr.Error() {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestV1CostForEventVolumeCallerDriven(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	rateS := NewRateS(cfg, engine.NewFilterS(cfg, nil, dm), dm, nil)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant:        "cgrates.org",
+		ID:            "RATE_PRF_VOLUME_FLOW",
+		VolumeCounter: utils.MetaTenant,
+		Rates: map[string]*engine.Rate{
+			"RATE1": {
+				ID:              "RATE1",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						RecurrentFee:  utils.NewDecimal(2, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+					{
+						IntervalStart: time.Minute,
+						RecurrentFee:  utils.NewDecimal(1, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Cache.Remove(utils.CacheRateProfiles, rPrf.TenantID(), true, utils.NonTransactional)
+	args := &utils.ArgsCostForEvent{
+		RateProfileIDs: []string{rPrf.ID},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "RATE_VOLUME_FLOW",
+			Event:  map[string]interface{}{},
+			Opts: map[string]interface{}{
+				utils.OptsRatesUsage: "1m",
+			},
+		},
+	}
+	var rpCost engine.RateProfileCost
+	// cost queries, as done by AccountS when debiting, do not consume the volume
+	for i := 0; i < 3; i++ {
+		if err := rateS.V1CostForEvent(args, &rpCost); err != nil {
+			t.Fatal(err)
+		} else if rpCost.Cost != 0.2 {
+			t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCost))
+		}
+	}
+	if _, err := dm.GetRateVolumeCounter("cgrates.org", utils.ConcatenatedKey(rPrf.ID, utils.MetaTenant)); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	// the caller charging the event updates the volume once
+	args.Opts[utils.OptsRatesUpdateVolume] = true
+	if err := rateS.V1CostForEvent(args, &rpCost); err != nil {
+		t.Fatal(err)
+	} else if rpCost.Cost != 0.2 {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCost))
+	}
+	if rcv, err := dm.GetRateVolumeCounter("cgrates.org", utils.ConcatenatedKey(rPrf.ID, utils.MetaTenant)); err != nil {
+		t.Fatal(err)
+	} else if rcv.Volume != time.Minute {
+		t.Errorf("Expected volume %v, received %s", time.Minute, utils.ToJSON(rcv))
+	}
+	// the next events are rated on the next tier
+	delete(args.Opts, utils.OptsRatesUpdateVolume)
+	if err := rateS.V1CostForEvent(args, &rpCost); err != nil {
+		t.Fatal(err)
+	} else if rpCost.Cost != 0.1 {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCost))
+	}
+}
+
 /*
 func TestMatchingRateProfileEvent(t *testing.T) {
 	defaultCfg := config.NewDefaultCGRConfig()
@@ -478,7 +747,7 @@ func TestV1CostForEventError(t *testing.T) {
 	data := engine.NewInternalDB(nil, nil, true)
 	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
 	filters := engine.NewFilterS(defaultCfg, nil, dm)
-	rateS := NewRateS(defaultCfg, filters, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
 	minDecimal, err := utils.NewDecimalFromUsage("1m")
 	if err != nil {
 		t.Error(err)
@@ -770,7 +1039,7 @@ func TestRateProfileCostForEventInvalidUsage(t *testing.T) {
 	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
 	filters := engine.NewFilterS(defaultCfg, nil, dm)
 
-	rateS := NewRateS(defaultCfg, filters, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
 	minDecimal, err := utils.NewDecimalFromUsage("1m")
 	if err != nil {
 		t.Error(err)
@@ -849,7 +1118,7 @@ func TestRateProfileCostForEventZeroIncrement(t *testing.T) {
 	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
 	filters := engine.NewFilterS(defaultCfg, nil, dm)
 
-	rateS := NewRateS(defaultCfg, filters, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
 	minDecimal, err := utils.NewDecimalFromUsage("1m")
 	if err != nil {
 		t.Error(err)
@@ -912,7 +1181,7 @@ func TestRateProfileCostForEventMaximumIterations(t *testing.T) {
 	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
 	filters := engine.NewFilterS(defaultCfg, nil, dm)
 
-	rateS := NewRateS(defaultCfg, filters, dm)
+	rateS := NewRateS(defaultCfg, filters, dm, nil)
 	minDecimal, err := utils.NewDecimalFromUsage("1m")
 	if err != nil {
 		t.Error(err)
@@ -970,3 +1239,146 @@ func TestRateProfileCostForEventMaximumIterations(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+type testMockCall struct {
+	calls map[string]func(args interface{}, reply interface{}) error
+}
+
+func (tS *testMockCall) Call(method string, args interface{}, rply interface{}) error {
+	if call, has := tS.calls[method]; !has {
+		return rpcclient.ErrUnsupporteServiceMethod
+	} else {
+		return call(args, rply)
+	}
+}
+
+func TestV1CostForEvents(t *testing.T) {
+	engine.Cache.Clear(nil)
+	defaultCfg := config.NewDefaultCGRConfig()
+	defaultCfg.RateSCfg().CDRsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)}
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	sTestMock := &testMockCall{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.CDRsV1GetCDRs: func(args interface{}, reply interface{}) error {
+				*reply.(*[]*engine.CDR) = []*engine.CDR{{
+					CGRID:   "CDR1",
+					Tenant:  "cgrates.org",
+					Account: "1001",
+					Usage:   time.Minute,
+				}}
+				return nil
+			},
+		},
+	}
+	chanInternal := make(chan rpcclient.ClientConnector, 1)
+	chanInternal <- sTestMock
+	connMgr := engine.NewConnManager(defaultCfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs): chanInternal,
+	})
+	rateS := NewRateS(defaultCfg, filters, dm, connMgr)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant:    "cgrates.org",
+		ID:        "RP_STORED",
+		FilterIDs: []string{"*string:~*req.Account:1001"},
+		Rates: map[string]*engine.Rate{
+			"RATE1": {
+				ID:              "RATE1",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						RecurrentFee:  utils.NewDecimal(2, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var rpCosts engine.RateProfileCosts
+	expErr := "MANDATORY_IE_MISSING: [CGREvents]"
+	if err := rateS.V1CostForEvents(&engine.ArgsCostForEvents{},
+		&rpCosts); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+
+	args := &engine.ArgsCostForEvents{
+		CGREvents: []*utils.CGREvent{
+			{
+				ID: "EV1",
+				Event: map[string]interface{}{
+					utils.AccountField: "1001",
+					utils.Usage:        2 * time.Minute,
+				},
+			},
+			{
+				ID: "EV2",
+				Event: map[string]interface{}{
+					utils.AccountField: "1002",
+				},
+			},
+		},
+	}
+	if err := rateS.V1CostForEvents(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	}
+	if rpCosts.RatedEvents != 1 || rpCosts.FailedEvents != 1 ||
+		rpCosts.TotalCost != 0.4 || rpCosts.TotalUsage != 2*time.Minute {
+		t.Errorf("Unexpected costs: %s", utils.ToJSON(rpCosts))
+	} else if eTotals := map[string]float64{"RP_STORED": 0.4}; !reflect.DeepEqual(eTotals, rpCosts.RateProfileTotals) {
+		t.Errorf("Expected %+v, received %+v", eTotals, rpCosts.RateProfileTotals)
+	} else if rpCosts.EventCosts[0].EventID != "EV1" || rpCosts.EventCosts[0].RateProfileCost.Cost != 0.4 {
+		t.Errorf("Unexpected event cost: %s", utils.ToJSON(rpCosts.EventCosts[0]))
+	} else if rpCosts.EventCosts[1].EventID != "EV2" || rpCosts.EventCosts[1].Error != utils.ErrNotFound.Error() {
+		t.Errorf("Unexpected event cost: %s", utils.ToJSON(rpCosts.EventCosts[1]))
+	}
+	if len(args.CGREvents[0].Opts) != 0 || args.CGREvents[0].Tenant != utils.EmptyString {
+		t.Errorf("Events should not be modified: %s", utils.ToJSON(args.CGREvents[0]))
+	}
+
+	// the candidate profile is used for all the events, without being stored
+	args.RateProfile = &engine.APIRateProfile{
+		ID: "RP_CANDIDATE",
+		Rates: map[string]*engine.APIRate{
+			"RATE1": {
+				ID:              "RATE1",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.APIIntervalRate{
+					{
+						IntervalStart: "0",
+						RecurrentFee:  utils.Float64Pointer(0.1),
+						Unit:          utils.Float64Pointer(float64(time.Minute)),
+						Increment:     utils.Float64Pointer(float64(time.Minute)),
+					},
+				},
+			},
+		},
+	}
+	args.CDRsFilter = &utils.RPCCDRsFilter{Accounts: []string{"1001"}}
+	rpCosts = engine.RateProfileCosts{}
+	if err := rateS.V1CostForEvents(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	}
+	if rpCosts.RatedEvents != 3 || rpCosts.FailedEvents != 0 ||
+		rpCosts.TotalCost != 0.4 || rpCosts.TotalUsage != 4*time.Minute {
+		t.Errorf("Unexpected costs: %s", utils.ToJSON(rpCosts))
+	} else if eTotals := map[string]float64{"RP_CANDIDATE": 0.4}; !reflect.DeepEqual(eTotals, rpCosts.RateProfileTotals) {
+		t.Errorf("Expected %+v, received %+v", eTotals, rpCosts.RateProfileTotals)
+	} else if rpCosts.EventCosts[2].EventID != "CDR1" || rpCosts.EventCosts[2].RateProfileCost.Cost != 0.1 {
+		t.Errorf("Unexpected event cost: %s", utils.ToJSON(rpCosts.EventCosts[2]))
+	}
+	if _, err := dm.GetRateProfile("cgrates.org", "RP_CANDIDATE", true, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
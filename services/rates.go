@@ -37,7 +37,7 @@ import (
 // NewRateService constructs RateService
 func NewRateService(cfg *config.CGRConfig,
 	cacheS *engine.CacheS, filterSChan chan *engine.FilterS,
-	dmS *DataDBService, server *cores.Server,
+	dmS *DataDBService, connMgr *engine.ConnManager, server *cores.Server,
 	intConnChan chan rpcclient.ClientConnector, anz *AnalyzerService,
 	srvDep map[string]*sync.WaitGroup) servmanager.Service {
 	return &RateService{
@@ -45,6 +45,7 @@ func NewRateService(cfg *config.CGRConfig,
 		cacheS:      cacheS,
 		filterSChan: filterSChan,
 		dmS:         dmS,
+		connMgr:     connMgr,
 		server:      server,
 		intConnChan: intConnChan,
 		rldChan:     make(chan struct{}),
@@ -60,6 +61,7 @@ type RateService struct {
 	cfg         *config.CGRConfig
 	filterSChan chan *engine.FilterS
 	dmS         *DataDBService
+	connMgr     *engine.ConnManager
 	cacheS      *engine.CacheS
 	server      *cores.Server
 
@@ -126,7 +128,7 @@ func (rs *RateService) Start() (err error) {
 	dm := <-dbchan
 	dbchan <- dm
 	rs.Lock()
-	rs.rateS = rates.NewRateS(rs.cfg, fltrS, dm)
+	rs.rateS = rates.NewRateS(rs.cfg, fltrS, dm, rs.connMgr)
 	rs.Unlock()
 
 	rs.stopChan = make(chan struct{})
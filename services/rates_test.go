@@ -42,7 +42,7 @@ func TestRateSCoverage(t *testing.T) {
 	db := NewDataDBService(cfg, nil, srvDep)
 	chS := engine.NewCacheS(cfg, nil, nil)
 	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan rpcclient.ClientConnector, 1), srvDep)
-	rS := NewRateService(cfg, chS, filterSChan, db, server, make(chan rpcclient.ClientConnector, 1), anz, srvDep)
+	rS := NewRateService(cfg, chS, filterSChan, db, nil, server, make(chan rpcclient.ClientConnector, 1), anz, srvDep)
 
 	if rS.IsRunning() {
 		t.Errorf("Expected service to be down")
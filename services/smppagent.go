@@ -0,0 +1,129 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewSMPPAgent returns the smpp Agent
+func NewSMPPAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
+	shdChan *utils.SyncedChan, connMgr *engine.ConnManager,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &SMPPAgent{
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		shdChan:     shdChan,
+		connMgr:     connMgr,
+		srvDep:      srvDep,
+	}
+}
+
+// SMPPAgent implements Agent interface
+type SMPPAgent struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	shdChan     *utils.SyncedChan
+
+	smpp    *agents.SMPPAgent
+	connMgr *engine.ConnManager
+	srvDep  map[string]*sync.WaitGroup
+
+	oldListen string
+}
+
+// Start should handle the sercive start
+func (smpp *SMPPAgent) Start() (err error) {
+	if smpp.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	filterS := <-smpp.filterSChan
+	smpp.filterSChan <- filterS
+
+	smpp.Lock()
+	defer smpp.Unlock()
+	smpp.oldListen = smpp.cfg.SMPPAgentCfg().Listen
+	smpp.smpp, err = agents.NewSMPPAgent(smpp.connMgr, smpp.cfg, filterS)
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> error: %s!",
+			utils.SMPPAgent, err))
+		return
+	}
+	go func() {
+		if err = smpp.smpp.ListenAndServe(); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.SMPPAgent, err.Error()))
+			smpp.shdChan.CloseOnce() // stop the engine here
+		}
+	}()
+	return
+}
+
+// Reload handles the change of config
+func (smpp *SMPPAgent) Reload() (err error) {
+	if smpp.oldListen == smpp.cfg.SMPPAgentCfg().Listen {
+		return
+	}
+	smpp.Lock()
+	smpp.smpp.Shutdown()
+	smpp.oldListen = smpp.cfg.SMPPAgentCfg().Listen
+	smpp.smpp.InitStopChan()
+	smpp.Unlock()
+	go func() {
+		if err := smpp.smpp.ListenAndServe(); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.SMPPAgent, err.Error()))
+			smpp.shdChan.CloseOnce() // stop the engine here
+		}
+	}()
+	return
+}
+
+// Shutdown stops the service
+func (smpp *SMPPAgent) Shutdown() (err error) {
+	smpp.Lock()
+	defer smpp.Unlock()
+	smpp.smpp.Shutdown()
+	smpp.smpp = nil
+	return
+}
+
+// IsRunning returns if the service is running
+func (smpp *SMPPAgent) IsRunning() bool {
+	smpp.RLock()
+	defer smpp.RUnlock()
+	return smpp != nil && smpp.smpp != nil
+}
+
+// ServiceName returns the service name
+func (smpp *SMPPAgent) ServiceName() string {
+	return utils.SMPPAgent
+}
+
+// ShouldRun returns if the service should be running
+func (smpp *SMPPAgent) ShouldRun() bool {
+	return smpp.cfg.SMPPAgentCfg().Enabled
+}
@@ -0,0 +1,67 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package services
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+//TestSMPPAgentCoverage for cover testing
+func TestSMPPAgentCoverage(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().Enabled = true
+	filterSChan := make(chan *engine.FilterS, 1)
+	filterSChan <- nil
+	shdChan := utils.NewSyncedChan()
+	chS := engine.NewCacheS(cfg, nil, nil)
+	cacheSChan := make(chan rpcclient.ClientConnector, 1)
+	cacheSChan <- chS
+	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
+	srv := NewSMPPAgent(cfg, filterSChan, shdChan, nil, srvDep)
+	if srv.IsRunning() {
+		t.Errorf("Expected service to be down")
+	}
+	srv2 := SMPPAgent{
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		shdChan:     shdChan,
+		smpp:        &agents.SMPPAgent{},
+		connMgr:     nil,
+		srvDep:      srvDep,
+	}
+	if !srv2.IsRunning() {
+		t.Errorf("Expected service to be running")
+	}
+	serviceName := srv2.ServiceName()
+	if !reflect.DeepEqual(serviceName, utils.SMPPAgent) {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.SMPPAgent, serviceName)
+	}
+	shouldRun := srv2.ShouldRun()
+	if !reflect.DeepEqual(shouldRun, false) {
+		t.Errorf("\nExpecting <false>,\n Received <%+v>", shouldRun)
+	}
+
+}
@@ -243,6 +243,8 @@ func (srvMngr *ServiceManager) handleReload() {
 			engine.Cache.Clear([]string{utils.CacheRPCConnections})
 		case <-srvMngr.GetConfig().GetReloadChan(config.SIPAgentJson):
 			go srvMngr.reloadService(utils.SIPAgent)
+		case <-srvMngr.GetConfig().GetReloadChan(config.SMPPAgentJson):
+			go srvMngr.reloadService(utils.SMPPAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.DispatcherHJson):
 			go srvMngr.reloadService(utils.DispatcherH)
 		case <-srvMngr.GetConfig().GetReloadChan(config.HTTP_JSN):
@@ -50,12 +50,14 @@ type BiRPClient interface {
 	V1ReAuthorize(originID string, reply *string) (err error)
 	V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error)
 	V1WarnDisconnect(args map[string]interface{}, reply *string) (err error)
+	V1AlterSession(args utils.AttrAlterSession, reply *string) (err error)
 
 	BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) (err error)
 	BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string, sessionIDs *[]*SessionID) (err error)
 	BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error)
 	BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error)
 	BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error)
+	BiRPCv1AlterSession(clnt rpcclient.ClientConnector, args utils.AttrAlterSession, reply *string) (err error)
 }
 
 // GetSetCGRID will populate the CGRID key if not present and return it
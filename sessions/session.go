@@ -254,6 +254,65 @@ func (s *Session) stopDebitLoops() {
 	}
 }
 
+// asStoredSession returns the checkpoint of the session to be stored in DataDB
+// not thread safe
+func (s *Session) asStoredSession(nodeID string) (ss *engine.StoredSession) {
+	ss = &engine.StoredSession{
+		Tenant:        s.Tenant,
+		ID:            s.CGRID,
+		NodeID:        nodeID,
+		ResourceID:    s.ResourceID,
+		ClientConnID:  s.ClientConnID,
+		EventStart:    s.EventStart.Clone(),
+		DebitInterval: s.DebitInterval,
+		OptsStart:     s.OptsStart.Clone(),
+		SRuns:         make([]*engine.StoredSRun, len(s.SRuns)),
+	}
+	for i, sr := range s.SRuns {
+		clsr := sr.Clone()
+		ss.SRuns[i] = &engine.StoredSRun{
+			Event:         clsr.Event,
+			CD:            clsr.CD,
+			EventCost:     clsr.EventCost,
+			ExtraDuration: clsr.ExtraDuration,
+			LastUsage:     clsr.LastUsage,
+			TotalUsage:    clsr.TotalUsage,
+			NextAutoDebit: clsr.NextAutoDebit,
+		}
+	}
+	return
+}
+
+// newSessionFromStored recreates the session out of its checkpoint
+func newSessionFromStored(ss *engine.StoredSession) (s *Session) {
+	s = &Session{
+		CGRID:         ss.ID,
+		Tenant:        ss.Tenant,
+		ResourceID:    ss.ResourceID,
+		ClientConnID:  ss.ClientConnID,
+		EventStart:    ss.EventStart,
+		DebitInterval: ss.DebitInterval,
+		OptsStart:     ss.OptsStart,
+		SRuns:         make([]*SRun, len(ss.SRuns)),
+	}
+	if s.EventStart == nil {
+		s.EventStart = make(engine.MapEvent)
+	}
+	s.chargeable = s.OptsStart.GetBoolOrDefault(utils.OptsChargeable, true)
+	for i, sr := range ss.SRuns {
+		s.SRuns[i] = &SRun{
+			Event:         sr.Event,
+			CD:            sr.CD,
+			EventCost:     sr.EventCost,
+			ExtraDuration: sr.ExtraDuration,
+			LastUsage:     sr.LastUsage,
+			TotalUsage:    sr.TotalUsage,
+			NextAutoDebit: sr.NextAutoDebit,
+		}
+	}
+	return
+}
+
 // SRun is one billing run for the Session
 type SRun struct {
 	Event     engine.MapEvent        // Event received from ChargerS
@@ -97,6 +97,13 @@ type SessionS struct {
 // ListenAndServe starts the service and binds it to the listen loop
 func (sS *SessionS) ListenAndServe(stopChan chan struct{}) {
 	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.SessionS))
+	if sS.cgrCfg.SessionSCfg().StoreSessions {
+		if err := sS.restoreSessions(); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed restoring the stored sessions, error: <%s>",
+					utils.SessionS, err.Error()))
+		}
+	}
 	if sS.cgrCfg.SessionSCfg().ChannelSyncInterval != 0 {
 		for { // Schedule sync channels to run repeately
 			select {
@@ -112,6 +119,16 @@ func (sS *SessionS) ListenAndServe(stopChan chan struct{}) {
 
 // Shutdown is called by engine to clear states
 func (sS *SessionS) Shutdown() (err error) {
+	if sS.cgrCfg.SessionSCfg().StoreSessions { // keep the sessions stored to be recovered on start
+		for _, s := range sS.getSessions("", false) {
+			s.Lock()
+			s.stopSTerminator()
+			s.stopDebitLoops()
+			s.Unlock()
+			sS.storeSession(s)
+		}
+		return
+	}
 	var hasErr bool
 	for _, s := range sS.getSessions("", false) { // Force sessions shutdown
 		if err = sS.terminateSession(s, nil, nil, nil, false); err != nil {
@@ -540,7 +557,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 	now := time.Now()
 	if s.SRuns[sRunIdx].NextAutoDebit != nil &&
 		now.Before(*s.SRuns[sRunIdx].NextAutoDebit) {
-		time.Sleep(now.Sub(*s.SRuns[sRunIdx].NextAutoDebit))
+		time.Sleep(s.SRuns[sRunIdx].NextAutoDebit.Sub(now))
 	}
 	for {
 		s.Lock()
@@ -585,6 +602,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 			}
 		}
 		s.Unlock()
+		sS.storeSession(s)
 		sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
 		if maxDebit < dbtIvl { // disconnect faster
 			select {
@@ -621,6 +639,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 // refundSession will refund the extra usage debitted by the end of session
 // not thread-safe so the locks need to be done in a layer above
 // rUsage represents the amount of usage to be refunded
+// ToDo: refund with AccountSv1.RefundCharges once the sessions are debited out of AccountS
 func (sS *SessionS) refundSession(s *Session, sRunIdx int, rUsage time.Duration) (err error) {
 	if sRunIdx >= len(s.SRuns) {
 		return errors.New("sRunIdx out of range")
@@ -812,6 +831,74 @@ func (sS *SessionS) replicateSessions(cgrID string, psv bool, connIDs []string)
 	return
 }
 
+// storeSession checkpoints the active session into DataDB, if enabled
+// the Session should not be locked since it is locked here
+// so the session cannot end between the check and the store
+func (sS *SessionS) storeSession(s *Session) {
+	if !sS.cgrCfg.SessionSCfg().StoreSessions {
+		return
+	}
+	s.RLock()
+	defer s.RUnlock()
+	if !sS.isIndexed(s, false) { // ended in the meantime
+		return
+	}
+	ss := s.asStoredSession(sS.cgrCfg.GeneralCfg().NodeID)
+	if err := sS.dm.SetStoredSession(ss); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot store session with id <%s>, err: %s",
+				utils.SessionS, ss.ID, err.Error()))
+	}
+}
+
+// removeStoredSession removes the checkpoint of the session out of DataDB, if enabled
+func (sS *SessionS) removeStoredSession(tnt, cgrID string) {
+	if !sS.cgrCfg.SessionSCfg().StoreSessions {
+		return
+	}
+	if err := sS.dm.RemoveStoredSession(tnt, cgrID); err != nil &&
+		err != utils.ErrNotFound {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot remove stored session with id <%s>, err: %s",
+				utils.SessionS, cgrID, err.Error()))
+	}
+}
+
+// restoreSessions recovers the sessions of this node stored in DataDB
+// re-arming their debit loops and terminators
+func (sS *SessionS) restoreSessions() (err error) {
+	if sS.dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	var keys []string
+	if keys, err = sS.dm.DataDB().GetKeysForPrefix(utils.StoredSessionPrefix); err != nil {
+		return
+	}
+	for _, key := range keys {
+		tntID := utils.NewTenantID(strings.TrimPrefix(key, utils.StoredSessionPrefix))
+		ss, errGet := sS.dm.GetStoredSession(tntID.Tenant, tntID.ID)
+		if errGet != nil { // do not let one bad record block restoring the others
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot restore stored session <%s>, err: <%s>",
+					utils.SessionS, tntID.TenantID(), errGet.Error()))
+			continue
+		}
+		if ss.NodeID != sS.cgrCfg.GeneralCfg().NodeID { // owned by other node
+			continue
+		}
+		if len(sS.getSessions(ss.ID, false)) != 0 { // already active
+			continue
+		}
+		s := newSessionFromStored(ss)
+		s.Lock()
+		sS.registerSession(s, false)
+		sS.initSessionDebitLoops(s)
+		sS.setSTerminator(s, nil)
+		s.Unlock()
+	}
+	return
+}
+
 // registerSession will register an active or passive Session
 // called on init or relocate
 // not thread safe for the Session
@@ -1295,6 +1382,11 @@ func (sS *SessionS) transitSState(cgrID string, psv bool) (s *Session) {
 		s.stopDebitLoops()
 	}
 	s.Unlock()
+	if !psv {
+		sS.storeSession(s)
+	} else {
+		sS.removeStoredSession(s.Tenant, cgrID)
+	}
 	return
 }
 
@@ -1330,6 +1422,8 @@ func (sS *SessionS) relocateSession(initOriginID, originID, originHost string) (
 	}
 	s.Unlock()
 	sS.registerSession(s, false)
+	sS.removeStoredSession(s.Tenant, initCGRID)
+	sS.storeSession(s)
 	sS.replicateSessions(initCGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
 	return
 }
@@ -1470,6 +1564,7 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID,
 		sS.initSessionDebitLoops(s)
 		sS.registerSession(s, false)
 		s.Unlock()
+		sS.storeSession(s)
 	}
 	return
 }
@@ -1478,6 +1573,7 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID,
 func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMsg bool) (maxUsage map[string]time.Duration, err error) {
 	if !isMsg {
 		defer sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+		defer sS.storeSession(s)
 		s.Lock()
 		defer s.Unlock()
 
@@ -1524,6 +1620,37 @@ func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMs
 	return
 }
 
+// reservationUnits returns the units to reserve out of the event options
+func reservationUnits(opts engine.MapEvent) (rsrvUnits *time.Duration, err error) {
+	if !opts.HasField(utils.OptsReservationUnits) {
+		return
+	}
+	var units time.Duration
+	if units, err = opts.GetDuration(utils.OptsReservationUnits); err != nil {
+		return
+	}
+	return &units, nil
+}
+
+// quotaThreshold returns the remaining units out of maxUsage when the client should request more
+// the ratio from the event options has priority over the one configured for the ToR
+func (sS *SessionS) quotaThreshold(tor string, opts engine.MapEvent,
+	maxUsage time.Duration) (qtThld *time.Duration, err error) {
+	ratio := sS.cgrCfg.SessionSCfg().GetQuotaThreshold(tor)
+	if opts.HasField(utils.OptsQuotaThreshold) {
+		if ratio, err = opts.GetFloat64(utils.OptsQuotaThreshold); err != nil {
+			return
+		}
+	}
+	if ratio <= 0 { // no quota threshold
+		return
+	}
+	if ratio > 1 {
+		return nil, fmt.Errorf("invalid quota threshold: %v", ratio)
+	}
+	return utils.DurationPointer(maxUsage - time.Duration(ratio*float64(maxUsage))), nil
+}
+
 // terminateSession will end a session from outside
 // calls endSession thread safe
 func (sS *SessionS) terminateSession(s *Session, tUsage, lastUsage *time.Duration,
@@ -1542,6 +1669,7 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 		//check if we have replicate connection and close the session there
 		defer sS.replicateSessions(s.CGRID, true, sS.cgrCfg.SessionSCfg().ReplicationConns)
 		sS.unregisterSession(s.CGRID, false)
+		sS.removeStoredSession(s.Tenant, s.CGRID)
 		s.stopSTerminator()
 		s.stopDebitLoops()
 	}
@@ -2177,6 +2305,8 @@ type V1InitSessionReply struct {
 	Attributes         *engine.AttrSProcessEventReply `json:",omitempty"`
 	ResourceAllocation *string                        `json:",omitempty"`
 	MaxUsage           *time.Duration                 `json:",omitempty"`
+	QuotaThreshold     *time.Duration                 `json:",omitempty"` // remaining units when the client should request more
+	FinalUnit          *bool                          `json:",omitempty"` // no more units can be granted after MaxUsage
 	ThresholdIDs       *[]string                      `json:",omitempty"`
 	StatQueueIDs       *[]string                      `json:",omitempty"`
 
@@ -2213,6 +2343,12 @@ func (v1Rply *V1InitSessionReply) AsNavigableMap() utils.NavigableMap2 {
 	} else if v1Rply.needsMaxUsage {
 		cgrReply[utils.CapMaxUsage] = utils.NewNMData(0)
 	}
+	if v1Rply.QuotaThreshold != nil {
+		cgrReply[utils.CapQuotaThreshold] = utils.NewNMData(*v1Rply.QuotaThreshold)
+	}
+	if v1Rply.FinalUnit != nil {
+		cgrReply[utils.CapFinalUnit] = utils.NewNMData(*v1Rply.FinalUnit)
+	}
 
 	if v1Rply.ThresholdIDs != nil {
 		thIDs := make(utils.NMSlice, len(*v1Rply.ThresholdIDs))
@@ -2309,6 +2445,14 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 				return utils.NewErrRALs(err)
 			}
 		}
+		rsrvUnits, err := reservationUnits(opts)
+		if err != nil {
+			return utils.NewErrRALs(err)
+		}
+		if rsrvUnits != nil { // requested usage given by the reservation policy
+			args.CGREvent.Event[utils.Usage] = *rsrvUnits
+		}
+		tor := utils.IfaceAsString(args.CGREvent.Event[utils.ToR])
 		s, err := sS.initSession(args.CGREvent, sS.biJClntID(clnt), originID, dbtItvl,
 			false, args.ForceDuration)
 		if err != nil {
@@ -2318,7 +2462,14 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 		isPrepaid := s.debitStop != nil
 		s.RUnlock()
 		if isPrepaid { //active debit
-			rply.MaxUsage = utils.DurationPointer(sS.cgrCfg.SessionSCfg().GetDefaultUsage(utils.IfaceAsString(args.CGREvent.Event[utils.ToR])))
+			maxUsage := sS.cgrCfg.SessionSCfg().GetDefaultUsage(tor)
+			if rsrvUnits != nil {
+				maxUsage = *rsrvUnits
+			}
+			rply.MaxUsage = &maxUsage
+			if rply.QuotaThreshold, err = sS.quotaThreshold(tor, opts, maxUsage); err != nil {
+				return utils.NewErrRALs(err)
+			}
 		} else {
 			var sRunsUsage map[string]time.Duration
 			if sRunsUsage, err = sS.updateSession(s, nil, args.Opts, false); err != nil {
@@ -2334,6 +2485,16 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 				}
 			}
 			rply.MaxUsage = &maxUsage
+			if rply.QuotaThreshold, err = sS.quotaThreshold(tor, opts, maxUsage); err != nil {
+				return utils.NewErrRALs(err)
+			}
+			if rsrvUnits != nil || rply.QuotaThreshold != nil {
+				reqUsage, err := engine.MapEvent(args.CGREvent.Event).GetDuration(utils.Usage)
+				if err != nil {
+					reqUsage = sS.cgrCfg.SessionSCfg().GetDefaultUsage(tor)
+				}
+				rply.FinalUnit = utils.BoolPointer(maxUsage < reqUsage)
+			}
 		}
 	}
 	if args.ProcessThresholds {
@@ -2430,8 +2591,10 @@ type V1UpdateSessionArgs struct {
 
 // V1UpdateSessionReply contains options for session update reply
 type V1UpdateSessionReply struct {
-	Attributes *engine.AttrSProcessEventReply `json:",omitempty"`
-	MaxUsage   *time.Duration                 `json:",omitempty"`
+	Attributes     *engine.AttrSProcessEventReply `json:",omitempty"`
+	MaxUsage       *time.Duration                 `json:",omitempty"`
+	QuotaThreshold *time.Duration                 `json:",omitempty"` // remaining units when the client should request more
+	FinalUnit      *bool                          `json:",omitempty"` // no more units can be granted after MaxUsage
 
 	needsMaxUsage bool // for gob encoding only
 }
@@ -2463,6 +2626,12 @@ func (v1Rply *V1UpdateSessionReply) AsNavigableMap() utils.NavigableMap2 {
 	} else if v1Rply.needsMaxUsage {
 		cgrReply[utils.CapMaxUsage] = utils.NewNMData(0)
 	}
+	if v1Rply.QuotaThreshold != nil {
+		cgrReply[utils.CapQuotaThreshold] = utils.NewNMData(*v1Rply.QuotaThreshold)
+	}
+	if v1Rply.FinalUnit != nil {
+		cgrReply[utils.CapFinalUnit] = utils.NewNMData(*v1Rply.FinalUnit)
+	}
 	return cgrReply
 }
 
@@ -2521,6 +2690,13 @@ func (sS *SessionS) BiRPCv1UpdateSession(clnt rpcclient.ClientConnector,
 				return utils.NewErrRALs(err)
 			}
 		}
+		rsrvUnits, err := reservationUnits(opts)
+		if err != nil {
+			return utils.NewErrRALs(err)
+		}
+		if rsrvUnits != nil { // requested usage given by the reservation policy
+			ev[utils.Usage] = *rsrvUnits
+		}
 		cgrID := GetSetCGRID(ev)
 		s := sS.getRelocateSession(cgrID,
 			ev.GetStringIgnoreErrors(utils.InitialOriginID),
@@ -2545,6 +2721,15 @@ func (sS *SessionS) BiRPCv1UpdateSession(clnt rpcclient.ClientConnector,
 			}
 		}
 		rply.MaxUsage = &maxUsage
+		s.RLock()
+		tor := s.EventStart.GetStringIgnoreErrors(utils.ToR)
+		s.RUnlock()
+		if rply.QuotaThreshold, err = sS.quotaThreshold(tor, opts, maxUsage); err != nil {
+			return utils.NewErrRALs(err)
+		}
+		if rsrvUnits != nil || rply.QuotaThreshold != nil { // updateSession populates the requested Usage
+			rply.FinalUnit = utils.BoolPointer(maxUsage < ev.GetDurationIgnoreErrors(utils.Usage))
+		}
 	}
 	return
 }
@@ -4049,6 +4234,63 @@ func (sS *SessionS) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector,
 	return
 }
 
+func (sS *SessionS) alterSession(s *Session, ev map[string]interface{}) (err error) {
+	clnt := sS.biJClnt(s.ClientConnID)
+	if clnt == nil {
+		return fmt.Errorf("calling %s requires bidirectional JSON connection, connID: <%s>",
+			utils.SessionSv1AlterSession, s.ClientConnID)
+	}
+	s.RLock()
+	evStart := s.EventStart.Clone()
+	s.RUnlock()
+	var rply string
+	if err = clnt.conn.Call(utils.SessionSv1AlterSession,
+		utils.AttrAlterSession{
+			EventStart: evStart,
+			Event:      ev}, &rply); err == utils.ErrNotImplemented {
+		err = nil
+	}
+	return
+}
+
+// BiRPCv1AlterSession asks the agents to change the matching sessions(ie. CoA for RADIUS)
+func (sS *SessionS) BiRPCv1AlterSession(clnt rpcclient.ClientConnector,
+	args *utils.SessionFilterWithEvent, reply *string) (err error) {
+	if args == nil { //protection in case on nil
+		args = &utils.SessionFilterWithEvent{}
+	}
+	if args.SessionFilter == nil {
+		args.SessionFilter = &utils.SessionFilter{}
+	}
+	aSs := sS.filterSessions(args.SessionFilter, false)
+	if len(aSs) == 0 {
+		return utils.ErrNotFound
+	}
+	cache := utils.NewStringSet(nil)
+	for _, as := range aSs {
+		if cache.Has(as.CGRID) {
+			continue
+		}
+		cache.Add(as.CGRID)
+		ss := sS.getSessions(as.CGRID, false)
+		if len(ss) == 0 {
+			continue
+		}
+		if errAlt := sS.alterSession(ss[0], args.Event); errAlt != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf(
+					"<%s> failed altering session with id: <%s>, err: <%s>",
+					utils.SessionS, ss[0].cgrID(), errAlt.Error()))
+			err = utils.ErrPartiallyExecuted
+		}
+	}
+	if err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
 // BiRPCv1DisconnectPeer sends a DPR for the given OriginHost and OriginRealm
 func (sS *SessionS) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector,
 	args *utils.DPRArgs, reply *string) (err error) {
@@ -4185,6 +4427,9 @@ func (sS *SessionS) Handlers() map[string]interface{} {
 		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args *utils.SessionFilter, rply *string) (err error) {
 			return sS.BiRPCv1ReAuthorize(clnt, args, rply)
 		},
+		utils.SessionSv1AlterSession: func(clnt *rpc2.Client, args *utils.SessionFilterWithEvent, rply *string) (err error) {
+			return sS.BiRPCv1AlterSession(clnt, args, rply)
+		},
 		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
 			return sS.BiRPCv1DisconnectPeer(clnt, args, rply)
 		},
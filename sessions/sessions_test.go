@@ -1391,6 +1391,52 @@ func TestSessionSV1UpdateSessionReplyAsNavigableMap(t *testing.T) {
 	if rply := v1UpdtRpl.AsNavigableMap(); !reflect.DeepEqual(expected, rply) {
 		t.Errorf("Expecting \n%+v\n, received: \n%+v", expected, rply)
 	}
+	v1UpdtRpl.QuotaThreshold = utils.DurationPointer(time.Minute)
+	v1UpdtRpl.FinalUnit = utils.BoolPointer(true)
+	expected[utils.CapQuotaThreshold] = utils.NewNMData(time.Minute)
+	expected[utils.CapFinalUnit] = utils.NewNMData(true)
+	if rply := v1UpdtRpl.AsNavigableMap(); !reflect.DeepEqual(expected, rply) {
+		t.Errorf("Expecting \n%+v\n, received: \n%+v", expected, rply)
+	}
+}
+
+func TestSessionSReservationPolicy(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().QuotaThreshold = map[string]float64{
+		utils.MetaData: 0.8,
+	}
+	sS := NewSessionS(cfg, nil, nil)
+	if rsrv, err := reservationUnits(engine.MapEvent{}); err != nil {
+		t.Error(err)
+	} else if rsrv != nil {
+		t.Errorf("unexpected reservation units: %v", *rsrv)
+	}
+	if rsrv, err := reservationUnits(engine.MapEvent{
+		utils.OptsReservationUnits: "10485760"}); err != nil {
+		t.Error(err)
+	} else if rsrv == nil || *rsrv != 10485760 {
+		t.Errorf("unexpected reservation units: %v", rsrv)
+	}
+	if qt, err := sS.quotaThreshold(utils.MetaVoice, engine.MapEvent{}, time.Minute); err != nil {
+		t.Error(err)
+	} else if qt != nil {
+		t.Errorf("unexpected quota threshold: %v", *qt)
+	}
+	if qt, err := sS.quotaThreshold(utils.MetaData, engine.MapEvent{}, 10485760); err != nil {
+		t.Error(err)
+	} else if qt == nil || *qt != 2097152 {
+		t.Errorf("unexpected quota threshold: %v", qt)
+	}
+	if qt, err := sS.quotaThreshold(utils.MetaVoice, engine.MapEvent{
+		utils.OptsQuotaThreshold: 0.5}, time.Minute); err != nil {
+		t.Error(err)
+	} else if qt == nil || *qt != 30*time.Second {
+		t.Errorf("unexpected quota threshold: %v", qt)
+	}
+	if _, err := sS.quotaThreshold(utils.MetaData, engine.MapEvent{
+		utils.OptsQuotaThreshold: 1.5}, time.Minute); err == nil {
+		t.Error("expecting error for invalid quota threshold")
+	}
 }
 
 func TestSessionSV1ProcessMessageReplyAsNavigableMap(t *testing.T) {
@@ -2574,3 +2620,129 @@ func TestInitSession(t *testing.T) {
 func TestSessionSAsBiRPC(t *testing.T) {
 	_ = rpcclient.BiRPCConector(new(SessionS))
 }
+
+func TestSessionSStoreRestoreSessions(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().StoreSessions = true
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, nil)
+	nextDebit := time.Date(2020, 10, 1, 8, 0, 0, 0, time.UTC)
+	s := &Session{
+		CGRID:      "STORED_SESSION",
+		Tenant:     "cgrates.org",
+		EventStart: engine.MapEvent{utils.OriginID: "ORIGIN1"},
+		OptsStart:  engine.MapEvent{utils.OptsChargeable: false},
+		SRuns: []*SRun{{
+			Event:         engine.MapEvent{utils.RunID: utils.MetaDefault, utils.RequestType: utils.MetaPostpaid},
+			CD:            &engine.CallDescriptor{Tenant: "cgrates.org", LoopIndex: 2},
+			LastUsage:     time.Minute,
+			TotalUsage:    2 * time.Minute,
+			NextAutoDebit: &nextDebit,
+		}},
+	}
+	defer dm.RemoveStoredSession("cgrates.org", "STORED_SESSION")
+	sS.storeSession(s) // not active, nothing stored
+	if _, err := dm.GetStoredSession("cgrates.org", "STORED_SESSION"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	sS.registerSession(s, false)
+	if err := sS.Shutdown(); err != nil { // keeps the session stored
+		t.Error(err)
+	}
+	exp := &engine.StoredSession{
+		Tenant:     "cgrates.org",
+		ID:         "STORED_SESSION",
+		NodeID:     cfg.GeneralCfg().NodeID,
+		EventStart: engine.MapEvent{utils.OriginID: "ORIGIN1"},
+		OptsStart:  engine.MapEvent{utils.OptsChargeable: false},
+		SRuns: []*engine.StoredSRun{{
+			Event:         engine.MapEvent{utils.RunID: utils.MetaDefault, utils.RequestType: utils.MetaPostpaid},
+			CD:            &engine.CallDescriptor{Tenant: "cgrates.org", LoopIndex: 2},
+			LastUsage:     time.Minute,
+			TotalUsage:    2 * time.Minute,
+			NextAutoDebit: &nextDebit,
+		}},
+	}
+	if rcv, err := dm.GetStoredSession("cgrates.org", "STORED_SESSION"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	// a bad record does not stop restoring the others
+	if err := engine.Cache.Set(utils.CacheStoredSessions, "cgrates.org:BROKEN_SESSION", nil, nil,
+		true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveStoredSession("cgrates.org", "BROKEN_SESSION")
+	// restart
+	sS = NewSessionS(cfg, dm, nil)
+	if err := sS.restoreSessions(); err != nil {
+		t.Fatal(err)
+	}
+	rcv := sS.getSessions("STORED_SESSION", false)
+	if len(rcv) != 1 {
+		t.Fatalf("expecting one restored session, received: %s", utils.ToJSON(rcv))
+	}
+	if rcv[0].chargeable {
+		t.Error("expecting the session not chargeable")
+	}
+	if rcvSS := rcv[0].asStoredSession(cfg.GeneralCfg().NodeID); !reflect.DeepEqual(exp, rcvSS) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcvSS))
+	}
+	if !sS.isIndexed(rcv[0], false) {
+		t.Error("expecting the restored session to be indexed")
+	}
+
+	// sessions of other nodes are not restored
+	exp.ID = "OTHER_NODE_SESSION"
+	exp.NodeID = "OTHER_NODE"
+	if err := dm.SetStoredSession(exp); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveStoredSession("cgrates.org", "OTHER_NODE_SESSION")
+	sS = NewSessionS(cfg, dm, nil)
+	if err := sS.restoreSessions(); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := sS.getSessions("OTHER_NODE_SESSION", false); len(rcv) != 0 {
+		t.Errorf("unexpected restored session: %s", utils.ToJSON(rcv))
+	}
+
+	sS.removeStoredSession("cgrates.org", "STORED_SESSION")
+	if _, err := dm.GetStoredSession("cgrates.org", "STORED_SESSION"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestSessionSStoreSessionEnded(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().StoreSessions = true
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, nil)
+	s := &Session{
+		CGRID:      "ENDED_SESSION",
+		Tenant:     "cgrates.org",
+		EventStart: engine.MapEvent{utils.OriginID: "ORIGIN1"},
+	}
+	defer dm.RemoveStoredSession("cgrates.org", "ENDED_SESSION")
+	sS.registerSession(s, false)
+	s.Lock() // the session is ending
+	stored := make(chan struct{})
+	go func() {
+		sS.storeSession(s)
+		close(stored)
+	}()
+	select {
+	case <-stored:
+		t.Fatal("expecting the store to wait for the session to end")
+	case <-time.After(10 * time.Millisecond):
+	}
+	sS.unregisterSession(s.CGRID, false)
+	sS.removeStoredSession(s.Tenant, s.CGRID)
+	s.Unlock()
+	<-stored
+	if _, err := dm.GetStoredSession("cgrates.org", "ENDED_SESSION"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -35,6 +35,7 @@ type AccountProfile struct {
 	Opts               map[string]interface{}
 	Balances           map[string]*Balance
 	ThresholdIDs       []string
+	RefundIDs          map[string]time.Time // refunds already applied on the account, protecting against double refunds
 }
 
 // BalancesAltered detects altering of the Balances by comparing the Balance values with the ones from backup
@@ -74,16 +75,17 @@ func (ap *AccountProfile) AccountBalancesBackup() (abb AccountBalancesBackup) {
 
 // Balance represents one Balance inside an Account
 type Balance struct {
-	ID             string // Balance identificator, unique within an Account
-	FilterIDs      []string
-	Weights        DynamicWeights
-	Type           string
-	Units          *Decimal
-	UnitFactors    []*UnitFactor
-	Opts           map[string]interface{}
-	CostIncrements []*CostIncrement
-	AttributeIDs   []string
-	RateProfileIDs []string
+	ID                 string // Balance identificator, unique within an Account
+	FilterIDs          []string
+	ActivationInterval *ActivationInterval // the balance is considered for debits only inside this interval
+	Weights            DynamicWeights
+	Type               string
+	Units              *Decimal
+	UnitFactors        []*UnitFactor
+	Opts               map[string]interface{}
+	CostIncrements     []*CostIncrement
+	AttributeIDs       []string
+	RateProfileIDs     []string
 }
 
 // CostIncrement enforces cost calculation to specific balance increments
@@ -172,6 +174,12 @@ func (aP *AccountProfile) Clone() (acnt *AccountProfile) {
 			acnt.ThresholdIDs[i] = value
 		}
 	}
+	if aP.RefundIDs != nil {
+		acnt.RefundIDs = make(map[string]time.Time, len(aP.RefundIDs))
+		for key, value := range aP.RefundIDs {
+			acnt.RefundIDs[key] = value
+		}
+	}
 	return
 }
 
@@ -189,9 +197,10 @@ func (aI *ActivationInterval) Clone() *ActivationInterval {
 //Clone return a clone of the Balance
 func (bL *Balance) Clone() (blnc *Balance) {
 	blnc = &Balance{
-		ID:      bL.ID,
-		Weights: bL.Weights.Clone(),
-		Type:    bL.Type,
+		ID:                 bL.ID,
+		ActivationInterval: bL.ActivationInterval.Clone(),
+		Weights:            bL.Weights.Clone(),
+		Type:               bL.Type,
 	}
 	if bL.FilterIDs != nil {
 		blnc.FilterIDs = make([]string, len(bL.FilterIDs))
@@ -235,6 +244,19 @@ func (bL *Balance) Clone() (blnc *Balance) {
 	return
 }
 
+// IsActiveAt returns true if the balance can be used at the given time
+func (bL *Balance) IsActiveAt(atTime time.Time) bool {
+	return bL.ActivationInterval == nil ||
+		bL.ActivationInterval.IsActiveAtTime(atTime)
+}
+
+// IsExpiredAt returns true if the balance ExpiryTime has passed at the given time
+func (bL *Balance) IsExpiredAt(atTime time.Time) bool {
+	return bL.ActivationInterval != nil &&
+		!bL.ActivationInterval.ExpiryTime.IsZero() &&
+		!bL.ActivationInterval.ExpiryTime.After(atTime)
+}
+
 // AccountProfileWithWeight attaches static weight to AccountProfile
 type AccountProfileWithWeight struct {
 	*AccountProfile
@@ -325,6 +347,12 @@ type ArgsAccountsForEvent struct {
 	AccountIDs []string
 }
 
+// ArgsAccountsRefundCharges are used to give back the units debited for an event
+type ArgsAccountsRefundCharges struct {
+	*CGREvent    // CGRID field is mandatory, used to protect against double refunds
+	EventCharges *ExtEventCharges
+}
+
 type ReplyMaxUsage struct {
 	AccountID string
 	MaxUsage  time.Duration
@@ -371,28 +399,30 @@ func (ext *APIAccountProfile) AsAccountProfile() (profile *AccountProfile, err e
 
 // APIBalance represents one APIBalance inside an APIAccount
 type APIBalance struct {
-	ID             string // Balance identificator, unique within an Account
-	FilterIDs      []string
-	Weights        string
-	Type           string
-	Units          float64
-	UnitFactors    []*APIUnitFactor
-	Opts           map[string]interface{}
-	CostIncrements []*APICostIncrement
-	AttributeIDs   []string
-	RateProfileIDs []string
+	ID                 string // Balance identificator, unique within an Account
+	FilterIDs          []string
+	ActivationInterval *ActivationInterval
+	Weights            string
+	Type               string
+	Units              float64
+	UnitFactors        []*APIUnitFactor
+	Opts               map[string]interface{}
+	CostIncrements     []*APICostIncrement
+	AttributeIDs       []string
+	RateProfileIDs     []string
 }
 
 // AsBalance convert APIBalance struct to Balance struct
 func (ext *APIBalance) AsBalance() (balance *Balance, err error) {
 	balance = &Balance{
-		ID:             ext.ID,
-		FilterIDs:      ext.FilterIDs,
-		Type:           ext.Type,
-		Units:          NewDecimalFromFloat64(ext.Units),
-		Opts:           ext.Opts,
-		AttributeIDs:   ext.AttributeIDs,
-		RateProfileIDs: ext.RateProfileIDs,
+		ID:                 ext.ID,
+		FilterIDs:          ext.FilterIDs,
+		ActivationInterval: ext.ActivationInterval,
+		Type:               ext.Type,
+		Units:              NewDecimalFromFloat64(ext.Units),
+		Opts:               ext.Opts,
+		AttributeIDs:       ext.AttributeIDs,
+		RateProfileIDs:     ext.RateProfileIDs,
 	}
 	if ext.Weights != EmptyString {
 		if balance.Weights, err = NewDynamicWeightsFromString(ext.Weights, ";", "&"); err != nil {
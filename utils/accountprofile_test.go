@@ -30,6 +30,9 @@ func TestCloneBalance(t *testing.T) {
 	expBlc := &Balance{
 		ID:        "TEST_ID1",
 		FilterIDs: []string{"*string:~*req.Account:1001"},
+		ActivationInterval: &ActivationInterval{
+			ExpiryTime: time.Date(2020, 7, 22, 10, 0, 0, 0, time.UTC),
+		},
 		Weights: DynamicWeights{
 			{
 				Weight: 1.1,
@@ -265,3 +268,32 @@ func TestAPIBalanceAsBalance(t *testing.T) {
 	}
 
 }
+
+func TestBalanceIsActiveAt(t *testing.T) {
+	blnc := &Balance{ID: "TEST_ID1"}
+	atTime := time.Date(2020, 7, 21, 10, 0, 0, 0, time.UTC)
+	if !blnc.IsActiveAt(atTime) {
+		t.Error("Expected balance without ActivationInterval to be active")
+	} else if blnc.IsExpiredAt(atTime) {
+		t.Error("Expected balance without ActivationInterval to not expire")
+	}
+	blnc.ActivationInterval = &ActivationInterval{
+		ActivationTime: atTime.Add(-time.Hour),
+		ExpiryTime:     atTime.Add(time.Hour),
+	}
+	if !blnc.IsActiveAt(atTime) {
+		t.Error("Expected balance to be active")
+	} else if blnc.IsExpiredAt(atTime) {
+		t.Error("Expected balance to not be expired")
+	}
+	if blnc.IsActiveAt(atTime.Add(-2 * time.Hour)) {
+		t.Error("Expected balance to not be active yet")
+	} else if blnc.IsExpiredAt(atTime.Add(-2 * time.Hour)) {
+		t.Error("Expected balance to not be expired before activation")
+	}
+	if blnc.IsActiveAt(atTime.Add(time.Hour)) {
+		t.Error("Expected balance to not be active at expiry")
+	} else if !blnc.IsExpiredAt(atTime.Add(time.Hour)) {
+		t.Error("Expected balance to be expired")
+	}
+}
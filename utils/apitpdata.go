@@ -892,6 +892,7 @@ type TPResourceProfile struct {
 	Stored             bool
 	Weight             float64  // Weight to sort the ResourceLimits
 	ThresholdIDs       []string // Thresholds to check after changing Limit
+	RateWindow         string   // Limit applies to the units allocated within this sliding window
 }
 
 // TPActivationInterval represents an activation interval for an item
@@ -987,6 +988,12 @@ type AttrDisconnectSession struct {
 	Reason     string
 }
 
+// AttrAlterSession is sent by SessionS to the agents in order to change an active session
+type AttrAlterSession struct {
+	EventStart map[string]interface{}
+	Event      map[string]interface{} // the new values for the session
+}
+
 //MetricWithFilters is used in TPStatProfile
 type MetricWithFilters struct {
 	FilterIDs []string
@@ -1008,6 +1015,11 @@ type TPStatProfile struct {
 	Weight             float64
 	MinItems           int
 	ThresholdIDs       []string
+	GroupBy            string
+	GroupTTL           string
+	WindowType         string
+	WindowLength       string
+	WindowHistory      int
 }
 
 // TPThresholdProfile is used in APIs to manage remotely offline ThresholdProfile
@@ -1024,6 +1036,8 @@ type TPThresholdProfile struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	RecoveryFilterIDs  []string
+	RecoveryActionIDs  []string
 }
 
 // TPFilterProfile is used in APIs to manage remotely offline FilterProfile
@@ -1066,6 +1080,8 @@ type TPRouteProfile struct {
 	SortingParameters  []string
 	Routes             []*TPRoute
 	Weight             float64
+	BreakerFailures    int    // consecutive failures blocking a route, 0 disables the circuit breaker
+	BreakerCoolDown    string // time a blocked route is kept out before probing it again
 }
 
 // TPAttribute is used in TPAttributeProfile
@@ -1375,6 +1391,12 @@ type SessionFilter struct {
 	Opts    map[string]interface{}
 }
 
+// SessionFilterWithEvent is used to alter the filtered sessions with the values in Event
+type SessionFilterWithEvent struct {
+	*SessionFilter
+	Event map[string]interface{}
+}
+
 type RatingPlanCostArg struct {
 	RatingPlanIDs []string
 	Destination   string
@@ -1464,6 +1486,9 @@ type TPRateProfile struct {
 	MinCost            float64
 	MaxCost            float64
 	MaxCostStrategy    string
+	Currency           string
+	VolumeCounter      string
+	VolumeReset        string
 	Rates              map[string]*TPRate
 }
 
@@ -1504,6 +1529,23 @@ func (args *ArgsCostForEvent) StartTime(tmz string) (sTime time.Time, err error)
 	return time.Now(), nil
 }
 
+// Currency returns the currency requested for the cost, empty if the cost should not be converted
+func (args *ArgsCostForEvent) Currency() (currency string) {
+	if cIface, has := args.Opts[OptsRatesCurrency]; has {
+		return IfaceAsString(cIface)
+	}
+	return
+}
+
+// UpdateVolume returns true if the usage of the event should be added to the volume counter
+// set by the caller charging the event, once per event
+func (args *ArgsCostForEvent) UpdateVolume() (upd bool, err error) {
+	if uIface, has := args.Opts[OptsRatesUpdateVolume]; has {
+		return IfaceAsBool(uIface)
+	}
+	return
+}
+
 // usage returns the event time used to check active rate profiles
 func (args *ArgsCostForEvent) Usage() (usage time.Duration, err error) {
 	// first search for the usage in opts
@@ -1529,6 +1571,7 @@ type TPActionProfile struct {
 	ActivationInterval *TPActivationInterval
 	Weight             float64
 	Schedule           string
+	MissedRuns         string
 	Targets            []*TPActionTarget
 	Actions            []*TPAPAction
 }
@@ -1561,17 +1604,18 @@ type TPAccountProfile struct {
 }
 
 type TPAccountBalance struct {
-	ID             string
-	FilterIDs      []string
-	Weights        string
-	Blocker        bool
-	Type           string
-	Opts           string
-	CostIncrement  []*TPBalanceCostIncrement
-	AttributeIDs   []string
-	RateProfileIDs []string
-	UnitFactors    []*TPBalanceUnitFactor
-	Units          float64
+	ID                 string
+	FilterIDs          []string
+	Weights            string
+	Blocker            bool
+	Type               string
+	Opts               string
+	CostIncrement      []*TPBalanceCostIncrement
+	AttributeIDs       []string
+	RateProfileIDs     []string
+	UnitFactors        []*TPBalanceUnitFactor
+	Units              float64
+	ActivationInterval *TPActivationInterval // the balance is considered for debits only inside this interval
 }
 
 func NewTPBalanceCostIncrement(filtersStr, incrementStr, fixedFeeStr, recurrentFeeStr string) (costIncrement *TPBalanceCostIncrement, err error) {
@@ -1656,3 +1700,18 @@ type ArgActionSv1ScheduleActions struct {
 	*CGREvent
 	ActionProfileIDs []string
 }
+
+// ArgActionSv1GetExecutions is used to list the actions in progress
+type ArgActionSv1GetExecutions struct {
+	Tenant           string
+	ActionProfileIDs []string // all the executions of the tenant if empty
+	Opts             map[string]interface{}
+}
+
+// ArgActionSv1GetActionSchedules is used to list the past and upcoming executions of the scheduled ActionProfiles
+type ArgActionSv1GetActionSchedules struct {
+	Tenant           string
+	ActionProfileIDs []string // all the ActionProfiles of the tenant if empty
+	Upcoming         int      // number of upcoming runs returned for each ActionProfile, defaults to 1
+	Opts             map[string]interface{}
+}
@@ -40,10 +40,11 @@ var (
 		MetaSQSjsonMap:    ContentJSON,
 		MetaKafkajsonMap:  ContentJSON,
 		MetaS3jsonMap:     ContentJSON,
+		MetaNatsjsonMap:   ContentJSON,
 	}
 
 	extraDBPartition = NewStringSet([]string{CacheDispatchers,
-		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses, CacheClosedSessions,
+		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRadiusPackets, CacheRPCResponses, CacheClosedSessions,
 		CacheCDRIDs, CacheRPCConnections, CacheUCH, CacheSTIR, CacheEventCharges, MetaAPIBan,
 		CacheCapsEvents, CacheVersions})
 
@@ -56,7 +57,9 @@ var (
 		CacheAttributeFilterIndexes, CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs,
 		CacheRatingProfilesTmp, CacheRateProfiles, CacheRateProfilesFilterIndexes, CacheRateFilterIndexes,
 		CacheActionProfilesFilterIndexes, CacheAccountProfilesFilterIndexes, CacheReverseFilterIndexes,
-		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts})
+		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts,
+		CacheCurrencyConversions, CacheRateVolumeCounters, CacheActionSchedules, CacheStatQueueHistories,
+		CacheStoredSessions})
 
 	storDBPartition = NewStringSet([]string{CacheTBLTPTimings, CacheTBLTPDestinations, CacheTBLTPRates, CacheTBLTPDestinationRates,
 		CacheTBLTPRatingPlans, CacheTBLTPRatingProfiles, CacheTBLTPSharedGroups, CacheTBLTPActions,
@@ -94,6 +97,11 @@ var (
 		CacheRateProfiles:                 RateProfilePrefix,
 		CacheActionProfiles:               ActionProfilePrefix,
 		CacheAccountProfiles:              AccountProfilePrefix,
+		CacheCurrencyConversions:          CurrencyConversionsPrefix,
+		CacheRateVolumeCounters:           RateVolumeCounterPrefix,
+		CacheActionSchedules:              ActionSchedulePrefix,
+		CacheStatQueueHistories:           StatQueueHistoryPrefix,
+		CacheStoredSessions:               StoredSessionPrefix,
 		CacheResourceFilterIndexes:        ResourceFilterIndexes,
 		CacheStatFilterIndexes:            StatFilterIndexes,
 		CacheThresholdFilterIndexes:       ThresholdFilterIndexes,
@@ -201,6 +209,7 @@ var (
 		ActionProfileIDs:      ActionProfilePrefix,
 
 		TimingIDs:                     TimingsPrefix,
+		CurrencyConversionsIDs:        CurrencyConversionsPrefix,
 		AttributeFilterIndexIDs:       AttributeFilterIndexes,
 		ResourceFilterIndexIDs:        ResourceFilterIndexes,
 		StatFilterIndexIDs:            StatFilterIndexes,
@@ -241,6 +250,7 @@ var (
 		ActionProfileIDs:      CacheActionProfiles,
 
 		TimingIDs:                     CacheTimings,
+		CurrencyConversionsIDs:        CacheCurrencyConversions,
 		AttributeFilterIndexIDs:       CacheAttributeFilterIndexes,
 		ResourceFilterIndexIDs:        CacheResourceFilterIndexes,
 		StatFilterIndexIDs:            CacheStatFilterIndexes,
@@ -288,6 +298,13 @@ const (
 	MetaSingle               = "*single"
 	MetaZero                 = "*zero"
 	MetaASAP                 = "*asap"
+	MetaSkip                 = "*skip"
+	MetaRunOnce              = "*run_once"
+	MetaRunAll               = "*run_all"
+	MetaTumbling             = "*tumbling"
+	MetaSliding              = "*sliding"
+	MetaOK                   = "*ok"
+	MetaAlarm                = "*alarm"
 	CommentChar              = '#'
 	CSVSep                   = ','
 	FallbackSep              = ';'
@@ -403,6 +420,11 @@ const (
 	RateProfilePrefix         = "rtp_"
 	ActionProfilePrefix       = "acp_"
 	AccountProfilePrefix      = "anp_"
+	CurrencyConversionsPrefix = "ccv_"
+	RateVolumeCounterPrefix   = "rvc_"
+	ActionSchedulePrefix      = "acs_"
+	StatQueueHistoryPrefix    = "sqh_"
+	StoredSessionPrefix       = "sts_"
 	DispatcherHostPrefix      = "dph_"
 	ThresholdProfilePrefix    = "thp_"
 	StatQueuePrefix           = "stq_"
@@ -425,6 +447,7 @@ const (
 	MetaSQL                   = "*sql"
 	MetaMySQL                 = "*mysql"
 	MetaS3jsonMap             = "*s3_json_map"
+	MetaNatsjsonMap           = "*nats_json_map"
 	ConfigPath                = "/etc/cgrates/"
 	DisconnectCause           = "DisconnectCause"
 	MetaFlatstore             = "*flatstore"
@@ -598,6 +621,10 @@ const (
 	ResourceID            = "ResourceID"
 	TotalUsage            = "TotalUsage"
 	StatID                = "StatID"
+	ThresholdID           = "ThresholdID"
+	State                 = "State"
+	PreviousState         = "PreviousState"
+	Hits                  = "Hits"
 	BalanceType           = "BalanceType"
 	BalanceID             = "BalanceID"
 	BalanceDestinationIds = "BalanceDestinationIds"
@@ -610,9 +637,12 @@ const (
 	BalanceBlocker        = "BalanceBlocker"
 	BalanceDisabled       = "BalanceDisabled"
 	Units                 = "Units"
+	InitialUnits          = "InitialUnits"
+	BalanceIDs            = "BalanceIDs"
 	AccountUpdate         = "AccountUpdate"
 	BalanceUpdate         = "BalanceUpdate"
 	StatUpdate            = "StatUpdate"
+	ThresholdUpdate       = "ThresholdUpdate"
 	ResourceUpdate        = "ResourceUpdate"
 	CDR                   = "CDR"
 	CDRs                  = "CDRs"
@@ -645,9 +675,16 @@ const (
 	MetaQOS                  = "*qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
+	MetaLCQOS                = "*lc_qos"
+	MetaMissingStats         = "*missing_stats"
+	MetaPass                 = "*pass"
+	MetaLast                 = "*last"
+	MetaDrop                 = "*drop"
 	Weight                   = "Weight"
+	Weights                  = "Weights"
 	Limit                    = "Limit"
 	UsageTTL                 = "UsageTTL"
+	RateWindow               = "RateWindow"
 	AllocationMessage        = "AllocationMessage"
 	Stored                   = "Stored"
 	RatingSubject            = "RatingSubject"
@@ -705,6 +742,8 @@ const (
 	MaxHits                  = "MaxHits"
 	MinHits                  = "MinHits"
 	Async                    = "Async"
+	RecoveryFilterIDs        = "RecoveryFilterIDs"
+	RecoveryActionIDs        = "RecoveryActionIDs"
 	Sorting                  = "Sorting"
 	SortingParameters        = "SortingParameters"
 	RouteAccountIDs          = "RouteAccountIDs"
@@ -714,6 +753,8 @@ const (
 	RouteWeight              = "RouteWeight"
 	RouteParameters          = "RouteParameters"
 	RouteBlocker             = "RouteBlocker"
+	BreakerFailures          = "BreakerFailures"
+	BreakerCoolDown          = "BreakerCoolDown"
 	RouteResourceIDs         = "RouteResourceIDs"
 	RouteFilterIDs           = "RouteFilterIDs"
 	AttributeFilterIDs       = "AttributeFilterIDs"
@@ -722,6 +763,11 @@ const (
 	MinItems                 = "MinItems"
 	MetricIDs                = "MetricIDs"
 	MetricFilterIDs          = "MetricFilterIDs"
+	GroupBy                  = "GroupBy"
+	GroupTTL                 = "GroupTTL"
+	WindowType               = "WindowType"
+	WindowLength             = "WindowLength"
+	WindowHistory            = "WindowHistory"
 	FieldName                = "FieldName"
 	Path                     = "Path"
 	MetaRound                = "*round"
@@ -799,6 +845,7 @@ const (
 	CGRDebitInterval      = "CGRDebitInterval"
 	VersionName           = "Version"
 	MetaTenant            = "*tenant"
+	MetaAccount           = "*account"
 	ResourceUsage         = "ResourceUsage"
 	MetaDuration          = "*duration"
 	MetaLibPhoneNumber    = "*libphonenumber"
@@ -812,6 +859,7 @@ const (
 	MetaReload            = "*reload"
 	MetaLoad              = "*load"
 	MetaRemove            = "*remove"
+	MetaReset             = "*reset"
 	MetaRemoveAll         = "*removeall"
 	MetaStore             = "*store"
 	MetaClear             = "*clear"
@@ -832,10 +880,18 @@ const (
 	Preference            = "Preference"
 	Flags                 = "Flags"
 	Service               = "Service"
+	Ttl                   = "Ttl"
+	DNSA                  = "A"
+	DNSAAAA               = "AAAA"
+	Priority              = "Priority"
+	Port                  = "Port"
+	Target                = "Target"
+	Txt                   = "Txt"
 	ApierV                = "ApierV"
 	MetaApier             = "*apier"
 	MetaAnalyzer          = "*analyzer"
 	CGREventString        = "CGREvent"
+	EventChargesString    = "EventCharges"
 	MetaTextPlain         = "*text_plain"
 	MetaIgnoreErrors      = "*ignore_errors"
 	MetaRelease           = "*release"
@@ -877,10 +933,21 @@ const (
 	OrderIDStart          = "OrderIDStart"
 	OrderIDEnd            = "OrderIDEnd"
 	MinCost               = "MinCost"
+	Currency              = "Currency"
+	FromCurrency          = "FromCurrency"
+	ToCurrency            = "ToCurrency"
+	ExchangeRate          = "ExchangeRate"
+	ExchangeRates         = "ExchangeRates"
+	VolumeCounter         = "VolumeCounter"
+	VolumeReset           = "VolumeReset"
+	CGREventsFld          = "CGREvents"
 	MaxCost               = "MaxCost"
 	MetaLoaders           = "*loaders"
 	TmpSuffix             = ".tmp"
 	MetaDiamreq           = "*diamreq"
+	MetaDiameter          = "*diameter"
+	MetaRadius            = "*radius"
+	MetaSIP               = "*sip"
 	MetaCost              = "*cost"
 	MetaGroup             = "*group"
 	InternalRPCSet        = "InternalRPCSet"
@@ -948,6 +1015,7 @@ const (
 	BalanceOpts           = "BalanceOpts"
 	MetaConcrete          = "*concrete"
 	MetaAbstract          = "*abstract"
+	MetaBalance           = "*balance"
 	MetaBalanceLimit      = "*balanceLimit"
 	MetaBalanceUnlimited  = "*balanceUnlimited"
 	MetaTemplateID        = "*templateID"
@@ -989,22 +1057,27 @@ const (
 	MetaAttributes          = "*attributes"
 	MetaActionProfiles      = "*action_profiles"
 	MetaAccountProfiles     = "*account_profiles"
+	MetaCurrencyConversions = "*currency_conversions"
 	MetaLoadIDs             = "*load_ids"
 )
 
 // MetaMetrics
 const (
-	MetaASR      = "*asr"
-	MetaACD      = "*acd"
-	MetaTCD      = "*tcd"
-	MetaACC      = "*acc"
-	MetaTCC      = "*tcc"
-	MetaPDD      = "*pdd"
-	MetaDDC      = "*ddc"
-	MetaSum      = "*sum"
-	MetaAverage  = "*average"
-	MetaDistinct = "*distinct"
-	MetaRAR      = "*rar"
+	MetaASR        = "*asr"
+	MetaACD        = "*acd"
+	MetaTCD        = "*tcd"
+	MetaACC        = "*acc"
+	MetaTCC        = "*tcc"
+	MetaPDD        = "*pdd"
+	MetaDDC        = "*ddc"
+	MetaSum        = "*sum"
+	MetaAverage    = "*average"
+	MetaDistinct   = "*distinct"
+	MetaHighest    = "*highest"
+	MetaLowest     = "*lowest"
+	MetaStdDev     = "*stddev"
+	MetaPercentile = "*percentile"
+	MetaRAR        = "*rar"
 )
 
 // Services
@@ -1062,6 +1135,7 @@ const (
 	MetaRemoveAccount           = "*remove_account"
 	MetaSetBalance              = "*set_balance"
 	MetaRemoveBalance           = "*remove_balance"
+	MetaResetBalance            = "*reset_balance"
 	MetaTopUpReset              = "*topup_reset"
 	MetaTopUp                   = "*topup"
 	MetaDebitReset              = "*debit_reset"
@@ -1089,8 +1163,10 @@ const (
 	ActionID                    = "ActionID"
 	ActionType                  = "ActionType"
 	ActionValue                 = "ActionValue"
+	MissedRuns                  = "MissedRuns"
 	BalanceValue                = "BalanceValue"
 	BalanceUnits                = "BalanceUnits"
+	BalanceActivationInterval   = "BalanceActivationInterval"
 	ExtraParameters             = "ExtraParameters"
 )
 
@@ -1123,6 +1199,8 @@ const (
 	CapAttributes           = "Attributes"
 	CapResourceAllocation   = "ResourceAllocation"
 	CapMaxUsage             = "MaxUsage"
+	CapQuotaThreshold       = "QuotaThreshold"
+	CapFinalUnit            = "FinalUnit"
 	CapRoutes               = "Routes"
 	CapThresholds           = "Thresholds"
 	CapStatQueues           = "StatQueues"
@@ -1165,6 +1243,9 @@ const (
 	MetaRoundRobin     = "*round_robin"
 	MetaRatio          = "*ratio"
 	MetaDefaultRatio   = "*default_ratio"
+	MetaHash           = "*hash"
+	MetaHashFields     = "*hash_fields"
+	MetaHashFailover   = "*hash_failover"
 	ThresholdSv1       = "ThresholdSv1"
 	StatSv1            = "StatSv1"
 	ResourceSv1        = "ResourceSv1"
@@ -1559,9 +1640,10 @@ const (
 )
 
 const (
-	RateSv1             = "RateSv1"
-	RateSv1CostForEvent = "RateSv1.CostForEvent"
-	RateSv1Ping         = "RateSv1.Ping"
+	RateSv1              = "RateSv1"
+	RateSv1CostForEvent  = "RateSv1.CostForEvent"
+	RateSv1CostForEvents = "RateSv1.CostForEvents"
+	RateSv1Ping          = "RateSv1.Ping"
 )
 
 const (
@@ -1570,6 +1652,9 @@ const (
 	AccountSv1AccountProfilesForEvent = "AccountSv1.AccountProfilesForEvent"
 	AccountSv1MaxUsage                = "AccountSv1.MaxUsage"
 	AccountSv1DebitUsage              = "AccountSv1.DebitUsage"
+	AccountSv1RefundCharges           = "AccountSv1.RefundCharges"
+	AccountSv1MaxCost                 = "AccountSv1.MaxCost"
+	AccountSv1DebitCost               = "AccountSv1.DebitCost"
 )
 
 const (
@@ -1585,6 +1670,7 @@ const (
 	RouteSv1GetRoutes                = "RouteSv1.GetRoutes"
 	RouteSv1GetRouteProfilesForEvent = "RouteSv1.GetRouteProfilesForEvent"
 	RouteSv1Ping                     = "RouteSv1.Ping"
+	RouteSv1ReportRouteOutcome       = "RouteSv1.ReportRouteOutcome"
 	APIerSv1GetRouteProfile          = "APIerSv1.GetRouteProfile"
 	APIerSv1GetRouteProfileIDs       = "APIerSv1.GetRouteProfileIDs"
 	APIerSv1RemoveRouteProfile       = "APIerSv1.RemoveRouteProfile"
@@ -1640,6 +1726,7 @@ const (
 	StatSv1GetStatQueue            = "StatSv1.GetStatQueue"
 	StatSv1V1GetQueueIDs           = "StatSv1.GetQueueIDs"
 	StatSv1ResetStatQueue          = "StatSv1.ResetStatQueue"
+	StatSv1GetQueueHistory         = "StatSv1.GetQueueHistory"
 	APIerSv1GetStatQueueProfile    = "APIerSv1.GetStatQueueProfile"
 	APIerSv1RemoveStatQueueProfile = "APIerSv1.RemoveStatQueueProfile"
 	APIerSv1SetStatQueueProfile    = "APIerSv1.SetStatQueueProfile"
@@ -1689,6 +1776,7 @@ const (
 	SessionSv1DeactivateSessions         = "SessionSv1.DeactivateSessions"
 	SMGenericV1InitiateSession           = "SMGenericV1.InitiateSession"
 	SessionSv1ReAuthorize                = "SessionSv1.ReAuthorize"
+	SessionSv1AlterSession               = "SessionSv1.AlterSession"
 	SessionSv1DisconnectPeer             = "SessionSv1.DisconnectPeer"
 	SessionSv1WarnDisconnect             = "SessionSv1.WarnDisconnect"
 	SessionSv1STIRAuthenticate           = "SessionSv1.STIRAuthenticate"
@@ -1737,6 +1825,13 @@ const (
 	APIerSv1RemoveRateProfileRates = "APIerSv1.RemoveRateProfileRates"
 )
 
+// CurrencyConversions APIs
+const (
+	APIerSv1SetCurrencyConversions    = "APIerSv1.SetCurrencyConversions"
+	APIerSv1GetCurrencyConversions    = "APIerSv1.GetCurrencyConversions"
+	APIerSv1RemoveCurrencyConversions = "APIerSv1.RemoveCurrencyConversions"
+)
+
 // AnalyzerS APIs
 const (
 	AnalyzerSv1            = "AnalyzerSv1"
@@ -1841,29 +1936,30 @@ const (
 
 //CSV file name
 const (
-	TimingsCsv            = "Timings.csv"
-	DestinationsCsv       = "Destinations.csv"
-	RatesCsv              = "Rates.csv"
-	DestinationRatesCsv   = "DestinationRates.csv"
-	RatingPlansCsv        = "RatingPlans.csv"
-	RatingProfilesCsv     = "RatingProfiles.csv"
-	SharedGroupsCsv       = "SharedGroups.csv"
-	ActionsCsv            = "Actions.csv"
-	ActionPlansCsv        = "ActionPlans.csv"
-	ActionTriggersCsv     = "ActionTriggers.csv"
-	AccountActionsCsv     = "AccountActions.csv"
-	ResourcesCsv          = "Resources.csv"
-	StatsCsv              = "Stats.csv"
-	ThresholdsCsv         = "Thresholds.csv"
-	FiltersCsv            = "Filters.csv"
-	RoutesCsv             = "Routes.csv"
-	AttributesCsv         = "Attributes.csv"
-	ChargersCsv           = "Chargers.csv"
-	DispatcherProfilesCsv = "DispatcherProfiles.csv"
-	DispatcherHostsCsv    = "DispatcherHosts.csv"
-	RateProfilesCsv       = "RateProfiles.csv"
-	ActionProfilesCsv     = "ActionProfiles.csv"
-	AccountProfilesCsv    = "AccountProfiles.csv"
+	TimingsCsv             = "Timings.csv"
+	DestinationsCsv        = "Destinations.csv"
+	RatesCsv               = "Rates.csv"
+	DestinationRatesCsv    = "DestinationRates.csv"
+	RatingPlansCsv         = "RatingPlans.csv"
+	RatingProfilesCsv      = "RatingProfiles.csv"
+	SharedGroupsCsv        = "SharedGroups.csv"
+	ActionsCsv             = "Actions.csv"
+	ActionPlansCsv         = "ActionPlans.csv"
+	ActionTriggersCsv      = "ActionTriggers.csv"
+	AccountActionsCsv      = "AccountActions.csv"
+	ResourcesCsv           = "Resources.csv"
+	StatsCsv               = "Stats.csv"
+	ThresholdsCsv          = "Thresholds.csv"
+	FiltersCsv             = "Filters.csv"
+	RoutesCsv              = "Routes.csv"
+	AttributesCsv          = "Attributes.csv"
+	ChargersCsv            = "Chargers.csv"
+	DispatcherProfilesCsv  = "DispatcherProfiles.csv"
+	DispatcherHostsCsv     = "DispatcherHosts.csv"
+	RateProfilesCsv        = "RateProfiles.csv"
+	ActionProfilesCsv      = "ActionProfiles.csv"
+	AccountProfilesCsv     = "AccountProfiles.csv"
+	CurrencyConversionsCsv = "CurrencyConversions.csv"
 )
 
 // Table Name
@@ -1928,6 +2024,11 @@ const (
 	CacheRateProfiles                 = "*rate_profiles"
 	CacheActionProfiles               = "*action_profiles"
 	CacheAccountProfiles              = "*account_profiles"
+	CacheCurrencyConversions          = "*currency_conversions"
+	CacheRateVolumeCounters           = "*rate_volume_counters"
+	CacheActionSchedules              = "*action_schedules"
+	CacheStatQueueHistories           = "*statqueue_histories"
+	CacheStoredSessions               = "*stored_sessions"
 	CacheResourceFilterIndexes        = "*resource_filter_indexes"
 	CacheStatFilterIndexes            = "*stat_filter_indexes"
 	CacheThresholdFilterIndexes       = "*threshold_filter_indexes"
@@ -1936,6 +2037,7 @@ const (
 	CacheChargerFilterIndexes         = "*charger_filter_indexes"
 	CacheDispatcherFilterIndexes      = "*dispatcher_filter_indexes"
 	CacheDiameterMessages             = "*diameter_messages"
+	CacheRadiusPackets                = "*radius_packets"
 	CacheRPCResponses                 = "*rpc_responses"
 	CacheClosedSessions               = "*closed_sessions"
 	CacheRateProfilesFilterIndexes    = "*rate_profile_filter_indexes"
@@ -2010,6 +2112,7 @@ const (
 	AsteriskAgent   = "AsteriskAgent"
 	HTTPAgent       = "HTTPAgent"
 	SIPAgent        = "SIPAgent"
+	SMPPAgent       = "SMPPAgent"
 )
 
 // Google_API
@@ -2187,6 +2290,8 @@ const (
 	ChargerSConnsCfg       = "chargers_conns"
 	AttributeSConnsCfg     = "attributes_conns"
 	RetransmissionTimerCfg = "retransmission_timer"
+	SystemIDCfg            = "system_id"
+	BindCredentialsCfg     = "bind_credentials"
 	OnlineCDRExportsCfg    = "online_cdr_exports"
 	SessionCostRetires     = "session_cost_retries"
 	RateSConnsCfg          = "rates_conns"
@@ -2205,6 +2310,7 @@ const (
 	RemoteConnsCfg         = "remote_conns"
 	DebitIntervalCfg       = "debit_interval"
 	StoreSCostsCfg         = "store_session_costs"
+	StoreSessionsCfg       = "store_sessions"
 	SessionTTLCfg          = "session_ttl"
 	SessionTTLMaxDelayCfg  = "session_ttl_max_delay"
 	SessionTTLLastUsedCfg  = "session_ttl_last_used"
@@ -2217,6 +2323,7 @@ const (
 	AlterableFieldsCfg     = "alterable_fields"
 	MinDurLowBalanceCfg    = "min_dur_low_balance"
 	DefaultUsageCfg        = "default_usage"
+	QuotaThresholdCfg      = "quota_threshold"
 	STIRCfg                = "stir"
 
 	AllowedAtestCfg       = "allowed_attest"
@@ -2282,6 +2389,9 @@ const (
 	ListenAcctCfg         = "listen_acct"
 	ClientSecretsCfg      = "client_secrets"
 	ClientDictionariesCfg = "client_dictionaries"
+	ClientDAAddressesCfg  = "client_da_addresses"
+	DMRTemplateCfg        = "dmr_template"
+	CoATemplateCfg        = "coa_template"
 
 	// AttributeSCfg
 	IndexedSelectsCfg = "indexed_selects"
@@ -2345,8 +2455,15 @@ const (
 	ShutdownTimeoutCfg   = "shutdown_timeout"
 
 	// AccountSCfg
-	MaxIterations = "max_iterations"
-	MaxUsage      = "max_usage"
+	MaxIterations      = "max_iterations"
+	MaxUsage           = "max_usage"
+	SweepIntervalCfg   = "sweep_interval"
+	ExpiredBalancesCfg = "expired_balances"
+	RefundIDsTTLCfg    = "refund_ids_ttl"
+
+	// ActionSCfg
+	ExecutionsHistoryCfg = "executions_history"
+	MaxMissedRunsCfg     = "max_missed_runs"
 )
 
 // FC Template
@@ -2501,12 +2618,14 @@ var (
 )
 
 // CGROptionsSet the possible cgr options
-var CGROptionsSet = NewStringSet([]string{OptsRatesStartTime, OptsRatesUsage, OptsSessionsTTL,
-	OptsSessionsTTLMaxDelay, OptsSessionsTTLLastUsed, OptsSessionsTTLLastUsage, OptsSessionsTTLUsage,
+var CGROptionsSet = NewStringSet([]string{OptsRatesStartTime, OptsRatesUsage, OptsRatesCurrency,
+	OptsRatesUpdateVolume, OptsSessionsTTL, OptsSessionsTTLMaxDelay, OptsSessionsTTLLastUsed,
+	OptsSessionsTTLLastUsage, OptsSessionsTTLUsage,
 	OptsDebitInterval, OptsStirATest, OptsStirPayloadMaxDuration, OptsStirIdentity,
 	OptsStirOriginatorTn, OptsStirOriginatorURI, OptsStirDestinationTn, OptsStirDestinationURI,
 	OptsStirPublicKeyPath, OptsStirPrivateKeyPath, OptsAPIKey, OptsRouteID, OptsContext,
-	OptsAttributesProcessRuns, OptsRoutesLimit, OptsRoutesOffset, OptsChargeable})
+	OptsAttributesProcessRuns, OptsRoutesLimit, OptsRoutesOffset, OptsChargeable,
+	OptsReservationUnits, OptsQuotaThreshold})
 
 // EventExporter metrics
 const (
@@ -2532,6 +2651,8 @@ const (
 	OptsRoutesOffset         = "*routes_offset"
 	OptsRatesStartTime       = "*ratesStartTime"
 	OptsRatesUsage           = "*ratesUsage"
+	OptsRatesCurrency        = "*ratesCurrency"
+	OptsRatesUpdateVolume    = "*ratesUpdateVolume"
 	OptsSessionsTTL          = "*sessionsTTL"
 	OptsSessionsTTLMaxDelay  = "*sessionsTTLMaxDelay"
 	OptsSessionsTTLLastUsed  = "*sessionsTTLLastUsed"
@@ -2539,6 +2660,8 @@ const (
 	OptsSessionsTTLUsage     = "*sessionsTTLUsage"
 	OptsDebitInterval        = "*sessionsDebitInterval"
 	OptsChargeable           = "*sessionsChargeable"
+	OptsReservationUnits     = "*sessionsReservationUnits"
+	OptsQuotaThreshold       = "*sessionsQuotaThreshold"
 	// STIR
 	OptsStirATest              = "*stirATest"
 	OptsStirPayloadMaxDuration = "*stirPayloadMaxDuration"
@@ -2623,6 +2746,7 @@ const (
 	RateProfileIDs                = "RateProfileIDs"
 	ActionProfileIDs              = "ActionProfileIDs"
 	TimingIDs                     = "TimingIDs"
+	CurrencyConversionsIDs        = "CurrencyConversionsIDs"
 	AttributeFilterIndexIDs       = "AttributeFilterIndexIDs"
 	ResourceFilterIndexIDs        = "ResourceFilterIndexIDs"
 	StatFilterIndexIDs            = "StatFilterIndexIDs"
@@ -2650,6 +2774,11 @@ const (
 	KafkaGroupID  = "groupID"
 	KafkaMaxWait  = "maxWait"
 
+	NatsSubject      = "natsSubject"
+	NatsQueueID      = "natsQueueID"
+	NatsJetStream    = "natsJetStream"
+	NatsConsumerName = "natsConsumerName"
+
 	// General constants for posters
 	DefaultQueueID      = "cgrates_cdrs"
 	QueueID             = "queueID"
@@ -2666,6 +2795,8 @@ const (
 	KafkaDefaultGroupID = "cgrates"
 	KafkaDefaultMaxWait = time.Millisecond
 
+	NatsDefaultConsumerName = "cgrates"
+
 	SQLDBName         = "dbName"
 	SQLTableName      = "tableName"
 	SQLSSLMode        = "sslmode"
@@ -2765,10 +2896,13 @@ var (
 
 // ActionSv1
 const (
-	ActionSv1                = "ActionSv1"
-	ActionSv1Ping            = "ActionSv1.Ping"
-	ActionSv1ScheduleActions = "ActionSv1.ScheduleActions"
-	ActionSv1ExecuteActions  = "ActionSv1.ExecuteActions"
+	ActionSv1                   = "ActionSv1"
+	ActionSv1Ping               = "ActionSv1.Ping"
+	ActionSv1ScheduleActions    = "ActionSv1.ScheduleActions"
+	ActionSv1ExecuteActions     = "ActionSv1.ExecuteActions"
+	ActionSv1GetActionSchedules = "ActionSv1.GetActionSchedules"
+	ActionSv1GetExecutions      = "ActionSv1.GetExecutions"
+	ActionSv1CancelExecution    = "ActionSv1.CancelExecution"
 )
 
 // Time duration suffix
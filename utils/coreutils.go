@@ -770,6 +770,13 @@ func (tID *TenantIDWithCache) TenantID() string {
 	return ConcatenatedKey(tID.Tenant, tID.ID)
 }
 
+// TenantWithCache is used for the items stored once per tenant
+type TenantWithCache struct {
+	Tenant string
+	Cache  *string
+	Opts   map[string]interface{}
+}
+
 // RPCCall is a generic method calling RPC on a struct instance
 // serviceMethod is assumed to be in the form InstanceV1.Method
 // where V1Method will become RPC method called on instance
@@ -98,6 +98,11 @@ func NewDataConverter(params string) (conv DataConverter, err error) {
 			layout = params[len(MetaTimeString)+1:]
 		}
 		return NewTimeStringConverter(layout), nil
+	case strings.HasPrefix(params, MetaPrefix):
+		if len(params) == len(MetaPrefix) {
+			return NewPrefixConverter(EmptyString)
+		}
+		return NewPrefixConverter(params[len(MetaPrefix)+1:])
 	case strings.HasPrefix(params, MetaRandom):
 		if len(params) == len(MetaRandom) { // no extra params, defaults implied
 			return NewRandomConverter(EmptyString)
@@ -446,3 +451,28 @@ func (rC *RandomConverter) Convert(in interface{}) (
 		}
 	}
 }
+
+// NewPrefixConverter expects the number of characters to keep as params
+func NewPrefixConverter(params string) (hdlr DataConverter, err error) {
+	pC := new(PrefixConverter)
+	if pC.Length, err = strconv.Atoi(params); err != nil || pC.Length <= 0 {
+		return nil, fmt.Errorf("%s converter needs positive integer as length, have: <%s>",
+			MetaPrefix, params)
+	}
+	return pC, nil
+}
+
+// PrefixConverter keeps only the first Length characters of the value
+type PrefixConverter struct {
+	Length int
+}
+
+// Convert implements DataConverter interface
+func (pC *PrefixConverter) Convert(in interface{}) (
+	out interface{}, err error) {
+	inStr := IfaceAsString(in)
+	if len(inStr) <= pC.Length {
+		return inStr, nil
+	}
+	return inStr[:pC.Length], nil
+}
@@ -900,3 +900,27 @@ func TestRandomConverter(t *testing.T) {
 		t.Errorf("Expecting bigger than 10 and smaller than 20, received: %+v", rcv)
 	}
 }
+
+func TestPrefixConverter(t *testing.T) {
+	exp := &PrefixConverter{Length: 4}
+	if cnv, err := NewDataConverter("*prefix:4"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, cnv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, cnv)
+	}
+	if rcv, err := exp.Convert("+4986517174963"); err != nil {
+		t.Error(err)
+	} else if rcv != "+498" {
+		t.Errorf("Expecting: +498, received: %+v", rcv)
+	}
+	if rcv, err := exp.Convert(100); err != nil {
+		t.Error(err)
+	} else if rcv != "100" {
+		t.Errorf("Expecting: 100, received: %+v", rcv)
+	}
+	for _, params := range []string{"*prefix", "*prefix:a", "*prefix:0"} {
+		if _, err := NewDataConverter(params); err == nil {
+			t.Errorf("Expecting error for converter: <%s>", params)
+		}
+	}
+}
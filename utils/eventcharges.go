@@ -36,11 +36,21 @@ type EventCharges struct {
 	Account    *AccountProfile
 	Accounting *ChargedAccounting
 	Rating     *ChargedRating
+
+	BalanceCharges []*BalanceCharge // units debited out of each balance, used on refunds
+}
+
+// BalanceCharge records the units debited out of one Balance
+type BalanceCharge struct {
+	AccountID string
+	BalanceID string
+	Units     *Decimal
 }
 
 // Merge will merge the event charges into existing
 func (ec *EventCharges) Merge(eCs ...*EventCharges) {
 	for _, nEc := range eCs {
+		ec.BalanceCharges = append(ec.BalanceCharges, nEc.BalanceCharges...)
 		if ec.Usage == nil {
 			ec.Usage = nEc.Usage
 			continue
@@ -66,12 +76,60 @@ func (ec *EventCharges) AsExtEventCharges() (eEc *ExtEventCharges, err error) {
 			eEc.Cost = &flt
 		}
 	}
+	if ec.BalanceCharges != nil {
+		eEc.BalanceCharges = make([]*ExtBalanceCharge, len(ec.BalanceCharges))
+		for i, bC := range ec.BalanceCharges {
+			eEc.BalanceCharges[i] = &ExtBalanceCharge{
+				AccountID: bC.AccountID,
+				BalanceID: bC.BalanceID,
+			}
+			if bC.Units != nil {
+				if flt, ok := bC.Units.Big.Float64(); !ok {
+					return nil, errors.New("cannot convert decimal Units to float64")
+				} else {
+					eEc.BalanceCharges[i].Units = &flt
+				}
+			}
+		}
+	}
 	// add here code for the rest of the fields
 	return
 }
 
 // ExtEventCharges is a generic EventCharges used in APIs
 type ExtEventCharges struct {
-	Usage *float64
-	Cost  *float64
+	Usage          *float64
+	Cost           *float64
+	BalanceCharges []*ExtBalanceCharge
+}
+
+// ExtBalanceCharge is a generic BalanceCharge used in APIs
+type ExtBalanceCharge struct {
+	AccountID string
+	BalanceID string
+	Units     *float64
+}
+
+// AsEventCharges converts ExtEventCharges to EventCharges
+func (eEc *ExtEventCharges) AsEventCharges() (ec *EventCharges) {
+	ec = NewEventCharges()
+	if eEc.Usage != nil {
+		ec.Usage = NewDecimalFromFloat64(*eEc.Usage)
+	}
+	if eEc.Cost != nil {
+		ec.Cost = NewDecimalFromFloat64(*eEc.Cost)
+	}
+	if eEc.BalanceCharges != nil {
+		ec.BalanceCharges = make([]*BalanceCharge, len(eEc.BalanceCharges))
+		for i, bC := range eEc.BalanceCharges {
+			ec.BalanceCharges[i] = &BalanceCharge{
+				AccountID: bC.AccountID,
+				BalanceID: bC.BalanceID,
+			}
+			if bC.Units != nil {
+				ec.BalanceCharges[i].Units = NewDecimalFromFloat64(*bC.Units)
+			}
+		}
+	}
+	return
 }